@@ -0,0 +1,278 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/wwengg/protoc-gen-simple/simple"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var batchSizeFlag = flag.Int("batch_size", 100, "rows per INSERT statement of the BatchCreate implementations")
+
+// batchMethods returns the batch methods of the repository of the model
+// modelName. BatchDelete and GetByIds are only generated for single column
+// keys.
+func batchMethods(g *protogen.GeneratedFile, modelName string, key modelKey, preload bool) ([]repositoryMethod, error) {
+	ctx := g.QualifiedGoIdent(contextPackage.Ident("Context"))
+	methods := []repositoryMethod{{
+		name:     "BatchCreate",
+		doc:      "批量创建，每条 INSERT 语句最多 batchSize 行",
+		params:   fmt.Sprintf("ctx %s, list []*%s, batchSize int", ctx, modelName),
+		results:  "error",
+		body:     "return r.db.WithContext(ctx)" + omitAssociations(g, preload) + ".CreateInBatches(list, batchSize).Error",
+		args:     "ctx, list, batchSize",
+		funcName: "BatchCreate" + modelName,
+	}, {
+		name:    "BatchUpdate",
+		doc:     "在一个事务中逐条 Update",
+		params:  fmt.Sprintf("ctx %s, list []*%s", ctx, modelName),
+		results: "error",
+		body: fmt.Sprintf(`return r.db.WithContext(ctx).Transaction(func(tx *%s) error {
+				for _, a := range list {
+					if err := New%sRepository(tx).Update(ctx, a); err != nil {
+						return err
+					}
+				}
+				return nil
+			})`, g.QualifiedGoIdent(GormPackage.Ident("DB")), modelName),
+		args:     "ctx, list",
+		funcName: "BatchUpdate" + modelName,
+	}}
+	column, goType, err := singleKeyColumn(g, key)
+	if err != nil || column == "" {
+		return methods, err
+	}
+	query := "r.db.WithContext(ctx)"
+	if preload {
+		query = "Preload" + modelName + "(" + query + ")"
+	}
+	return append(methods, repositoryMethod{
+		name:     "BatchDelete",
+		doc:      "按主键批量删除",
+		params:   fmt.Sprintf("ctx %s, ids []%s", ctx, goType),
+		results:  "error",
+		body:     fmt.Sprintf(`return r.db.WithContext(ctx).Where("%s IN ?", ids).Delete(&%s{}).Error`, column, modelName),
+		args:     "ctx, ids",
+		funcName: "BatchDelete" + modelName,
+	}, repositoryMethod{
+		name:    "GetByIds",
+		doc:     "按主键批量查询",
+		params:  fmt.Sprintf("ctx %s, ids []%s", ctx, goType),
+		results: fmt.Sprintf("(list []%s, err error)", modelName),
+		body: fmt.Sprintf(`err = %s.Where("%s IN ?", ids).Find(&list).Error
+			return`, query, column),
+		args:     "ctx, ids",
+		funcName: "Get" + modelName + "ByIds",
+	}), nil
+}
+
+// singleKeyColumn returns the column and Go type of a single column primary
+// key, "" for a composite key.
+func singleKeyColumn(g *protogen.GeneratedFile, key modelKey) (string, string, error) {
+	switch len(key.fields) {
+	case 0:
+		return "id", "int64", nil
+	case 1:
+		ct, err := fieldColumnType(g, key.fields[0], simple.Storage_STORAGE_AUTO)
+		return columnName(key.fields[0]), ct.goType, err
+	}
+	return "", "", nil
+}
+
+// batchMethodBody returns the statements implementing method with the
+// BatchCreate, BatchUpdate, BatchDelete and Find<X>ByIds conventions for the
+// service model, using the model repository repo. It returns "" when the
+// method follows none of them. When the reply has a repeated results field
+// of the reply codes, every item is handled on its own and gets its code; a
+// deleted id that does not exist gets FindError. The batch is rolled back
+// when an item failed, and the items that succeeded get the error code too.
+func batchMethodBody(g *protogen.GeneratedFile, file *protogen.File, model *protogen.Message, key modelKey, method *protogen.Method, repo string) (string, error) {
+	name := upperFirstLatter(method.GoName)
+	modelPath := modelPackage(file).importPath
+	commonPath := commonPackage(file)
+	serviceName := modelName(model)
+	success := g.QualifiedGoIdent(commonPath.Ident("EnumCode_Success"))
+	var op, errorCode string
+	switch {
+	case strings.HasPrefix(name, "BatchCreate"):
+		op, errorCode = "Create", "EnumCode_CreateError"
+	case strings.HasPrefix(name, "BatchUpdate"):
+		op, errorCode = "Update", "EnumCode_UpdateError"
+	case strings.HasPrefix(name, "BatchDelete"):
+		op, errorCode = "Delete", "EnumCode_DeleteError"
+	case strings.HasPrefix(name, "Find") && strings.HasSuffix(name, "ByIds"):
+		op, errorCode = "GetByIds", "EnumCode_FindError"
+	default:
+		return "", nil
+	}
+	errorCode = g.QualifiedGoIdent(commonPath.Ident(errorCode))
+	var conflict, conflictResult string
+	if op == "Update" {
+		var err error
+		if conflict, err = versionConflictCase(g, file, model, "reply.Code = %s"); err != nil {
+			return "", err
+		}
+		if conflictResult, err = versionConflictCase(g, file, model, `reply.Results = append(reply.Results, %[1]s)
+					reply.Code = %[1]s`); err != nil {
+			return "", err
+		}
+	}
+	results := messageField(method.Output, "Results")
+	if results != nil && (results.Desc.Kind() != protoreflect.EnumKind || !results.Desc.IsList()) {
+		return "", fmt.Errorf("field %s: the results of %s must be repeated reply codes", results.Desc.FullName(), name)
+	}
+	if op == "Delete" || op == "GetByIds" {
+		ids, err := batchIds(g, model, key, method.Input, modelPath.Ident(serviceName))
+		if err != nil {
+			return "", err
+		}
+		if op == "GetByIds" {
+			return fmt.Sprintf(`if list,err := %s.GetByIds(ctx, args.Ids);err == nil{
+				for _, v := range list {
+					reply.List = append(reply.List, v.Proto())
+				}
+				reply.Code = %s
+			}else{
+				reply.Code = %s
+			}`, repo, success, errorCode), nil
+		}
+		if results == nil {
+			return fmt.Sprintf(`if err = %s.BatchDelete(ctx, args.Ids);err == nil{
+				reply.Code = %s
+			}else{
+				reply.Code = %s
+			}`, repo, success, errorCode), nil
+		}
+		loop := fmt.Sprintf(`for _, id := range args.Ids {
+				err := repo.Transaction(ctx, func(repo %[1]s) error {
+					return repo.Delete(ctx, %[2]s)
+				})
+				switch {
+				case err == nil:
+					reply.Results = append(reply.Results, %[3]s)
+				case %[4]s(err, %[5]s):
+					reply.Results = append(reply.Results, %[6]s)
+					reply.Code = %[6]s
+				default:
+					reply.Results = append(reply.Results, %[7]s)
+					reply.Code = %[7]s
+				}
+			}`, g.QualifiedGoIdent(modelPath.Ident(serviceName+"Repository")), ids, success, g.QualifiedGoIdent(errorsPackage.Ident("Is")),
+			g.QualifiedGoIdent(GormPackage.Ident("ErrRecordNotFound")), g.QualifiedGoIdent(commonPath.Ident("EnumCode_FindError")), errorCode)
+		return batchTransaction(g, file, model, repo, errorCode, loop), nil
+	}
+	list := modelListField(model, method.Input)
+	if list == nil {
+		return "", fmt.Errorf("message %s: %s requires a repeated %s field", method.Input.Desc.FullName(), name, model.Desc.Name())
+	}
+	toModel := g.QualifiedGoIdent(modelPath.Ident(serviceName + "ProtoToModel"))
+	if results != nil {
+		repoType := g.QualifiedGoIdent(modelPath.Ident(serviceName + "Repository"))
+		loop := fmt.Sprintf(`list := make([]*%[1]s, 0, len(args.%[2]s))
+			for _, v := range args.%[2]s {
+				list = append(list, %[3]s(v))
+			}
+`, g.QualifiedGoIdent(modelPath.Ident(serviceName)), list.GoName, toModel)
+		if op == "Create" {
+			loop += fmt.Sprintf(`// 整批创建失败时逐条创建，找出失败的记录
+			if repo.Transaction(ctx, func(repo %[1]s) error {
+				return repo.BatchCreate(ctx, list, %[2]d)
+			}) == nil {
+				for range list {
+					reply.Results = append(reply.Results, %[3]s)
+				}
+				return nil
+			}
+`, repoType, *batchSizeFlag, success)
+		}
+		loop += fmt.Sprintf(`for _, a := range list {
+				if err := repo.Transaction(ctx, func(repo %[1]s) error {
+					return repo.%[2]s(ctx, a)
+				});err == nil{
+					reply.Results = append(reply.Results, %[3]s)
+				%[5]s}else{
+					reply.Results = append(reply.Results, %[4]s)
+					reply.Code = %[4]s
+				}
+			}`, repoType, op, success, errorCode, conflictResult)
+		return batchTransaction(g, file, model, repo, errorCode, loop), nil
+	}
+	batchArgs := "ctx, list"
+	if op == "Create" {
+		batchArgs += ", " + strconv.Itoa(*batchSizeFlag)
+	}
+	return fmt.Sprintf(`list := make([]*%[7]s, 0, len(args.%[5]s))
+			for _, v := range args.%[5]s {
+				list = append(list, %[6]s(v))
+			}
+			if err = %[1]s.Batch%[2]s(%[8]s);err == nil{
+				reply.Code = %[3]s
+			%[9]s}else{
+				reply.Code = %[4]s
+			}`, repo, op, success, errorCode, list.GoName, toModel, g.QualifiedGoIdent(modelPath.Ident(serviceName)), batchArgs, conflict), nil
+}
+
+// batchTransaction returns the statements running loop, which sets the
+// result of every item of a batch, in a transaction of the model repository
+// repo. The loop runs each item in a nested transaction, so that the next
+// ones still run after it failed, and the batch is rolled back when one
+// failed. None of the items is saved then, so the results of the ones that
+// succeeded are set to errorCode as well.
+func batchTransaction(g *protogen.GeneratedFile, file *protogen.File, model *protogen.Message, repo, errorCode, loop string) string {
+	return fmt.Sprintf(`reply.Code = %[1]s
+		batchFailed := %[2]s("batch failed")
+		err = %[3]s.Transaction(ctx, func(repo %[4]s) error {
+			%[5]s
+			if reply.Code != %[1]s {
+				return batchFailed
+			}
+			return nil
+		})
+		if err != nil {
+			if err != batchFailed {
+				reply.Code = %[6]s
+			}
+			// 整批已回滚，成功的记录也没有保存
+			for i, code := range reply.Results {
+				if code == %[1]s {
+					reply.Results[i] = %[6]s
+				}
+			}
+		}`, g.QualifiedGoIdent(commonPackage(file).Ident("EnumCode_Success")), g.QualifiedGoIdent(errorsPackage.Ident("New")), repo,
+		g.QualifiedGoIdent(modelPackage(file).importPath.Ident(modelName(model)+"Repository")), loop, errorCode)
+}
+
+// modelListField returns the first repeated field of input holding models
+// of message.
+func modelListField(message, input *protogen.Message) *protogen.Field {
+	for _, field := range input.Fields {
+		if field.Desc.IsList() && field.Message == message {
+			return field
+		}
+	}
+	return nil
+}
+
+// batchIds checks the repeated ids field of input holding the primary keys of
+// the model of message, and returns the literal of model holding the key id.
+func batchIds(g *protogen.GeneratedFile, message *protogen.Message, key modelKey, input *protogen.Message, model protogen.GoIdent) (string, error) {
+	if len(key.fields) > 1 {
+		return "", fmt.Errorf("message %s: batch operations by ids require a single column primary key", message.Desc.FullName())
+	}
+	want := protoreflect.Int64Kind
+	if len(key.fields) == 1 {
+		want = key.fields[0].Desc.Kind()
+	}
+	ids := messageField(input, "Ids")
+	if ids == nil || !ids.Desc.IsList() || ids.Desc.Kind() != want || want == protoreflect.EnumKind {
+		return "", fmt.Errorf("message %s: batch operations on %s require a repeated %v ids field", input.Desc.FullName(), message.Desc.Name(), want)
+	}
+	if len(key.fields) == 0 {
+		return fmt.Sprintf("%s{BASE_MODEL: %s{ID: id}}", g.QualifiedGoIdent(model), g.QualifiedGoIdent(storePackage().Ident("BASE_MODEL"))), nil
+	}
+	return fmt.Sprintf("%s{%s: %s}", g.QualifiedGoIdent(model), key.fields[0].GoName, modelValue(key.fields[0], "id")), nil
+}
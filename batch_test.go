@@ -0,0 +1,31 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBatchTransaction(t *testing.T) {
+	gen := testPlugin(t, testFile("batch", `message_type {
+		name: "ItemModel" options { [simple.model]: true }
+		field { name: "name" number: 1 type: TYPE_STRING }
+	}`))
+	file := gen.Files[len(gen.Files)-1]
+	g := gen.NewGeneratedFile("item_service.go", "example.com/app/pb/batch/impl")
+	got := batchTransaction(g, file, testMessage(t, gen, "ItemModel"), "s.repository()", "batch.EnumCode_CreateError", "// loop")
+	// The items saved before the failed one are rolled back with it.
+	want := `if err != nil {
+			if err != batchFailed {
+				reply.Code = batch.EnumCode_CreateError
+			}
+			// 整批已回滚，成功的记录也没有保存
+			for i, code := range reply.Results {
+				if code == batch.EnumCode_Success {
+					reply.Results[i] = batch.EnumCode_CreateError
+				}
+			}
+		}`
+	if !strings.Contains(got, want) {
+		t.Errorf("batch transaction does not fail the rolled back items:\n%s", got)
+	}
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/wwengg/protoc-gen-simple/simple"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var enumAsString = flag.Bool("enum_as_string", false, "store enum fields as their value name instead of their number")
+
+// columnType is the Go type and SQL column type a proto kind is stored as.
+type columnType struct {
+	goType  string
+	sqlType string
+}
+
+// kindColumnTypes maps every protobuf kind to its model column type. Enums are
+// stored as their number. Messages and groups, like every field stored as
+// JSON, hold the generated proto type, serialized with protojson when it holds
+// messages and by the gorm json serializer otherwise.
+var kindColumnTypes = map[protoreflect.Kind]columnType{
+	protoreflect.BoolKind:     {"bool", "tinyint(1)"},
+	protoreflect.EnumKind:     {"int32", "int"},
+	protoreflect.Int32Kind:    {"int32", "int"},
+	protoreflect.Sint32Kind:   {"int32", "int"},
+	protoreflect.Sfixed32Kind: {"int32", "int"},
+	protoreflect.Uint32Kind:   {"uint32", "int unsigned"},
+	protoreflect.Fixed32Kind:  {"uint32", "int unsigned"},
+	protoreflect.Int64Kind:    {"int64", "bigint"},
+	protoreflect.Sint64Kind:   {"int64", "bigint"},
+	protoreflect.Sfixed64Kind: {"int64", "bigint"},
+	protoreflect.Uint64Kind:   {"uint64", "bigint unsigned"},
+	protoreflect.Fixed64Kind:  {"uint64", "bigint unsigned"},
+	protoreflect.FloatKind:    {"float32", "float"},
+	protoreflect.DoubleKind:   {"float64", "double"},
+	protoreflect.StringKind:   {"string", "varchar(255)"},
+	protoreflect.BytesKind:    {"[]byte", "blob"},
+	protoreflect.MessageKind:  {"", "json"},
+	protoreflect.GroupKind:    {"", "json"},
+}
+
+// enumStringColumnType is used for enums when enum_as_string is set.
+var enumStringColumnType = columnType{"string", "varchar(64)"}
+
+// fieldStorage returns how field is stored in the model. Relations, including
+// the ones declared with STORAGE_CHILD_TABLE and STORAGE_FOREIGN_KEY, are
+// reported as STORAGE_RELATION.
+func fieldStorage(field *protogen.Field) (simple.Storage, error) {
+	storage := columnOptions(field).GetStorage()
+	relation := fieldRelation(field)
+	if isOneofField(field) && field.Message != nil {
+		if storage != simple.Storage_STORAGE_AUTO && storage != simple.Storage_STORAGE_JSON || relation != simple.RelationType_RELATION_NONE {
+			return storage, fmt.Errorf("field %s: %v is not supported in a oneof", field.Desc.FullName(), storage)
+		}
+		return simple.Storage_STORAGE_JSON, nil
+	}
+	if relation != simple.RelationType_RELATION_NONE {
+		if columnOptions(field).GetRelation().GetType() != simple.RelationType_RELATION_NONE && storage != simple.Storage_STORAGE_AUTO && storage != simple.Storage_STORAGE_RELATION {
+			return storage, fmt.Errorf("field %s: relation cannot be combined with %v", field.Desc.FullName(), storage)
+		}
+		if field.Message == nil || field.Desc.IsMap() || !isModel(field.Message) {
+			return storage, fmt.Errorf("field %s: %v requires a field of a model message", field.Desc.FullName(), relation)
+		}
+		switch relation {
+		case simple.RelationType_RELATION_HAS_MANY, simple.RelationType_RELATION_MANY_TO_MANY:
+			if !field.Desc.IsList() {
+				return storage, fmt.Errorf("field %s: %v requires a repeated field", field.Desc.FullName(), relation)
+			}
+		default:
+			if field.Desc.IsList() {
+				return storage, fmt.Errorf("field %s: %v requires a singular field", field.Desc.FullName(), relation)
+			}
+		}
+		return simple.Storage_STORAGE_RELATION, nil
+	}
+	switch storage {
+	case simple.Storage_STORAGE_AUTO:
+		if isWellKnownColumn(field) {
+			return storage, nil
+		}
+		if field.Desc.IsList() || field.Desc.IsMap() || field.Message != nil {
+			return simple.Storage_STORAGE_JSON, nil
+		}
+	case simple.Storage_STORAGE_RELATION:
+		return storage, fmt.Errorf("field %s: %v requires relation", field.Desc.FullName(), storage)
+	}
+	return storage, nil
+}
+
+// fieldColumnType returns the column type of a field stored as a column or
+// as JSON, with the Go type qualified for g.
+func fieldColumnType(g *protogen.GeneratedFile, field *protogen.Field, storage simple.Storage) (columnType, error) {
+	kind := field.Desc.Kind()
+	var ct columnType
+	switch {
+	case storage == simple.Storage_STORAGE_JSON && isStructField(field) && !isOneofField(field):
+		ct = columnType{goType: "map[string]interface{}", sqlType: kindColumnTypes[protoreflect.MessageKind].sqlType}
+	case storage == simple.Storage_STORAGE_JSON:
+		ct = columnType{goType: protoGoType(g, field), sqlType: kindColumnTypes[protoreflect.MessageKind].sqlType}
+	case isWellKnownColumn(field):
+		ct = wellKnownColumnType(g, field)
+	case kind == protoreflect.EnumKind && *enumAsString:
+		ct = enumStringColumnType
+	default:
+		var ok bool
+		if ct, ok = kindColumnTypes[kind]; !ok {
+			return columnType{}, fmt.Errorf("field %s: unsupported kind %v", field.Desc.FullName(), kind)
+		}
+	}
+	if isOptionalScalar(field) && ct.goType != "[]byte" {
+		ct.goType = "*" + ct.goType
+	}
+	opts := columnOptions(field)
+	if size := opts.GetSize(); size > 0 && kind == protoreflect.StringKind {
+		ct.sqlType = fmt.Sprintf("varchar(%d)", size)
+	}
+	if opts.GetType() != "" {
+		ct.sqlType = opts.GetType()
+	}
+	return ct, nil
+}
+
+// isOptionalScalar reports whether field is a scalar with explicit presence,
+// such as a proto3 optional field or a oneof field, stored as a nullable
+// pointer column.
+func isOptionalScalar(field *protogen.Field) bool {
+	return field.Desc.HasPresence() && field.Message == nil && !field.Desc.IsList()
+}
+
+// modelFields returns the fields of message stored as columns of the model,
+// leaving out the ones provided by the base model and the ignored ones.
+func modelFields(message *protogen.Message) []*protogen.Field {
+	baseModel := hasBaseModel(message)
+	var fields []*protogen.Field
+	for _, field := range message.Fields {
+		if baseModel && (field.GoName == "Id" || field.GoName == "CreatedAt" || field.GoName == "UpdatedAt" || field.GoName == "DeletedAt") {
+			continue
+		}
+		if columnOptions(field).GetIgnore() {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// columnName returns the column name of field.
+func columnName(field *protogen.Field) string {
+	if name := columnOptions(field).GetName(); name != "" {
+		return name
+	}
+	return ToSnakeCase(field.GoName)
+}
+
+// columnComment returns the comment of the column generated for field, which
+// defaults to the leading comment of the field.
+func columnComment(field *protogen.Field) string {
+	comment := columnOptions(field).GetComment()
+	if comment == "" {
+		comment = strings.Join(strings.Fields(string(field.Comments.Leading)), " ")
+	}
+	// gorm splits tag settings on ';' and the tag itself is quoted.
+	return strings.NewReplacer(";", ",", `"`, "'", "`", "'", `\`, "").Replace(comment)
+}
+
+// gormTag returns the gorm tag of the column generated for field.
+func gormTag(field *protogen.Field, ct columnType, storage simple.Storage) string {
+	opts := columnOptions(field)
+	var b strings.Builder
+	b.WriteString("column:" + columnName(field) + ";")
+	if storage == simple.Storage_STORAGE_JSON {
+		b.WriteString("serializer:" + jsonSerializer(field) + ";")
+	}
+	b.WriteString("type:" + ct.sqlType + ";")
+	if isKeyField(field) {
+		b.WriteString(keyTag(field))
+	}
+	if opts.GetSize() > 0 {
+		b.WriteString("size:" + strconv.Itoa(int(opts.GetSize())) + ";")
+	}
+	if opts.GetNotNull() {
+		b.WriteString("not null;")
+	}
+	if opts.GetDefault() != "" {
+		b.WriteString("default:" + opts.GetDefault() + ";")
+	}
+	if opts.GetUnique() {
+		b.WriteString("unique;")
+	}
+	b.WriteString(indexTag(field))
+	if comment := columnComment(field); comment != "" {
+		b.WriteString("comment:" + comment + ";")
+	}
+	return b.String()
+}
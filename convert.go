@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wwengg/protoc-gen-simple/simple"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// protoGoType returns the Go type of field in the generated proto struct.
+func protoGoType(g *protogen.GeneratedFile, field *protogen.Field) string {
+	if field.Desc.IsMap() {
+		return "map[" + protoGoType(g, field.Message.Fields[0]) + "]" + protoGoType(g, field.Message.Fields[1])
+	}
+	var goType string
+	switch field.Desc.Kind() {
+	case protoreflect.EnumKind:
+		goType = g.QualifiedGoIdent(field.Enum.GoIdent)
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		goType = "*" + g.QualifiedGoIdent(field.Message.GoIdent)
+	default:
+		goType = kindColumnTypes[field.Desc.Kind()].goType
+	}
+	if field.Desc.IsList() {
+		return "[]" + goType
+	}
+	return goType
+}
+
+func messageField(message *protogen.Message, goName string) *protogen.Field {
+	for _, field := range message.Fields {
+		if field.GoName == goName {
+			return field
+		}
+	}
+	return nil
+}
+
+// generateModelFiled generates the struct fields of the model storing field.
+func generateModelFiled(g *protogen.GeneratedFile, message *protogen.Message, field *protogen.Field) error {
+	storage, err := fieldStorage(field)
+	if err != nil {
+		return err
+	}
+	if storage == simple.Storage_STORAGE_RELATION {
+		return generateRelationField(g, message, field)
+	}
+	ct, err := fieldColumnType(g, field, storage)
+	if err != nil {
+		return err
+	}
+	g.P(fmt.Sprintf(`		%s  %s `, field.GoName, ct.goType) + "`" + fmt.Sprintf(`json:"%s" gorm:"%s"`, field.Desc.JSONName(), gormTag(field, ct, storage)) + "`")
+	return nil
+}
+
+// fieldConverter converts a model field from and to its proto field. Each
+// direction is either an expression used in the struct literal or, when the
+// expression is empty, statements following it. The statements refer to the
+// model and the proto message as model and proto.
+type fieldConverter struct {
+	// goName is the name of the field in the proto struct.
+	goName               string
+	toProto, toProtoStmt string
+	toModel, toModelStmt string
+}
+
+func newFieldConverter(g *protogen.GeneratedFile, message *protogen.Message, field *protogen.Field) (fieldConverter, error) {
+	storage, err := fieldStorage(field)
+	if err != nil {
+		return fieldConverter{}, err
+	}
+	name := field.GoName
+	switch storage {
+	case simple.Storage_STORAGE_RELATION:
+		return relationConverter(g, message, field)
+	case simple.Storage_STORAGE_JSON:
+		if isStructField(field) {
+			return structConverter(g, field), nil
+		}
+		return fieldConverter{goName: name, toProto: "model." + name, toModel: "proto." + name}, nil
+	}
+	if isWellKnownColumn(field) {
+		return wellKnownConverter(g, field), nil
+	}
+	if isOptionalScalar(field) && field.Desc.Kind() == protoreflect.EnumKind {
+		return fieldConverter{
+			goName: name,
+			toProtoStmt: fmt.Sprintf(`if model.%[1]s != nil {
+				proto.%[1]s = %[2]s.Enum()
+			}`, name, protoValue(g, field, "*model."+name)),
+			toModelStmt: fmt.Sprintf(`if proto.%[1]s != nil {
+				v := %[2]s
+				model.%[1]s = &v
+			}`, name, modelValue(field, "*proto."+name)),
+		}, nil
+	}
+	return fieldConverter{
+		goName:  name,
+		toProto: protoValue(g, field, "model."+name),
+		toModel: modelValue(field, "proto."+name),
+	}, nil
+}
+
+// modelValue converts the proto field value expr to the model field type.
+func modelValue(field *protogen.Field, expr string) string {
+	if field.Desc.Kind() != protoreflect.EnumKind {
+		return expr
+	}
+	if *enumAsString {
+		if strings.HasPrefix(expr, "*") {
+			expr = "(" + expr + ")"
+		}
+		return expr + ".String()"
+	}
+	return "int32(" + expr + ")"
+}
+
+// protoValue converts the model field value expr to the proto field type.
+func protoValue(g *protogen.GeneratedFile, field *protogen.Field, expr string) string {
+	if field.Desc.Kind() != protoreflect.EnumKind {
+		return expr
+	}
+	enum := g.QualifiedGoIdent(field.Enum.GoIdent)
+	if *enumAsString {
+		values := g.QualifiedGoIdent(protogen.GoIdent{GoName: field.Enum.GoIdent.GoName + "_value", GoImportPath: field.Enum.GoIdent.GoImportPath})
+		return fmt.Sprintf("%s(%s[%s])", enum, values, expr)
+	}
+	return fmt.Sprintf("%s(%s)", enum, expr)
+}
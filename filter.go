@@ -0,0 +1,369 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/wwengg/protoc-gen-simple/simple"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// filterColumn is a column with conditions in the filter of a model.
+type filterColumn struct {
+	field *protogen.Field
+	// goType is the Go type of the column values, never a pointer.
+	goType string
+	ops    []simple.FilterOp
+}
+
+// sortColumn is a column Get<Model>List can be sorted by.
+type sortColumn struct {
+	// name is the proto field name clients sort by.
+	name   string
+	column string
+}
+
+// modelFilter is the <Model>Filter of a model, declared by the filter,
+// sortable and keyword column options.
+type modelFilter struct {
+	columns []filterColumn
+	keyword []*protogen.Field
+	sort    []sortColumn
+}
+
+// newModelFilter returns the filter of the model of message. The primary key
+// columns, and the created_at and updated_at columns of the base model, are
+// always sortable.
+func newModelFilter(message *protogen.Message) (modelFilter, error) {
+	var f modelFilter
+	key, err := primaryKey(message)
+	if err != nil {
+		return f, err
+	}
+	if hasBaseModel(message) {
+		f.sort = append(f.sort, sortColumn{"id", "id"}, sortColumn{"created_at", "created_at"}, sortColumn{"updated_at", "updated_at"})
+	}
+	for _, field := range key.fields {
+		f.sort = append(f.sort, sortColumn{string(field.Desc.Name()), columnName(field)})
+	}
+	goNames := map[string]bool{"Keyword": true, "Sort": true}
+	for _, field := range modelFields(message) {
+		opts := columnOptions(field)
+		if len(opts.GetFilter()) == 0 && !opts.GetSortable() && !opts.GetKeyword() {
+			continue
+		}
+		goType, err := filterGoType(field)
+		if err != nil {
+			return f, err
+		}
+		if opts.GetSortable() && !isKeyField(field) {
+			f.sort = append(f.sort, sortColumn{string(field.Desc.Name()), columnName(field)})
+		}
+		if opts.GetKeyword() {
+			if field.Desc.Kind() != protoreflect.StringKind {
+				return f, fmt.Errorf("field %s: keyword requires a string field", field.Desc.FullName())
+			}
+			f.keyword = append(f.keyword, field)
+		}
+		if len(opts.GetFilter()) == 0 {
+			continue
+		}
+		c := filterColumn{field: field, goType: goType}
+		for _, op := range opts.GetFilter() {
+			if (op == simple.FilterOp_FILTER_OP_LIKE || op == simple.FilterOp_FILTER_OP_PREFIX) && goType != "string" {
+				return f, fmt.Errorf("field %s: %v requires a string column", field.Desc.FullName(), op)
+			}
+			for _, name := range filterGoNames(field, op) {
+				if goNames[name] {
+					return f, fmt.Errorf("field %s: filter field %s is declared twice", field.Desc.FullName(), name)
+				}
+				goNames[name] = true
+			}
+			c.ops = append(c.ops, op)
+		}
+		f.columns = append(f.columns, c)
+	}
+	return f, nil
+}
+
+// filterGoType returns the Go type of the values of a filtered, sorted or
+// keyword column.
+func filterGoType(field *protogen.Field) (string, error) {
+	storage, err := fieldStorage(field)
+	if err != nil {
+		return "", err
+	}
+	kind := field.Desc.Kind()
+	if storage != simple.Storage_STORAGE_AUTO || field.Desc.IsList() || field.Message != nil || kind == protoreflect.BytesKind || isOneofField(field) && isJSONOneof(field.Oneof) {
+		return "", fmt.Errorf("field %s: filter, sortable and keyword require a scalar column", field.Desc.FullName())
+	}
+	if kind == protoreflect.EnumKind && *enumAsString {
+		return enumStringColumnType.goType, nil
+	}
+	return kindColumnTypes[kind].goType, nil
+}
+
+// filterGoNames returns the names of the filter struct fields of op on field,
+// which are also the Go names of the request fields it is read from.
+func filterGoNames(field *protogen.Field, op simple.FilterOp) []string {
+	switch op {
+	case simple.FilterOp_FILTER_OP_EQ:
+		return []string{field.GoName}
+	case simple.FilterOp_FILTER_OP_RANGE:
+		return []string{field.GoName + "Min", field.GoName + "Max"}
+	case simple.FilterOp_FILTER_OP_IN:
+		return []string{field.GoName + "In"}
+	case simple.FilterOp_FILTER_OP_LIKE:
+		return []string{field.GoName + "Like"}
+	case simple.FilterOp_FILTER_OP_PREFIX:
+		return []string{field.GoName + "Prefix"}
+	}
+	return nil
+}
+
+// likeEscape is the escape character of the LIKE conditions of the filters.
+// It is not a backslash, which MySQL string literals would need doubled and
+// PostgreSQL ones would not.
+const likeEscape = "!"
+
+// likeEscapes are the strings.NewReplacer arguments escaping a value matched
+// literally by LIKE.
+var likeEscapes = []string{likeEscape, likeEscape + likeEscape, "%", likeEscape + "%", "_", likeEscape + "_"}
+
+// hasLike reports whether the filter has LIKE conditions.
+func (f modelFilter) hasLike() bool {
+	if len(f.keyword) > 0 {
+		return true
+	}
+	for _, c := range f.columns {
+		for _, op := range c.ops {
+			if op == simple.FilterOp_FILTER_OP_LIKE || op == simple.FilterOp_FILTER_OP_PREFIX {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// generateFilter generates <Model>Filter and its Scope method adding the
+// conditions and the order to a query.
+func generateFilter(g *protogen.GeneratedFile, message *protogen.Message, modelName string) error {
+	f, err := newModelFilter(message)
+	if err != nil {
+		return err
+	}
+	var sortNames, keywordColumns []string
+	for _, s := range f.sort {
+		sortNames = append(sortNames, s.name)
+	}
+	for _, field := range f.keyword {
+		keywordColumns = append(keywordColumns, columnName(field))
+	}
+	g.P(fmt.Sprintf(`// %sFilter 分页查询条件，零值的条件不生效
+		type %[1]sFilter struct {`, modelName))
+	for _, c := range f.columns {
+		column := columnName(c.field)
+		for _, op := range c.ops {
+			names := filterGoNames(c.field, op)
+			switch op {
+			case simple.FilterOp_FILTER_OP_EQ:
+				g.P(fmt.Sprintf(`	%s *%s // %s = ?`, names[0], c.goType, column))
+			case simple.FilterOp_FILTER_OP_RANGE:
+				g.P(fmt.Sprintf(`	%s *%s // %s >= ?`, names[0], c.goType, column))
+				g.P(fmt.Sprintf(`	%s *%s // %s <= ?`, names[1], c.goType, column))
+			case simple.FilterOp_FILTER_OP_IN:
+				g.P(fmt.Sprintf(`	%s []%s // %s IN ?`, names[0], c.goType, column))
+			case simple.FilterOp_FILTER_OP_LIKE:
+				g.P(fmt.Sprintf(`	%s string // %s LIKE %%?%%`, names[0], column))
+			case simple.FilterOp_FILTER_OP_PREFIX:
+				g.P(fmt.Sprintf(`	%s string // %s LIKE ?%%`, names[0], column))
+			}
+		}
+	}
+	if len(f.keyword) > 0 {
+		g.P(fmt.Sprintf(`	// Keyword 模糊匹配 %s
+			Keyword string`, strings.Join(keywordColumns, ", ")))
+	}
+	g.P(fmt.Sprintf(`	// Sort 排序字段，"-" 前缀表示降序，可选 %s
+		Sort []string
+	}
+
+	var %sSortColumns = map[string]string{`, strings.Join(sortNames, ", "), lowerFirstLatter(modelName)))
+	for _, s := range f.sort {
+		g.P(fmt.Sprintf(`	%q: %q,`, s.name, s.column))
+	}
+	g.P("}")
+	escaper := lowerFirstLatter(modelName) + "LikeEscaper"
+	if f.hasLike() {
+		var escapes []string
+		for _, s := range likeEscapes {
+			escapes = append(escapes, strconv.Quote(s))
+		}
+		g.P(fmt.Sprintf(`
+			// %s escapes the wildcards of the LIKE conditions, which are
+			// matched with ESCAPE '%s'.
+			var %[1]s = %[3]s(%[4]s)`, escaper, likeEscape, g.QualifiedGoIdent(stringsPackage.Ident("NewReplacer")), strings.Join(escapes, ", ")))
+	}
+	g.P(fmt.Sprintf(`
+		// Scope adds the conditions and the order of f to db. An unknown sort
+		// field is reported as an error of db.
+		func (f *%[1]sFilter) Scope(db *%[2]s) *%[2]s {
+			if f == nil {
+				return db
+			}`, modelName, g.QualifiedGoIdent(GormPackage.Ident("DB"))))
+	for _, c := range f.columns {
+		column := columnName(c.field)
+		for _, op := range c.ops {
+			names := filterGoNames(c.field, op)
+			switch op {
+			case simple.FilterOp_FILTER_OP_EQ:
+				g.P(fmt.Sprintf(`if f.%[1]s != nil {
+					db = db.Where("%[2]s = ?", *f.%[1]s)
+				}`, names[0], column))
+			case simple.FilterOp_FILTER_OP_RANGE:
+				g.P(fmt.Sprintf(`if f.%[1]s != nil {
+					db = db.Where("%[3]s >= ?", *f.%[1]s)
+				}
+				if f.%[2]s != nil {
+					db = db.Where("%[3]s <= ?", *f.%[2]s)
+				}`, names[0], names[1], column))
+			case simple.FilterOp_FILTER_OP_IN:
+				g.P(fmt.Sprintf(`if len(f.%[1]s) > 0 {
+					db = db.Where("%[2]s IN ?", f.%[1]s)
+				}`, names[0], column))
+			case simple.FilterOp_FILTER_OP_LIKE:
+				g.P(fmt.Sprintf(`if f.%[1]s != "" {
+					db = db.Where("%[2]s LIKE ? ESCAPE '%[4]s'", "%%"+%[3]s.Replace(f.%[1]s)+"%%")
+				}`, names[0], column, escaper, likeEscape))
+			case simple.FilterOp_FILTER_OP_PREFIX:
+				g.P(fmt.Sprintf(`if f.%[1]s != "" {
+					db = db.Where("%[2]s LIKE ? ESCAPE '%[4]s'", %[3]s.Replace(f.%[1]s)+"%%")
+				}`, names[0], column, escaper, likeEscape))
+			}
+		}
+	}
+	if len(f.keyword) > 0 {
+		var conds, args []string
+		for _, column := range keywordColumns {
+			conds = append(conds, column+" LIKE ? ESCAPE '"+likeEscape+"'")
+			args = append(args, "keyword")
+		}
+		g.P(fmt.Sprintf(`if f.Keyword != "" {
+			keyword := "%%" + %s.Replace(f.Keyword) + "%%"
+			db = db.Where("%s", %s)
+		}`, escaper, strings.Join(conds, " OR "), strings.Join(args, ", ")))
+	}
+	g.P(fmt.Sprintf(`for _, s := range f.Sort {
+			column, ok := %[1]sSortColumns[%[2]s(s, "-")]
+			if !ok {
+				db.AddError(%[3]s("%[4]sFilter: cannot sort by %%q", s))
+				return db
+			}
+			db = db.Order(%[5]s{Column: %[6]s{Name: column}, Desc: %[7]s(s, "-")})
+		}
+		return db
+	}
+`, lowerFirstLatter(modelName), g.QualifiedGoIdent(stringsPackage.Ident("TrimPrefix")), g.QualifiedGoIdent(fmtPackage.Ident("Errorf")), modelName,
+		g.QualifiedGoIdent(clausePackage.Ident("OrderByColumn")), g.QualifiedGoIdent(clausePackage.Ident("Column")), g.QualifiedGoIdent(stringsPackage.Ident("HasPrefix"))))
+	return nil
+}
+
+// filterArgs returns the statements declaring listFilter, the filter of the
+// model of message read from the request fields of input named after its
+// conditions, or "" when input has none of them.
+func filterArgs(g *protogen.GeneratedFile, message *protogen.Message, input *protogen.Message) (string, error) {
+	f, err := newModelFilter(message)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for _, c := range f.columns {
+		for _, op := range c.ops {
+			for _, name := range filterGoNames(c.field, op) {
+				arg := messageField(input, name)
+				if arg == nil {
+					continue
+				}
+				if err := checkFilterArg(c.field, op, arg); err != nil {
+					return "", err
+				}
+				b.WriteString(filterArg(c.field, op, arg))
+			}
+		}
+	}
+	if arg := messageField(input, "Keyword"); arg != nil && len(f.keyword) > 0 {
+		if arg.Desc.Kind() != protoreflect.StringKind || arg.Desc.IsList() {
+			return "", fmt.Errorf("field %s: the keyword of %s must be a string", arg.Desc.FullName(), message.Desc.Name())
+		}
+		b.WriteString("listFilter.Keyword = args.GetKeyword()\n")
+	}
+	if arg := messageField(input, "Sort"); arg != nil {
+		if arg.Desc.Kind() != protoreflect.StringKind || !arg.Desc.IsList() {
+			return "", fmt.Errorf("field %s: the sort of %s must be a repeated string", arg.Desc.FullName(), message.Desc.Name())
+		}
+		b.WriteString("listFilter.Sort = args.GetSort()\n")
+	}
+	if b.Len() == 0 {
+		return "", nil
+	}
+	return fmt.Sprintf("listFilter := &%s{}\n%s", g.QualifiedGoIdent(modelIdent(message, modelName(message)+"Filter")), b.String()), nil
+}
+
+// checkFilterArg reports an error when the request field arg cannot hold
+// the values of the condition op on field.
+func checkFilterArg(field *protogen.Field, op simple.FilterOp, arg *protogen.Field) error {
+	want, list := field.Desc.Kind(), op == simple.FilterOp_FILTER_OP_IN
+	if op == simple.FilterOp_FILTER_OP_LIKE || op == simple.FilterOp_FILTER_OP_PREFIX {
+		want = protoreflect.StringKind
+	}
+	ok := arg.Desc.Kind() == want && arg.Desc.IsList() == list && !arg.Desc.IsMap()
+	if ok && want == protoreflect.EnumKind {
+		ok = arg.Enum.Desc.FullName() == field.Enum.Desc.FullName()
+	}
+	if arg.Oneof != nil && !arg.Oneof.Desc.IsSynthetic() {
+		return fmt.Errorf("field %s: a %v condition of %s cannot be in a oneof", arg.Desc.FullName(), op, field.Desc.FullName())
+	}
+	if !ok && list {
+		return fmt.Errorf("field %s: a %v condition of %s must be a repeated %v field", arg.Desc.FullName(), op, field.Desc.FullName(), want)
+	}
+	if !ok {
+		return fmt.Errorf("field %s: a %v condition of %s must be a singular %v field", arg.Desc.FullName(), op, field.Desc.FullName(), want)
+	}
+	return nil
+}
+
+// filterArg returns the statement setting the filter field of the request
+// field arg.
+func filterArg(field *protogen.Field, op simple.FilterOp, arg *protogen.Field) string {
+	name := arg.GoName
+	switch {
+	case op == simple.FilterOp_FILTER_OP_IN && field.Desc.Kind() == protoreflect.EnumKind:
+		return fmt.Sprintf(`for _, v := range args.%[1]s {
+			listFilter.%[1]s = append(listFilter.%[1]s, %[2]s)
+		}
+`, name, modelValue(field, "v"))
+	case op == simple.FilterOp_FILTER_OP_IN || op == simple.FilterOp_FILTER_OP_LIKE || op == simple.FilterOp_FILTER_OP_PREFIX:
+		return fmt.Sprintf("listFilter.%[1]s = args.Get%[1]s()\n", name)
+	case arg.Desc.HasPresence():
+		return fmt.Sprintf(`if args.%[1]s != nil {
+			v := %[2]s
+			listFilter.%[1]s = &v
+		}
+`, name, modelValue(field, "*args."+name))
+	}
+	cond := "args." + name
+	switch field.Desc.Kind() {
+	case protoreflect.BoolKind:
+	case protoreflect.StringKind:
+		cond += ` != ""`
+	default:
+		cond += " != 0"
+	}
+	return fmt.Sprintf(`if %[1]s {
+			v := %[2]s
+			listFilter.%[3]s = &v
+		}
+`, cond, modelValue(field, "args."+name), name)
+}
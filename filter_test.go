@@ -0,0 +1,78 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLikeEscapes(t *testing.T) {
+	r := strings.NewReplacer(likeEscapes...)
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"abc", "abc"},
+		{"50%", "50!%"},
+		{"a_b", "a!_b"},
+		{"hi!", "hi!!"},
+		{`!%_\`, `!!!%!_\`},
+	}
+	for _, tt := range tests {
+		if got := r.Replace(tt.value); got != tt.want {
+			t.Errorf("escape %q = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateFilterLike(t *testing.T) {
+	tests := []struct {
+		name    string
+		options string
+		want    []string
+	}{{
+		name:    "like",
+		options: `filter: [FILTER_OP_LIKE, FILTER_OP_PREFIX]`,
+		want: []string{
+			`var tagLikeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")`,
+			`db.Where("label LIKE ? ESCAPE '!'", "%"+tagLikeEscaper.Replace(f.LabelLike)+"%")`,
+			`db.Where("label LIKE ? ESCAPE '!'", tagLikeEscaper.Replace(f.LabelPrefix)+"%")`,
+		},
+	}, {
+		name:    "keyword",
+		options: `keyword: true`,
+		want: []string{
+			`keyword := "%" + tagLikeEscaper.Replace(f.Keyword) + "%"`,
+			`db.Where("label LIKE ? ESCAPE '!'", keyword)`,
+		},
+	}, {
+		name:    "no like",
+		options: `filter: FILTER_OP_EQ`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := testPlugin(t, testFile("filter", `message_type {
+				name: "TagModel" options { [simple.model]: true }
+				field { name: "label" number: 1 type: TYPE_STRING options { [simple.column] { `+tt.options+` } } }
+			}`))
+			file := gen.Files[len(gen.Files)-1]
+			g := gen.NewGeneratedFile("filter_model.go", file.GoImportPath)
+			g.P("package model")
+			if err := generateFilter(g, testMessage(t, gen, "TagModel"), "Tag"); err != nil {
+				t.Fatal(err)
+			}
+			b, err := g.Content()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := string(b)
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("generated filter has no %s:\n%s", want, got)
+				}
+			}
+			if len(tt.want) == 0 && strings.Contains(got, "LikeEscaper") {
+				t.Errorf("generated filter without LIKE conditions has an escaper:\n%s", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// generator is one kind of output produced by the plugin, such as the gorm
+// model or the vue page. Generators register themselves in init functions
+// and are selected with the gen and nogen plugin parameters:
+//
+//	--simple_opt=gen=model+impl
+//	--simple_opt=nogen=vue+js
+type generator struct {
+	name        string
+	description string
+	// defaultOn reports whether the generator runs when gen is not given.
+	defaultOn bool
+	generate  func(gen *protogen.Plugin, file *protogen.File) error
+}
+
+var (
+	generators []*generator
+	// selectedGenerators is nil until the gen parameter is set.
+	selectedGenerators map[string]bool
+	skippedGenerators  = map[string]bool{}
+)
+
+func init() {
+	flag.Var(generatorFlag{enable: true}, "gen", "generators to run, joined by '+' (or \"all\")")
+	flag.Var(generatorFlag{enable: false}, "nogen", "generators to skip, joined by '+'")
+}
+
+// registerGenerator adds g to the generator table.
+func registerGenerator(g *generator) {
+	if lookupGenerator(g.name) != nil {
+		panic("protoc-gen-simple: duplicate generator " + g.name)
+	}
+	generators = append(generators, g)
+}
+
+func lookupGenerator(name string) *generator {
+	for _, g := range generators {
+		if g.name == name {
+			return g
+		}
+	}
+	return nil
+}
+
+// enabled reports whether g runs with the current parameters.
+func (g *generator) enabled() bool {
+	if skippedGenerators[g.name] {
+		return false
+	}
+	if selectedGenerators == nil {
+		return g.defaultOn
+	}
+	return selectedGenerators[g.name]
+}
+
+// runGenerators runs every enabled generator for file in table order.
+func runGenerators(gen *protogen.Plugin, file *protogen.File) error {
+	for _, g := range generators {
+		if !g.enabled() {
+			continue
+		}
+		if err := g.generate(gen, file); err != nil {
+			return fmt.Errorf("%s: %s: %v", file.Desc.Path(), g.name, err)
+		}
+	}
+	return nil
+}
+
+// listGenerators prints the generator table, marking the ones enabled by default.
+func listGenerators(w io.Writer) {
+	for _, g := range generators {
+		mark := " "
+		if g.defaultOn {
+			mark = "*"
+		}
+		fmt.Fprintf(w, "%s %-8s %s\n", mark, g.name, g.description)
+	}
+	fmt.Fprintln(w, "(* enabled by default)")
+}
+
+func generatorNames() string {
+	names := make([]string, 0, len(generators))
+	for _, g := range generators {
+		names = append(names, g.name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// generatorFlag implements flag.Value for the gen and nogen parameters.
+type generatorFlag struct {
+	enable bool
+}
+
+func (f generatorFlag) String() string { return "" }
+
+func (f generatorFlag) Set(value string) error {
+	set := skippedGenerators
+	if f.enable {
+		if selectedGenerators == nil {
+			selectedGenerators = map[string]bool{}
+		}
+		set = selectedGenerators
+	}
+	for _, name := range strings.Split(value, "+") {
+		name = strings.TrimSpace(name)
+		if name == "all" && f.enable {
+			for _, g := range generators {
+				set[g.name] = true
+			}
+			continue
+		}
+		if lookupGenerator(name) == nil {
+			return fmt.Errorf("unknown generator %q (known generators: %s)", name, generatorNames())
+		}
+		set[name] = true
+	}
+	return nil
+}
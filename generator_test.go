@@ -0,0 +1,54 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGeneratorFlag(t *testing.T) {
+	tests := []struct {
+		params  string
+		enabled []string
+		err     string
+	}{
+		{"", []string{"model", "impl", "vue", "js"}, ""},
+		{"gen=model+impl", []string{"model", "impl"}, ""},
+		{"gen= sql + rpcx", []string{"rpcx", "sql"}, ""},
+		{"gen=all", []string{"model", "impl", "rpcx", "sql", "vue", "js"}, ""},
+		{"nogen=vue+js", []string{"model", "impl"}, ""},
+		{"gen=all,nogen=vue", []string{"model", "impl", "rpcx", "sql", "js"}, ""},
+		{"gen=model,gen=sql", []string{"model", "sql"}, ""},
+		{"gen=nope", nil, `unknown generator "nope"`},
+		{"nogen=all", nil, `unknown generator "all"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.params, func(t *testing.T) {
+			setParams(t, "")
+			for _, param := range strings.Split(tt.params, ",") {
+				if param == "" {
+					continue
+				}
+				name, value, _ := strings.Cut(param, "=")
+				err := generatorFlag{enable: name == "gen"}.Set(value)
+				if tt.err != "" {
+					if err == nil || !strings.Contains(err.Error(), tt.err) {
+						t.Fatalf("Set(%q) = %v, want error %s", value, err, tt.err)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("Set(%q): %v", value, err)
+				}
+			}
+			var enabled []string
+			for _, g := range generators {
+				if g.enabled() {
+					enabled = append(enabled, g.name)
+				}
+			}
+			if got, want := strings.Join(enabled, "+"), strings.Join(tt.enabled, "+"); got != want {
+				t.Errorf("enabled generators = %s, want %s", got, want)
+			}
+		})
+	}
+}
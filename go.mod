@@ -2,4 +2,7 @@ module github.com/wwengg/protoc-gen-simple
 
 go 1.18
 
-require google.golang.org/protobuf v1.28.0
+require (
+	github.com/jinzhu/inflection v1.0.0
+	google.golang.org/protobuf v1.28.0
+)
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/wwengg/protoc-gen-simple/simple"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// modelIndex is an index declared by the indexes table option.
+type modelIndex struct {
+	name   string
+	fields []*protogen.Field
+	desc   []bool
+	unique bool
+	upsert bool
+}
+
+// modelIndexes returns the indexes declared by the indexes table option of
+// message.
+func modelIndexes(message *protogen.Message) ([]modelIndex, error) {
+	columns := make(map[string]*protogen.Field)
+	for _, field := range modelFields(message) {
+		storage, err := fieldStorage(field)
+		if err != nil {
+			return nil, err
+		}
+		if storage == simple.Storage_STORAGE_AUTO && !(isOneofField(field) && isJSONOneof(field.Oneof)) {
+			columns[string(field.Desc.Name())] = field
+		}
+	}
+	var indexes []modelIndex
+	for _, opts := range tableOptions(message).GetIndexes() {
+		if len(opts.GetFields()) == 0 {
+			return nil, fmt.Errorf("message %s: index %q has no fields", message.Desc.FullName(), opts.GetName())
+		}
+		index := modelIndex{name: opts.GetName(), unique: opts.GetUnique(), upsert: opts.GetUpsert()}
+		if index.upsert && !index.unique {
+			return nil, fmt.Errorf("message %s: the upsert index %q must be unique", message.Desc.FullName(), opts.GetName())
+		}
+		var names []string
+		for _, f := range opts.GetFields() {
+			field, ok := columns[f.GetName()]
+			if !ok {
+				return nil, fmt.Errorf("message %s: index field %s is not a column of the model", message.Desc.FullName(), f.GetName())
+			}
+			index.fields = append(index.fields, field)
+			index.desc = append(index.desc, f.GetOrder() == simple.IndexOrder_INDEX_ORDER_DESC)
+			names = append(names, columnName(field))
+		}
+		if index.name == "" {
+			index.name = "idx_" + ToSnakeCase(modelName(message)) + "_" + strings.Join(names, "_")
+		}
+		indexes = append(indexes, index)
+	}
+	return indexes, nil
+}
+
+// indexTag returns the gorm tag settings adding field to the indexes of its
+// model.
+func indexTag(field *protogen.Field) string {
+	var b strings.Builder
+	if columnOptions(field).GetIndex() {
+		if columnOptions(field).GetIndexOrder() == simple.IndexOrder_INDEX_ORDER_DESC {
+			b.WriteString("index:,sort:desc;")
+		} else {
+			b.WriteString("index;")
+		}
+	}
+	indexes, _ := modelIndexes(field.Parent)
+	for _, index := range indexes {
+		for i, f := range index.fields {
+			if f != field {
+				continue
+			}
+			if index.unique {
+				b.WriteString("uniqueIndex:")
+			} else {
+				b.WriteString("index:")
+			}
+			b.WriteString(index.name)
+			if len(index.fields) > 1 {
+				b.WriteString(",priority:" + strconv.Itoa(i+1))
+			}
+			if index.desc[i] {
+				b.WriteString(",sort:desc")
+			}
+			b.WriteString(";")
+		}
+	}
+	return b.String()
+}
+
+// uniqueKeys returns the field sets of the unique columns and unique indexes
+// of message, each identifying a single row.
+func uniqueKeys(message *protogen.Message) ([]modelKey, error) {
+	indexes, err := modelIndexes(message)
+	if err != nil {
+		return nil, err
+	}
+	var keys []modelKey
+	for _, field := range modelFields(message) {
+		if columnOptions(field).GetUnique() {
+			keys = append(keys, modelKey{fields: []*protogen.Field{field}})
+		}
+	}
+	for _, index := range indexes {
+		if index.unique {
+			keys = append(keys, modelKey{fields: index.fields})
+		}
+	}
+	return keys, nil
+}
+
+// uniqueLookup is a query by a unique column or unique index.
+type uniqueLookup struct {
+	// name is By<Fields>, following Get<Model> in the function name.
+	name   string
+	params string
+	args   string
+	where  string
+}
+
+// uniqueLookups returns the queries by every unique column and unique index
+// of message.
+func uniqueLookups(g *protogen.GeneratedFile, message *protogen.Message) ([]uniqueLookup, error) {
+	keys, err := uniqueKeys(message)
+	if err != nil {
+		return nil, err
+	}
+	var lookups []uniqueLookup
+	seen := make(map[string]bool)
+	for _, key := range keys {
+		var names []string
+		for _, field := range key.fields {
+			names = append(names, field.GoName)
+		}
+		name := "By" + strings.Join(names, "And")
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		params, err := key.params(g)
+		if err != nil {
+			return nil, err
+		}
+		lookups = append(lookups, uniqueLookup{name: name, params: params, args: key.paramNames(), where: key.where()})
+	}
+	return lookups, nil
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/token"
+	"strings"
+
+	"github.com/wwengg/protoc-gen-simple/simple"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var snowflakeFlag goIdentFlag
+
+func init() {
+	flag.Var(&snowflakeFlag, "snowflake", "func() int64 generating the keys of KEY_STRATEGY_SNOWFLAKE models, as importpath.Name")
+}
+
+// hasBaseModel reports whether the model of message embeds store.BASE_MODEL,
+// which is the default.
+func hasBaseModel(message *protogen.Message) bool {
+	opts := tableOptions(message)
+	return opts == nil || opts.BaseModel == nil || *opts.BaseModel
+}
+
+// modelKey is the primary key of a model.
+type modelKey struct {
+	// fields are the primary key fields, nil for the ID of the base model.
+	fields   []*protogen.Field
+	strategy simple.KeyStrategy
+}
+
+// primaryKey returns the primary key of the model of message declared by the
+// primary_key and key_strategy table options, with KEY_STRATEGY_AUTO resolved.
+func primaryKey(message *protogen.Message) (modelKey, error) {
+	opts := tableOptions(message)
+	key := modelKey{strategy: opts.GetKeyStrategy()}
+	if hasBaseModel(message) {
+		if names := opts.GetPrimaryKey(); len(names) > 1 || len(names) == 1 && names[0] != "id" {
+			return key, fmt.Errorf("message %s: primary_key requires base_model = false", message.Desc.FullName())
+		}
+		if key.strategy != simple.KeyStrategy_KEY_STRATEGY_AUTO && key.strategy != simple.KeyStrategy_KEY_STRATEGY_AUTO_INCREMENT {
+			return key, fmt.Errorf("message %s: %v requires base_model = false", message.Desc.FullName(), key.strategy)
+		}
+		if id := messageField(message, "Id"); id != nil && kindColumnTypes[id.Desc.Kind()].goType != "int64" {
+			return key, fmt.Errorf("field %s: the ID of the base model is an int64, set base_model = false for other key types", id.Desc.FullName())
+		}
+		key.strategy = simple.KeyStrategy_KEY_STRATEGY_AUTO_INCREMENT
+		return key, nil
+	}
+	names := opts.GetPrimaryKey()
+	if len(names) == 0 {
+		names = []string{"id"}
+	}
+	for _, name := range names {
+		var field *protogen.Field
+		for _, f := range message.Fields {
+			if string(f.Desc.Name()) == name {
+				field = f
+			}
+		}
+		switch {
+		case field == nil:
+			return key, fmt.Errorf("message %s: no primary key field %s", message.Desc.FullName(), name)
+		case field.Desc.IsList() || field.Message != nil || isOptionalScalar(field) || columnOptions(field).GetIgnore():
+			return key, fmt.Errorf("field %s: a primary key must be a singular, non optional scalar column", field.Desc.FullName())
+		}
+		key.fields = append(key.fields, field)
+	}
+	single := key.fields[0]
+	integer := len(key.fields) == 1 && isIntegerKind(single.Desc.Kind())
+	switch key.strategy {
+	case simple.KeyStrategy_KEY_STRATEGY_AUTO:
+		key.strategy = simple.KeyStrategy_KEY_STRATEGY_NONE
+		if integer {
+			key.strategy = simple.KeyStrategy_KEY_STRATEGY_AUTO_INCREMENT
+		}
+	case simple.KeyStrategy_KEY_STRATEGY_AUTO_INCREMENT:
+		if !integer {
+			return key, fmt.Errorf("message %s: %v requires a single integer key", message.Desc.FullName(), key.strategy)
+		}
+	case simple.KeyStrategy_KEY_STRATEGY_UUID_V7:
+		if len(key.fields) != 1 || single.Desc.Kind() != protoreflect.StringKind {
+			return key, fmt.Errorf("message %s: %v requires a single string key", message.Desc.FullName(), key.strategy)
+		}
+	case simple.KeyStrategy_KEY_STRATEGY_SNOWFLAKE:
+		// A snowflake id does not fit a 32-bit key.
+		if len(key.fields) != 1 || !is64BitIntegerKind(single.Desc.Kind()) && single.Desc.Kind() != protoreflect.StringKind {
+			return key, fmt.Errorf("message %s: %v requires a single 64-bit integer or string key", message.Desc.FullName(), key.strategy)
+		}
+	}
+	return key, nil
+}
+
+func isIntegerKind(kind protoreflect.Kind) bool {
+	switch kind {
+	case protoreflect.BoolKind, protoreflect.EnumKind, protoreflect.FloatKind, protoreflect.DoubleKind,
+		protoreflect.StringKind, protoreflect.BytesKind, protoreflect.MessageKind, protoreflect.GroupKind:
+		return false
+	}
+	return true
+}
+
+func is64BitIntegerKind(kind protoreflect.Kind) bool {
+	switch kind {
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return true
+	}
+	return false
+}
+
+// isKeyField reports whether field is a primary key field of its model.
+func isKeyField(field *protogen.Field) bool {
+	names := tableOptions(field.Parent).GetPrimaryKey()
+	if len(names) == 0 {
+		names = []string{"id"}
+	}
+	for _, name := range names {
+		if string(field.Desc.Name()) == name {
+			return !hasBaseModel(field.Parent)
+		}
+	}
+	return false
+}
+
+// keyTag returns the gorm tag settings of a primary key field.
+func keyTag(field *protogen.Field) string {
+	key, err := primaryKey(field.Parent)
+	if err != nil || !isIntegerKind(field.Desc.Kind()) {
+		return "primaryKey;"
+	}
+	if key.strategy == simple.KeyStrategy_KEY_STRATEGY_AUTO_INCREMENT {
+		return "primaryKey;autoIncrement;"
+	}
+	return "primaryKey;autoIncrement:false;"
+}
+
+// keyParam returns the name of the function parameter holding field, which
+// must not clash with the ctx parameter.
+func keyParam(field *protogen.Field) string {
+	name := lowerFirstLatter(field.GoName)
+	if token.IsKeyword(name) || name == "ctx" {
+		name += "_"
+	}
+	return name
+}
+
+// params returns the parameter list of the functions looking up a model by
+// its primary key.
+func (k modelKey) params(g *protogen.GeneratedFile) (string, error) {
+	if k.fields == nil {
+		return "id int64", nil
+	}
+	var params []string
+	for _, field := range k.fields {
+		ct, err := fieldColumnType(g, field, simple.Storage_STORAGE_AUTO)
+		if err != nil {
+			return "", err
+		}
+		params = append(params, keyParam(field)+" "+ct.goType)
+	}
+	return strings.Join(params, ", "), nil
+}
+
+// paramNames returns the names of the key parameters, separated by commas.
+func (k modelKey) paramNames() string {
+	if k.fields == nil {
+		return "id"
+	}
+	var names []string
+	for _, field := range k.fields {
+		names = append(names, keyParam(field))
+	}
+	return strings.Join(names, ", ")
+}
+
+// where returns the arguments of db.Where matching the key parameters.
+func (k modelKey) where() string {
+	if k.fields == nil {
+		return `"id = ?", id`
+	}
+	var conds, args []string
+	for _, field := range k.fields {
+		conds = append(conds, columnName(field)+" = ?")
+		args = append(args, keyParam(field))
+	}
+	return fmt.Sprintf("%q, %s", strings.Join(conds, " AND "), strings.Join(args, ", "))
+}
+
+// args returns the key values taken from the proto message expr, in the
+// order of the key parameters.
+func (k modelKey) args(expr string) string {
+	if k.fields == nil {
+		return expr + ".Id"
+	}
+	var args []string
+	for _, field := range k.fields {
+		args = append(args, modelValue(field, expr+"."+field.GoName))
+	}
+	return strings.Join(args, ", ")
+}
+
+// literal returns the model struct literal holding only the key values taken
+// from the proto message expr.
+func (k modelKey) literal(g *protogen.GeneratedFile, model protogen.GoIdent, expr string) string {
+	if k.fields == nil {
+		return fmt.Sprintf("%s{BASE_MODEL: %s{ID: %s.Id}}", g.QualifiedGoIdent(model), g.QualifiedGoIdent(storePackage().Ident("BASE_MODEL")), expr)
+	}
+	var values []string
+	for _, field := range k.fields {
+		values = append(values, field.GoName+": "+modelValue(field, expr+"."+field.GoName))
+	}
+	return fmt.Sprintf("%s{%s}", g.QualifiedGoIdent(model), strings.Join(values, ", "))
+}
+
+// generateKeyHook generates the BeforeCreate hook assigning the primary key
+// of a new model for the UUID v7 and snowflake strategies.
+func generateKeyHook(g *protogen.GeneratedFile, key modelKey, modelName string) error {
+	if key.strategy != simple.KeyStrategy_KEY_STRATEGY_UUID_V7 && key.strategy != simple.KeyStrategy_KEY_STRATEGY_SNOWFLAKE {
+		return nil
+	}
+	field := key.fields[0]
+	if key.strategy == simple.KeyStrategy_KEY_STRATEGY_SNOWFLAKE && snowflakeFlag.GoName == "" {
+		return fmt.Errorf("message %s: %v requires the snowflake parameter", field.Parent.Desc.FullName(), key.strategy)
+	}
+	zero := "0"
+	if field.Desc.Kind() == protoreflect.StringKind {
+		zero = `""`
+	}
+	var assign string
+	switch {
+	case key.strategy == simple.KeyStrategy_KEY_STRATEGY_UUID_V7:
+		assign = fmt.Sprintf(`id, err := %s()
+			if err != nil {
+				return err
+			}
+			model.%s = id.String()`, g.QualifiedGoIdent(uuidPackage.Ident("NewV7")), field.GoName)
+	case field.Desc.Kind() == protoreflect.StringKind:
+		assign = fmt.Sprintf(`model.%s = %s(%s(), 10)`, field.GoName, g.QualifiedGoIdent(strconvPackage.Ident("FormatInt")), g.QualifiedGoIdent(snowflakeFlag.ident()))
+	case kindColumnTypes[field.Desc.Kind()].goType == "int64":
+		assign = fmt.Sprintf(`model.%s = %s()`, field.GoName, g.QualifiedGoIdent(snowflakeFlag.ident()))
+	default:
+		assign = fmt.Sprintf(`model.%s = %s(%s())`, field.GoName, kindColumnTypes[field.Desc.Kind()].goType, g.QualifiedGoIdent(snowflakeFlag.ident()))
+	}
+	g.P(fmt.Sprintf(`// BeforeCreate generates the primary key of a new %[1]s.
+		func (model *%[1]s) BeforeCreate(tx *%[2]s) error {
+			if model.%[3]s == %[4]s {
+				%[5]s
+			}
+			return nil
+		}
+`, modelName, g.QualifiedGoIdent(GormPackage.Ident("DB")), field.GoName, zero, assign))
+	return nil
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wwengg/protoc-gen-simple/simple"
+)
+
+func TestPrimaryKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		fields   string
+		strategy simple.KeyStrategy
+		err      string
+	}{{
+		name:     "base model",
+		message:  `options { [simple.model]: true } field { name: "id" number: 1 type: TYPE_INT64 }`,
+		strategy: simple.KeyStrategy_KEY_STRATEGY_AUTO_INCREMENT,
+	}, {
+		name:    "base model with a primary key",
+		message: `options { [simple.table] { primary_key: "code" } } field { name: "code" number: 1 type: TYPE_STRING }`,
+		err:     "primary_key requires base_model = false",
+	}, {
+		name:    "base model with a uuid key",
+		message: `options { [simple.table] { key_strategy: KEY_STRATEGY_UUID_V7 } }`,
+		err:     "KEY_STRATEGY_UUID_V7 requires base_model = false",
+	}, {
+		name:    "base model with a string id",
+		message: `field { name: "id" number: 1 type: TYPE_STRING }`,
+		err:     "the ID of the base model is an int64",
+	}, {
+		name:     "integer id",
+		message:  `options { [simple.table] { base_model: false } } field { name: "id" number: 1 type: TYPE_UINT32 }`,
+		fields:   "id",
+		strategy: simple.KeyStrategy_KEY_STRATEGY_AUTO_INCREMENT,
+	}, {
+		name:     "string key",
+		message:  `options { [simple.table] { base_model: false primary_key: "code" } } field { name: "code" number: 1 type: TYPE_STRING }`,
+		fields:   "code",
+		strategy: simple.KeyStrategy_KEY_STRATEGY_NONE,
+	}, {
+		name: "composite key",
+		message: `options { [simple.table] { base_model: false primary_key: ["tenant_id", "name"] } }
+			field { name: "name" number: 1 type: TYPE_STRING } field { name: "tenant_id" number: 2 type: TYPE_INT64 }`,
+		fields:   "tenant_id, name",
+		strategy: simple.KeyStrategy_KEY_STRATEGY_NONE,
+	}, {
+		name:     "uuid key",
+		message:  `options { [simple.table] { base_model: false key_strategy: KEY_STRATEGY_UUID_V7 } } field { name: "id" number: 1 type: TYPE_STRING }`,
+		fields:   "id",
+		strategy: simple.KeyStrategy_KEY_STRATEGY_UUID_V7,
+	}, {
+		name:    "uuid integer key",
+		message: `options { [simple.table] { base_model: false key_strategy: KEY_STRATEGY_UUID_V7 } } field { name: "id" number: 1 type: TYPE_INT64 }`,
+		err:     "KEY_STRATEGY_UUID_V7 requires a single string key",
+	}, {
+		name:     "snowflake string key",
+		message:  `options { [simple.table] { base_model: false key_strategy: KEY_STRATEGY_SNOWFLAKE } } field { name: "id" number: 1 type: TYPE_STRING }`,
+		fields:   "id",
+		strategy: simple.KeyStrategy_KEY_STRATEGY_SNOWFLAKE,
+	}, {
+		name:    "snowflake bool key",
+		message: `options { [simple.table] { base_model: false key_strategy: KEY_STRATEGY_SNOWFLAKE } } field { name: "id" number: 1 type: TYPE_BOOL }`,
+		err:     "KEY_STRATEGY_SNOWFLAKE requires a single 64-bit integer or string key",
+	}, {
+		name:     "snowflake uint64 key",
+		message:  `options { [simple.table] { base_model: false key_strategy: KEY_STRATEGY_SNOWFLAKE } } field { name: "id" number: 1 type: TYPE_UINT64 }`,
+		fields:   "id",
+		strategy: simple.KeyStrategy_KEY_STRATEGY_SNOWFLAKE,
+	}, {
+		// The id would be truncated.
+		name:    "snowflake int32 key",
+		message: `options { [simple.table] { base_model: false key_strategy: KEY_STRATEGY_SNOWFLAKE } } field { name: "id" number: 1 type: TYPE_INT32 }`,
+		err:     "KEY_STRATEGY_SNOWFLAKE requires a single 64-bit integer or string key",
+	}, {
+		name:    "auto increment string key",
+		message: `options { [simple.table] { base_model: false key_strategy: KEY_STRATEGY_AUTO_INCREMENT } } field { name: "id" number: 1 type: TYPE_STRING }`,
+		err:     "KEY_STRATEGY_AUTO_INCREMENT requires a single integer key",
+	}, {
+		name:    "missing key",
+		message: `options { [simple.table] { base_model: false primary_key: "code" } } field { name: "id" number: 1 type: TYPE_INT64 }`,
+		err:     "no primary key field code",
+	}, {
+		name:    "repeated key",
+		message: `options { [simple.table] { base_model: false } } field { name: "id" number: 1 type: TYPE_INT64 label: LABEL_REPEATED }`,
+		err:     "a primary key must be a singular, non optional scalar column",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := testPlugin(t, testFile("keys", `message_type { name: "Model" `+tt.message+` }`))
+			key, err := primaryKey(testMessage(t, gen, "Model"))
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("primaryKey() error = %v, want %s", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var fields []string
+			for _, field := range key.fields {
+				fields = append(fields, string(field.Desc.Name()))
+			}
+			if got := strings.Join(fields, ", "); got != tt.fields || key.strategy != tt.strategy {
+				t.Errorf("primaryKey() = (%s) %v, want (%s) %v", got, key.strategy, tt.fields, tt.strategy)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// isLockedModel reports whether updates of the model of message use
+// optimistic locking on its version field.
+func isLockedModel(message *protogen.Message) bool {
+	return tableOptions(message).GetOptimisticLock()
+}
+
+// versionField returns the version field of a model with optimistic locking.
+func versionField(message *protogen.Message) (*protogen.Field, error) {
+	for _, field := range modelFields(message) {
+		if field.Desc.Name() != "version" {
+			continue
+		}
+		if !isIntegerKind(field.Desc.Kind()) || field.Desc.IsList() || isOptionalScalar(field) || isOneofField(field) || isKeyField(field) {
+			return nil, fmt.Errorf("field %s: the version of optimistic locking must be a singular, non optional integer column", field.Desc.FullName())
+		}
+		return field, nil
+	}
+	return nil, fmt.Errorf("message %s: optimistic_lock requires an integer version field", message.Desc.FullName())
+}
+
+// versionConflictError returns the name of the error returned by the updates
+// of the model modelName when its version changed.
+func versionConflictError(modelName string) string {
+	return "Err" + modelName + "VersionConflict"
+}
+
+// generateVersionConflictError generates the version conflict error of a
+// model with optimistic locking.
+func generateVersionConflictError(g *protogen.GeneratedFile, message *protogen.Message, modelName string) error {
+	if !isLockedModel(message) {
+		return nil
+	}
+	if _, err := versionField(message); err != nil {
+		return err
+	}
+	g.P(fmt.Sprintf(`// %s is returned by the updates of %s when the row was changed
+		// or deleted since its version was read.
+		var %[1]s = %[3]s("%[2]s: version conflict, the record was changed")
+`, versionConflictError(modelName), modelName, g.QualifiedGoIdent(errorsPackage.Ident("New"))))
+	return nil
+}
+
+// lockedUpdate returns the statements running the gorm update of a ending
+// with query, restricted to the key and the version of a and incrementing it.
+// The key is matched explicitly since gorm leaves out zero keys, which would
+// update every row at the version. The version of a is restored when the
+// update fails.
+func lockedUpdate(message *protogen.Message, modelName, query string) (string, error) {
+	field, err := versionField(message)
+	if err != nil {
+		return "", err
+	}
+	key, err := primaryKey(message)
+	if err != nil {
+		return "", err
+	}
+	conds, args := []string{"id = ?"}, []string{"a.ID"}
+	if key.fields != nil {
+		conds, args = nil, nil
+		for _, f := range key.fields {
+			conds = append(conds, columnName(f)+" = ?")
+			args = append(args, "a."+f.GoName)
+		}
+	}
+	conds = append(conds, columnName(field)+" = ?")
+	args = append(args, "version")
+	return fmt.Sprintf(`version := a.%[1]s
+			a.%[1]s++
+			result := r.db.WithContext(ctx).Model(a).Where(%[2]q, %[5]s)%[3]s
+			if result.Error == nil && result.RowsAffected == 0 {
+				result.Error = %[4]s
+			}
+			if result.Error != nil {
+				a.%[1]s = version
+			}
+			return result.Error`, field.GoName, strings.Join(conds, " AND "), query, versionConflictError(modelName), strings.Join(args, ", ")), nil
+}
+
+// versionConflictCase returns the else-if branch of the impl templates
+// handling the version conflict error of the model of message, running
+// assign formatted with the VersionConflict reply code. It returns "" for
+// models without optimistic locking.
+func versionConflictCase(g *protogen.GeneratedFile, file *protogen.File, message *protogen.Message, assign string) (string, error) {
+	if !isLockedModel(message) {
+		return "", nil
+	}
+	commonPath := commonPackage(file)
+	if commonPath == file.GoImportPath {
+		for _, enum := range file.Enums {
+			if enum.Desc.Name() != "EnumCode" {
+				continue
+			}
+			if enum.Desc.Values().ByName("VersionConflict") == nil {
+				return "", fmt.Errorf("enum %s: optimistic_lock of %s requires a VersionConflict value", enum.Desc.FullName(), message.Desc.FullName())
+			}
+		}
+	}
+	conflict := modelPackage(file).importPath.Ident(versionConflictError(modelName(message)))
+	return fmt.Sprintf(`}else if %s(err, %s){
+				`, g.QualifiedGoIdent(errorsPackage.Ident("Is")), g.QualifiedGoIdent(conflict)) +
+		fmt.Sprintf(assign, g.QualifiedGoIdent(commonPath.Ident("EnumCode_VersionConflict"))) + "\n", nil
+}
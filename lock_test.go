@@ -0,0 +1,45 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLockedUpdateKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		options string
+		want    string
+	}{{
+		name:    "base model",
+		options: `[simple.table] { optimistic_lock: true }`,
+		want:    `Model(a).Where("id = ? AND version = ?", a.ID, version)`,
+	}, {
+		name:    "custom key",
+		options: `[simple.table] { base_model: false primary_key: "code" optimistic_lock: true }`,
+		want:    `Model(a).Where("code = ? AND version = ?", a.Code, version)`,
+	}, {
+		name:    "composite key",
+		options: `[simple.table] { base_model: false primary_key: ["tenant_id", "code"] optimistic_lock: true }`,
+		want:    `Model(a).Where("tenant_id = ? AND code = ? AND version = ?", a.TenantId, a.Code, version)`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := testPlugin(t, testFile("lock", `message_type {
+				name: "DocModel" options { `+tt.options+` }
+				field { name: "tenant_id" number: 1 type: TYPE_INT64 }
+				field { name: "code" number: 2 type: TYPE_STRING }
+				field { name: "version" number: 3 type: TYPE_INT64 }
+			}`))
+			// A zero key must not match the rows of other keys at the same
+			// version, gorm adds no key condition for it.
+			got, err := lockedUpdate(testMessage(t, gen, "DocModel"), "Doc", ".Updates(a)")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("locked update has no %s:\n%s", tt.want, got)
+			}
+		})
+	}
+}
@@ -3,7 +3,7 @@ package main
 import (
 	"flag"
 	"fmt"
-	"strings"
+	"os"
 
 	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/types/pluginpb"
@@ -13,37 +13,35 @@ const version = "0.0.7"
 
 func main() {
 	showVersion := flag.Bool("version", false, "print the version and exit")
+	showGenerators := flag.Bool("list", false, "print the available generators and exit")
 	flag.Parse()
 	if *showVersion {
 		fmt.Printf("protoc-gen-simple %v\n", version)
 		return
 	}
+	if *showGenerators {
+		listGenerators(os.Stdout)
+		return
+	}
 
 	protogen.Options{
 		ParamFunc: flag.CommandLine.Set,
-	}.Run(func(gen *protogen.Plugin) error {
-		gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
-		for _, f := range gen.Files {
-			if !f.Generate {
-				continue
-			}
-			//generateFile(gen, f)
-			if len(f.Messages) > 0 {
-				for _, message := range f.Messages {
-					if _, found := strings.CutSuffix(string(message.Desc.Name()), "Model"); found {
-						generateModelFile(gen, f, message)
-						generateTableFile(gen, f, message)
-					}
-				}
-			}
-			if len(f.Services) > 0 {
-				for _, service := range f.Services {
-					generateSimpleServerCode(gen, f, service)
-					generateApiCode(gen, f, service)
-				}
-			}
+	}.Run(generate)
+}
 
+// generate runs the enabled generators over the files to generate.
+func generate(gen *protogen.Plugin) error {
+	gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
+	for _, f := range gen.Files {
+		if !f.Generate {
+			continue
 		}
-		return nil
-	})
+		if err := runGenerators(gen, f); err != nil {
+			return err
+		}
+		if err := checkSchema(gen, f, os.Stderr); err != nil {
+			return err
+		}
+	}
+	return nil
 }
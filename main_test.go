@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/wwengg/protoc-gen-simple/simple"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+var update = flag.Bool("update", false, "update the golden files of the generator tests")
+
+// setParams sets the plugin parameters params, as passed to --simple_opt,
+// and restores their defaults when t ends.
+func setParams(t *testing.T, params string) {
+	t.Helper()
+	t.Cleanup(func() {
+		selectedGenerators = nil
+		skippedGenerators = map[string]bool{}
+		previous.once = sync.Once{}
+		previous.plugin, previous.err = nil, nil
+	})
+	for _, param := range strings.Split(params, ",") {
+		if param == "" {
+			continue
+		}
+		name, value, _ := strings.Cut(param, "=")
+		f := flag.Lookup(name)
+		if f == nil {
+			t.Fatalf("unknown parameter %q", name)
+		}
+		if err := f.Value.Set(value); err != nil {
+			t.Fatalf("parameter %s: %v", param, err)
+		}
+		t.Cleanup(func() {
+			switch v := f.Value.(type) {
+			case generatorFlag:
+			case *goIdentFlag:
+				*v = goIdentFlag{}
+				if f.DefValue != "" {
+					v.Set(f.DefValue)
+				}
+			default:
+				v.Set(f.DefValue)
+			}
+		})
+	}
+}
+
+// testPlugin returns the plugin generating the files written as
+// FileDescriptorProto text in srcs, which may import simple/options.proto
+// and google/protobuf/field_mask.proto.
+func testPlugin(t *testing.T, srcs ...string) *protogen.Plugin {
+	t.Helper()
+	req := &pluginpb.CodeGeneratorRequest{ProtoFile: []*descriptorpb.FileDescriptorProto{
+		protodesc.ToFileDescriptorProto(descriptorpb.File_google_protobuf_descriptor_proto),
+		protodesc.ToFileDescriptorProto(fieldmaskpb.File_google_protobuf_field_mask_proto),
+		protodesc.ToFileDescriptorProto(durationpb.File_google_protobuf_duration_proto),
+		protodesc.ToFileDescriptorProto(wrapperspb.File_google_protobuf_wrappers_proto),
+		protodesc.ToFileDescriptorProto(simple.File_simple_options_proto),
+	}}
+	for _, src := range srcs {
+		fd := new(descriptorpb.FileDescriptorProto)
+		if err := prototext.Unmarshal([]byte(src), fd); err != nil {
+			t.Fatalf("%v\n%s", err, src)
+		}
+		req.ProtoFile = append(req.ProtoFile, fd)
+		req.FileToGenerate = append(req.FileToGenerate, fd.GetName())
+	}
+	gen, err := protogen.Options{}.New(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return gen
+}
+
+// testFile returns the text of the FileDescriptorProto of a proto3 file
+// named name declaring messages, also written as text.
+func testFile(name, messages string) string {
+	return `name: "` + name + `.proto" package: "` + name + `" syntax: "proto3"
+		dependency: "simple/options.proto" dependency: "google/protobuf/field_mask.proto"
+		dependency: "google/protobuf/duration.proto" dependency: "google/protobuf/wrappers.proto"
+		options { go_package: "example.com/app/pb/` + name + `" }
+		` + messages
+}
+
+// testMessage returns the message name of the last file of gen.
+func testMessage(t *testing.T, gen *protogen.Plugin, name string) *protogen.Message {
+	t.Helper()
+	file := gen.Files[len(gen.Files)-1]
+	for _, message := range file.Messages {
+		if string(message.Desc.Name()) == name {
+			return message
+		}
+	}
+	t.Fatalf("no message %s in %s", name, file.Desc.Path())
+	return nil
+}
+
+// TestGolden runs the plugin over the protos of testdata/golden, whose
+// descriptor set golden.pb is written by
+//
+//	protoc -I testdata/golden -I . --include_imports --include_source_info \
+//		--descriptor_set_out=testdata/golden/golden.pb testdata/golden/*.proto
+//
+// and compares the generated files with the ones in testdata/golden/out.
+// Run go test -update to rewrite them.
+func TestGolden(t *testing.T) {
+	b, err := os.ReadFile("testdata/golden/golden.pb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	set := new(descriptorpb.FileDescriptorSet)
+	if err := proto.Unmarshal(b, set); err != nil {
+		t.Fatal(err)
+	}
+	protos, err := filepath.Glob("testdata/golden/*.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &pluginpb.CodeGeneratorRequest{ProtoFile: set.File, Parameter: proto.String("module=example.com/app")}
+	for _, p := range protos {
+		req.FileToGenerate = append(req.FileToGenerate, filepath.Base(p))
+	}
+	setParams(t, "gen=model+impl+sql,snowflake=github.com/wwengg/simple/core/snow.Next")
+	gen, err := protogen.Options{ParamFunc: flag.CommandLine.Set}.New(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := generate(gen); err != nil {
+		t.Fatal(err)
+	}
+	resp := gen.Response()
+	if resp.Error != nil {
+		t.Fatal(resp.GetError())
+	}
+	out := filepath.Join("testdata", "golden", "out")
+	if *update {
+		if err := os.RemoveAll(out); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var names []string
+	for _, f := range resp.File {
+		name := filepath.Join(out, filepath.FromSlash(f.GetName()))
+		names = append(names, name)
+		if *update {
+			if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(name, []byte(f.GetContent()), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		want, err := os.ReadFile(name)
+		if err != nil {
+			t.Errorf("%v (run go test -update)", err)
+			continue
+		}
+		if !bytes.Equal(want, []byte(f.GetContent())) {
+			t.Errorf("%s differs from the generated file (run go test -update and review the diff)", name)
+		}
+	}
+	var golden []string
+	filepath.Walk(out, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			golden = append(golden, path)
+		}
+		return nil
+	})
+	sort.Strings(names)
+	if !*update && strings.Join(golden, "\n") != strings.Join(names, "\n") {
+		t.Errorf("generated files:\n%s\nwant:\n%s", strings.Join(names, "\n"), strings.Join(golden, "\n"))
+	}
+}
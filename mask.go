@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wwengg/protoc-gen-simple/simple"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+const fieldMaskName = "google.protobuf.FieldMask"
+
+// maskPath is a field mask path accepted by UpdateFields and the columns it
+// updates.
+type maskPath struct {
+	path    string
+	columns []string
+}
+
+// maskPaths returns the field mask paths of the model of message: the proto
+// names of its columns and oneofs. The primary key, created_at and the
+// version of optimistic locking are never set from a mask.
+func maskPaths(message *protogen.Message) ([]maskPath, error) {
+	var fields []*protogen.Field
+	// Setting one field of a oneof stored as columns clears the others, so
+	// every path of the oneof updates all of its columns.
+	oneofs := make(map[*protogen.Oneof][]string)
+	for _, field := range modelFields(message) {
+		storage, err := fieldStorage(field)
+		if err != nil {
+			return nil, err
+		}
+		if storage == simple.Storage_STORAGE_RELATION || isKeyField(field) || columnName(field) == "created_at" ||
+			isLockedModel(message) && field.Desc.Name() == "version" {
+			continue
+		}
+		fields = append(fields, field)
+		if isOneofField(field) {
+			if oneofs[field.Oneof] == nil {
+				oneofs[field.Oneof] = []string{oneofColumn(field.Oneof)}
+			}
+			if !isJSONOneof(field.Oneof) {
+				oneofs[field.Oneof] = append(oneofs[field.Oneof], columnName(field))
+			}
+		}
+	}
+	var paths []maskPath
+	seen := make(map[*protogen.Oneof]bool)
+	for _, field := range fields {
+		if !isOneofField(field) {
+			paths = append(paths, maskPath{string(field.Desc.Name()), []string{columnName(field)}})
+			continue
+		}
+		if !seen[field.Oneof] {
+			seen[field.Oneof] = true
+			paths = append(paths, maskPath{string(field.Oneof.Desc.Name()), oneofs[field.Oneof]})
+		}
+		paths = append(paths, maskPath{string(field.Desc.Name()), oneofs[field.Oneof]})
+	}
+	return paths, nil
+}
+
+// oneofColumn returns the discriminator column of oneof, or its JSON column.
+func oneofColumn(oneof *protogen.Oneof) string {
+	if column := oneofOptions(oneof).GetName(); column != "" {
+		return column
+	}
+	if isJSONOneof(oneof) {
+		return ToSnakeCase(oneof.GoName)
+	}
+	return ToSnakeCase(oneof.GoName) + "_case"
+}
+
+// generateUpdateFieldsMethod generates the UpdateFields method of the
+// repository repo, updating only the columns of the field mask paths.
+func generateUpdateFieldsMethod(g *protogen.GeneratedFile, message *protogen.Message, modelName, repo string) error {
+	paths, err := maskPaths(message)
+	if err != nil {
+		return err
+	}
+	columns := lowerFirstLatter(modelName) + "UpdateColumns"
+	update := "return r.db.WithContext(ctx).Model(a).Select(columns).Updates(a).Error"
+	if isLockedModel(message) {
+		field, err := versionField(message)
+		if err != nil {
+			return err
+		}
+		if update, err = lockedUpdate(message, modelName, ".Select(columns).Updates(a)"); err != nil {
+			return err
+		}
+		update = fmt.Sprintf("columns = append(columns, %q)\n", columnName(field)) + update
+	}
+	g.P(fmt.Sprintf(`// %s maps the field mask paths of UpdateFields to the columns they
+		// update.
+		var %[1]s = map[string][]string{`, columns))
+	for _, p := range paths {
+		g.P(fmt.Sprintf(`%q: {"%s"},`, p.path, strings.Join(p.columns, `", "`)))
+	}
+	g.P(fmt.Sprintf(`}
+
+		func (r *%[1]s) UpdateFields(ctx %[2]s, a *%[3]s, paths []string) error {
+			if len(paths) == 0 {
+				return %[4]s("Update%[3]sFields: empty field mask")
+			}
+			var columns []string
+			for _, path := range paths {
+				c, ok := %[5]s[path]
+				if !ok {
+					return %[4]s("Update%[3]sFields: unknown or read-only field %%q", path)
+				}
+				columns = append(columns, c...)
+			}
+			%[6]s
+		}
+`, repo, g.QualifiedGoIdent(contextPackage.Ident("Context")), modelName, g.QualifiedGoIdent(fmtPackage.Ident("Errorf")), columns, update))
+	return nil
+}
+
+// maskArgs returns the field mask field of the request input of an Update
+// method and the field holding the model of message, or nil when input has
+// no field mask.
+func maskArgs(message, input *protogen.Message) (mask, data *protogen.Field, err error) {
+	for _, field := range input.Fields {
+		if field.Message != nil && field.Message.Desc.FullName() == fieldMaskName && !field.Desc.IsList() {
+			mask = field
+		}
+	}
+	if mask == nil {
+		return nil, nil, nil
+	}
+	for _, field := range input.Fields {
+		if field.Message == message && !field.Desc.IsList() {
+			return mask, field, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("message %s: an update with a field mask requires a %s field", input.Desc.FullName(), message.Desc.Name())
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestMaskPaths(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{{
+		name: "base model",
+		message: `options { [simple.model]: true }
+			field { name: "id" number: 1 type: TYPE_INT64 }
+			field { name: "name" number: 2 type: TYPE_STRING }
+			field { name: "created_at" number: 3 type: TYPE_STRING }
+			field { name: "age" number: 4 type: TYPE_INT32 proto3_optional: true oneof_index: 2 }
+			field { name: "email" number: 5 type: TYPE_STRING oneof_index: 0 }
+			field { name: "phone" number: 6 type: TYPE_STRING oneof_index: 0 }
+			field { name: "a" number: 7 type: TYPE_INT64 oneof_index: 1 }
+			field { name: "b" number: 8 type: TYPE_STRING oneof_index: 1 }
+			oneof_decl { name: "contact" }
+			oneof_decl { name: "extra" options { [simple.oneof] { storage: ONEOF_STORAGE_JSON } } }
+			oneof_decl { name: "_age" }`,
+		want: "name:[name] age:[age] contact:[contact_case email phone] email:[contact_case email phone] phone:[contact_case email phone] extra:[extra] a:[extra] b:[extra]",
+	}, {
+		name: "custom key and column names",
+		message: `options { [simple.table] { base_model: false primary_key: "code" } }
+			field { name: "code" number: 1 type: TYPE_STRING }
+			field { name: "body" number: 2 type: TYPE_STRING options { [simple.column] { name: "content" } } }
+			field { name: "created_at" number: 3 type: TYPE_STRING }
+			field { name: "skipped" number: 4 type: TYPE_STRING options { [simple.column] { ignore: true } } }`,
+		want: "body:[content]",
+	}, {
+		name: "optimistic lock",
+		message: `options { [simple.table] { optimistic_lock: true } }
+			field { name: "title" number: 1 type: TYPE_STRING }
+			field { name: "version" number: 2 type: TYPE_INT64 }`,
+		want: "title:[title]",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := testPlugin(t, testFile("mask", `message_type { name: "ProfileModel" `+tt.message+` }`))
+			paths, err := maskPaths(testMessage(t, gen, "ProfileModel"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, p := range paths {
+				got = append(got, fmt.Sprintf("%s:%v", p.path, p.columns))
+			}
+			if strings.Join(got, " ") != tt.want {
+				t.Errorf("maskPaths() = %s, want %s", strings.Join(got, " "), tt.want)
+			}
+		})
+	}
+}
+
+func TestMaskArgs(t *testing.T) {
+	gen := testPlugin(t, testFile("mask", `message_type { name: "ProfileModel" options { [simple.model]: true } field { name: "name" number: 1 type: TYPE_STRING } }
+		message_type {
+			name: "UpdateArgs"
+			field { name: "profile" number: 1 type: TYPE_MESSAGE type_name: ".mask.ProfileModel" }
+			field { name: "update_mask" number: 2 type: TYPE_MESSAGE type_name: ".google.protobuf.FieldMask" }
+		}
+		message_type { name: "NoModelArgs" field { name: "update_mask" number: 1 type: TYPE_MESSAGE type_name: ".google.protobuf.FieldMask" } }`))
+	model := testMessage(t, gen, "ProfileModel")
+	mask, data, err := maskArgs(model, testMessage(t, gen, "UpdateArgs"))
+	if err != nil || mask == nil || mask.GoName != "UpdateMask" || data == nil || data.GoName != "Profile" {
+		t.Errorf("maskArgs(UpdateArgs) = %v, %v, %v, want UpdateMask and Profile", mask, data, err)
+	}
+	if mask, data, err := maskArgs(model, model); mask != nil || data != nil || err != nil {
+		t.Errorf("maskArgs(ProfileModel) = %v, %v, %v, want no field mask", mask, data, err)
+	}
+	if _, _, err := maskArgs(model, testMessage(t, gen, "NoModelArgs")); err == nil {
+		t.Error("maskArgs(NoModelArgs) succeeded, want an error for the missing ProfileModel field")
+	}
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+var (
+	previousFlag         = flag.String("previous", "", "FileDescriptorSet of the previous schema, written by protoc --include_imports --descriptor_set_out; the sql generator writes a migration from it and model changes are checked against it")
+	migrationVersionFlag = flag.String("migration_version", "", "version prefixing the migration file names, required with previous")
+)
+
+var previous struct {
+	once   sync.Once
+	plugin *protogen.Plugin
+	err    error
+}
+
+// previousPlugin returns the proto files of the previous schema, loaded from
+// the descriptor set named by the previous parameter. The Go packages of the
+// files are resolved with the M, paths and module parameters of gen.
+func previousPlugin(gen *protogen.Plugin) (*protogen.Plugin, error) {
+	previous.once.Do(func() {
+		b, err := os.ReadFile(*previousFlag)
+		if err != nil {
+			previous.err = err
+			return
+		}
+		set := new(descriptorpb.FileDescriptorSet)
+		if err := proto.Unmarshal(b, set); err != nil {
+			previous.err = fmt.Errorf("%s: %v", *previousFlag, err)
+			return
+		}
+		var params []string
+		for _, param := range strings.Split(gen.Request.GetParameter(), ",") {
+			name := param
+			if i := strings.Index(param, "="); i >= 0 {
+				name = param[:i]
+			}
+			if name == "paths" || name == "module" || strings.HasPrefix(name, "M") {
+				params = append(params, param)
+			}
+		}
+		previous.plugin, previous.err = protogen.Options{}.New(&pluginpb.CodeGeneratorRequest{
+			ProtoFile: set.File,
+			Parameter: proto.String(strings.Join(params, ",")),
+		})
+		if previous.err != nil {
+			previous.err = fmt.Errorf("%s: %v", *previousFlag, previous.err)
+		}
+	})
+	return previous.plugin, previous.err
+}
+
+// generateMigration writes the statements migrating the tables of file from
+// the previous schema to tables, when the previous parameter is set and the
+// schema changed.
+func generateMigration(gen *protogen.Plugin, file *protogen.File, d *sqlDialect, dir string, tables []sqlTable) error {
+	if *previousFlag == "" {
+		return nil
+	}
+	if *migrationVersionFlag == "" {
+		return fmt.Errorf("the previous parameter requires migration_version")
+	}
+	prev, err := previousPlugin(gen)
+	if err != nil {
+		return err
+	}
+	filename := path.Join(dir, *migrationVersionFlag+"_"+path.Base(file.GeneratedFilenamePrefix)+".up.sql")
+	g := gen.NewGeneratedFile(filename, file.GoImportPath)
+	var old []sqlTable
+	if prevFile, ok := prev.FilesByPath[file.Desc.Path()]; ok {
+		if old, err = fileTables(g, prevFile); err != nil {
+			return fmt.Errorf("previous schema: %v", err)
+		}
+	}
+	stmts := d.migrate(old, tables)
+	if len(stmts) == 0 {
+		g.Skip()
+		return nil
+	}
+	generateSQLHeader(g, gen, file, d)
+	for _, stmt := range stmts {
+		g.P(stmt)
+	}
+	return nil
+}
+
+// migrate returns the statements changing the tables old into tables.
+func (d *sqlDialect) migrate(old, tables []sqlTable) []string {
+	oldTables := make(map[string]sqlTable)
+	for _, t := range old {
+		oldTables[t.name] = t
+	}
+	var stmts []string
+	for _, t := range tables {
+		o, ok := oldTables[t.name]
+		delete(oldTables, t.name)
+		if !ok {
+			stmts = append(stmts, d.createTable(t)...)
+			continue
+		}
+		stmts = append(stmts, d.alterTable(o, t)...)
+	}
+	for _, name := range sortedTableNames(oldTables) {
+		stmts = append(stmts, "DROP TABLE "+d.ident(name)+";")
+	}
+	return stmts
+}
+
+// alterTable returns the statements changing the table o into t.
+func (d *sqlDialect) alterTable(o, t sqlTable) []string {
+	var stmts []string
+	table := d.ident(t.name)
+	newIndexes := make(map[string]sqlIndex)
+	for _, index := range t.indexes {
+		newIndexes[index.name] = index
+	}
+	oldIndexes := make(map[string]sqlIndex)
+	for _, index := range o.indexes {
+		oldIndexes[index.name] = index
+		if n, ok := newIndexes[index.name]; !ok || !reflect.DeepEqual(n, index) {
+			stmts = append(stmts, d.dropIndex(o, index))
+		}
+	}
+	for _, c := range t.columns {
+		oc := o.column(c.name)
+		switch {
+		case oc.name == "":
+			stmts = append(stmts, "ALTER TABLE "+table+" ADD COLUMN "+d.columnDef(t, c)+";")
+			if stmt := d.commentOn(t, c); stmt != "" {
+				stmts = append(stmts, stmt)
+			}
+		case oc != c:
+			stmts = append(stmts, d.alterColumn(t, oc, c)...)
+		}
+	}
+	for _, c := range o.columns {
+		if t.column(c.name).name == "" {
+			stmts = append(stmts, "ALTER TABLE "+table+" DROP COLUMN "+d.ident(c.name)+";")
+		}
+	}
+	if !reflect.DeepEqual(o.primaryKey, t.primaryKey) {
+		switch d.name {
+		case "mysql":
+			stmts = append(stmts, "ALTER TABLE "+table+" DROP PRIMARY KEY, ADD PRIMARY KEY ("+d.idents(t.primaryKey)+");")
+		case "postgres":
+			stmts = append(stmts,
+				"ALTER TABLE "+table+" DROP CONSTRAINT "+d.ident(t.name+"_pkey")+";",
+				"ALTER TABLE "+table+" ADD PRIMARY KEY ("+d.idents(t.primaryKey)+");")
+		default:
+			stmts = append(stmts, fmt.Sprintf("-- TODO: %s cannot change the primary key of %s to (%s), rebuild the table.", d.name, t.name, d.idents(t.primaryKey)))
+		}
+	}
+	for _, index := range t.indexes {
+		if o, ok := oldIndexes[index.name]; !ok || !reflect.DeepEqual(o, index) {
+			stmts = append(stmts, d.createIndex(t, index))
+		}
+	}
+	return stmts
+}
+
+// alterColumn returns the statements changing the column o of t into c.
+func (d *sqlDialect) alterColumn(t sqlTable, o, c sqlColumn) []string {
+	table := d.ident(t.name)
+	column := d.ident(c.name)
+	switch d.name {
+	case "mysql":
+		return []string{"ALTER TABLE " + table + " MODIFY COLUMN " + d.columnDef(t, c) + ";"}
+	case "postgres":
+		var stmts []string
+		if d.columnType(o.sqlType) != d.columnType(c.sqlType) {
+			stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s;", table, column, d.columnType(c.sqlType)))
+		}
+		if o.notNull != c.notNull {
+			action := "DROP NOT NULL"
+			if c.notNull {
+				action = "SET NOT NULL"
+			}
+			stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s;", table, column, action))
+		}
+		if o.defaultValue != c.defaultValue {
+			action := "DROP DEFAULT"
+			if c.defaultValue != "" {
+				action = "SET DEFAULT " + c.defaultValue
+			}
+			stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s;", table, column, action))
+		}
+		if o.autoIncrement != c.autoIncrement {
+			action := "DROP IDENTITY IF EXISTS"
+			if c.autoIncrement {
+				action = "ADD GENERATED BY DEFAULT AS IDENTITY"
+			}
+			stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s;", table, column, action))
+		}
+		if o.comment != c.comment {
+			comment := "NULL"
+			if c.comment != "" {
+				comment = sqlString(c.comment)
+			}
+			stmts = append(stmts, fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s;", table, column, comment))
+		}
+		return stmts
+	}
+	if d.columnDef(t, o) == d.columnDef(t, c) {
+		return nil
+	}
+	return []string{fmt.Sprintf("-- TODO: %s cannot alter column %s.%s to %s, rebuild the table.", d.name, t.name, c.name, d.columnDef(t, c))}
+}
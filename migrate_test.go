@@ -0,0 +1,109 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestMigrate(t *testing.T) {
+	id := sqlColumn{name: "id", sqlType: "bigint", notNull: true, autoIncrement: true}
+	old := []sqlTable{{
+		name:       "users",
+		columns:    []sqlColumn{id, {name: "name", sqlType: "varchar(64)"}, {name: "age", sqlType: "int"}},
+		primaryKey: []string{"id"},
+		indexes:    []sqlIndex{{name: "idx_users_name", columns: []string{"name"}, desc: []bool{false}}},
+	}, {
+		name:       "logs",
+		columns:    []sqlColumn{id},
+		primaryKey: []string{"id"},
+	}}
+	tables := []sqlTable{{
+		name:       "users",
+		columns:    []sqlColumn{id, {name: "name", sqlType: "varchar(128)"}, {name: "email", sqlType: "varchar(255)", notNull: true, defaultValue: "''"}},
+		primaryKey: []string{"id"},
+		indexes:    []sqlIndex{{name: "idx_users_name", unique: true, columns: []string{"name"}, desc: []bool{false}}},
+	}, {
+		name:       "posts",
+		columns:    []sqlColumn{id, {name: "title", sqlType: "varchar(255)", comment: "title"}},
+		primaryKey: []string{"id"},
+	}}
+	tests := []struct {
+		dialect string
+		want    []string
+	}{{
+		dialect: "mysql",
+		want: []string{
+			"DROP INDEX `idx_users_name` ON `users`;",
+			"ALTER TABLE `users` MODIFY COLUMN `name` varchar(128);",
+			"ALTER TABLE `users` ADD COLUMN `email` varchar(255) NOT NULL DEFAULT '';",
+			"ALTER TABLE `users` DROP COLUMN `age`;",
+			"CREATE UNIQUE INDEX `idx_users_name` ON `users` (`name`);",
+			"CREATE TABLE `posts` (\n  `id` bigint NOT NULL AUTO_INCREMENT,\n  `title` varchar(255) COMMENT 'title',\n  PRIMARY KEY (`id`)\n);",
+			"DROP TABLE `logs`;",
+		},
+	}, {
+		dialect: "postgres",
+		want: []string{
+			`DROP INDEX "idx_users_name";`,
+			`ALTER TABLE "users" ALTER COLUMN "name" TYPE varchar(128);`,
+			`ALTER TABLE "users" ADD COLUMN "email" varchar(255) NOT NULL DEFAULT '';`,
+			`ALTER TABLE "users" DROP COLUMN "age";`,
+			`CREATE UNIQUE INDEX "idx_users_name" ON "users" ("name");`,
+			"CREATE TABLE \"posts\" (\n  \"id\" bigint GENERATED BY DEFAULT AS IDENTITY NOT NULL,\n  \"title\" varchar(255),\n  PRIMARY KEY (\"id\")\n);",
+			`COMMENT ON COLUMN "posts"."title" IS 'title';`,
+			`DROP TABLE "logs";`,
+		},
+	}, {
+		dialect: "sqlite",
+		want: []string{
+			`DROP INDEX "idx_users_name";`,
+			`ALTER TABLE "users" ADD COLUMN "email" text NOT NULL DEFAULT '';`,
+			`ALTER TABLE "users" DROP COLUMN "age";`,
+			`CREATE UNIQUE INDEX "idx_users_name" ON "users" ("name");`,
+			"CREATE TABLE \"posts\" (\n  \"id\" integer PRIMARY KEY AUTOINCREMENT,\n  \"title\" text\n);",
+			`DROP TABLE "logs";`,
+		},
+	}}
+	for _, tt := range tests {
+		got := sqlDialects[tt.dialect].migrate(old, tables)
+		if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+			t.Errorf("%s migrate() =\n%s\nwant:\n%s", tt.dialect, strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+		}
+	}
+	if got := sqlDialects["mysql"].migrate(tables, tables); len(got) != 0 {
+		t.Errorf("migrate() of unchanged tables = %q, want none", got)
+	}
+}
+
+func TestPreviousPluginParams(t *testing.T) {
+	// The previous file has no go_package, like the files compiled with
+	// M parameters.
+	old := new(descriptorpb.FileDescriptorProto)
+	if err := prototext.Unmarshal([]byte(`name: "shop.proto" package: "shop" syntax: "proto3" dependency: "simple/options.proto"`+itemModel), old); err != nil {
+		t.Fatal(err)
+	}
+	b, err := proto.Marshal(&descriptorpb.FileDescriptorSet{File: append(testPlugin(t).Request.ProtoFile, old)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	previous := filepath.Join(t.TempDir(), "previous.pb")
+	if err := os.WriteFile(previous, b, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	setParams(t, "previous="+previous)
+	gen := testPlugin(t, testFile("shop", itemModel))
+	gen.Request.Parameter = proto.String("Mshop.proto=example.com/app/pb/shop;shop,paths=source_relative,gen=sql,migration_version=2")
+	prev, err := previousPlugin(gen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := prev.FilesByPath["shop.proto"].GoImportPath, gen.FilesByPath["shop.proto"].GoImportPath; got != want {
+		t.Errorf("previous go package = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wwengg/protoc-gen-simple/simple"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// isOneofField reports whether field belongs to a oneof declared in the proto
+// file, as opposed to the synthetic oneof of a proto3 optional field.
+func isOneofField(field *protogen.Field) bool {
+	return field.Oneof != nil && !field.Oneof.Desc.IsSynthetic()
+}
+
+// oneofFields returns the fields of oneof stored in the model.
+func oneofFields(oneof *protogen.Oneof) []*protogen.Field {
+	var fields []*protogen.Field
+	for _, field := range oneof.Fields {
+		if !columnOptions(field).GetIgnore() {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// isJSONOneof reports whether oneof is stored as a single JSON column.
+func isJSONOneof(oneof *protogen.Oneof) bool {
+	return oneofOptions(oneof).GetStorage() == simple.OneofStorage_ONEOF_STORAGE_JSON
+}
+
+// oneofStructName returns the name of the struct holding a oneof stored as
+// JSON.
+func oneofStructName(message *protogen.Message, oneof *protogen.Oneof) string {
+	return modelName(message) + oneof.GoName
+}
+
+// generateModelOneof generates the struct fields of the model storing oneof
+// and returns their converter. A oneof is stored either as a discriminator
+// column named <Oneof>Case plus one nullable column per field, or as a JSON
+// column holding the struct generated by generateOneofStruct.
+func generateModelOneof(g *protogen.GeneratedFile, message *protogen.Message, oneof *protogen.Oneof) (fieldConverter, error) {
+	name := oneof.GoName
+	column := oneofOptions(oneof).GetName()
+	if isJSONOneof(oneof) {
+		if column == "" {
+			column = ToSnakeCase(name)
+		}
+		g.P(fmt.Sprintf(`		%s  *%s `, name, oneofStructName(message, oneof)) + "`" + fmt.Sprintf(`json:"%s" gorm:"column:%s;serializer:json;type:%s;"`, lowerFirstLatter(name), column, kindColumnTypes[protoreflect.MessageKind].sqlType) + "`")
+		return oneofJSONConverter(g, message, oneof), nil
+	}
+	if column == "" {
+		column = ToSnakeCase(name) + "_case"
+	}
+	g.P(fmt.Sprintf(`		%sCase  string `, name) + "`" + fmt.Sprintf(`json:"%sCase" gorm:"column:%s;type:varchar(64);"`, lowerFirstLatter(name), column) + "`")
+	for _, field := range oneofFields(oneof) {
+		if err := generateModelFiled(g, message, field); err != nil {
+			return fieldConverter{}, err
+		}
+	}
+	return oneofColumnsConverter(g, oneof), nil
+}
+
+// generateOneofStruct generates the struct holding a oneof stored as JSON,
+// with one nil-able field per oneof field.
+func generateOneofStruct(g *protogen.GeneratedFile, message *protogen.Message, oneof *protogen.Oneof) error {
+	structName := oneofStructName(message, oneof)
+	g.P(fmt.Sprintf(`// %s holds the %s oneof of %s, stored as JSON.
+		type %s struct {`, structName, oneof.Desc.Name(), modelName(message), structName))
+	for _, field := range oneofFields(oneof) {
+		storage, err := fieldStorage(field)
+		if err != nil {
+			return err
+		}
+		ct, err := fieldColumnType(g, field, storage)
+		if err != nil {
+			return err
+		}
+		g.P(fmt.Sprintf(`		%s  %s `, field.GoName, ct.goType) + "`" + fmt.Sprintf(`json:"%s,omitempty"`, field.Desc.JSONName()) + "`")
+	}
+	g.P(`		}`)
+	g.P()
+	return nil
+}
+
+// oneofColumnsConverter converts a oneof stored as a discriminator column
+// holding the name of the set field plus one column per field.
+func oneofColumnsConverter(g *protogen.GeneratedFile, oneof *protogen.Oneof) fieldConverter {
+	name := oneof.GoName
+	var toProto, toModel strings.Builder
+	for _, field := range oneofFields(oneof) {
+		fmt.Fprintf(&toProto, `
+			case %[1]q:
+				if model.%[2]s != nil {
+					proto.%[3]s = %[4]s
+				}`, field.Desc.Name(), field.GoName, name, oneofCaseToProto(g, field, "model."+field.GoName))
+		fmt.Fprintf(&toModel, `
+			case *%[1]s:
+				model.%[2]sCase = %[3]q
+				%[4]s`, g.QualifiedGoIdent(field.GoIdent), name, field.Desc.Name(), oneofCaseToModel(field, "model."+field.GoName))
+	}
+	if toProto.Len() == 0 {
+		return fieldConverter{goName: name}
+	}
+	return fieldConverter{
+		goName: name,
+		toProtoStmt: fmt.Sprintf(`switch model.%sCase {%s
+			}`, name, toProto.String()),
+		toModelStmt: fmt.Sprintf(`switch v := proto.%s.(type) {%s
+			}`, name, toModel.String()),
+	}
+}
+
+// oneofJSONConverter converts a oneof stored as a JSON column holding the
+// struct generated by generateOneofStruct.
+func oneofJSONConverter(g *protogen.GeneratedFile, message *protogen.Message, oneof *protogen.Oneof) fieldConverter {
+	name := oneof.GoName
+	var toProto, toModel strings.Builder
+	for _, field := range oneofFields(oneof) {
+		value := "model." + name + "." + field.GoName
+		fmt.Fprintf(&toProto, `
+				case %[1]s != nil:
+					proto.%[2]s = %[3]s`, value, name, oneofCaseToProto(g, field, value))
+		fmt.Fprintf(&toModel, `
+			case *%[1]s:
+				model.%[2]s = &%[3]s{}
+				%[4]s`, g.QualifiedGoIdent(field.GoIdent), name, oneofStructName(message, oneof), oneofCaseToModel(field, value))
+	}
+	if toProto.Len() == 0 {
+		return fieldConverter{goName: name}
+	}
+	return fieldConverter{
+		goName: name,
+		toProtoStmt: fmt.Sprintf(`if model.%s != nil {
+				switch {%s
+				}
+			}`, name, toProto.String()),
+		toModelStmt: fmt.Sprintf(`switch v := proto.%s.(type) {%s
+			}`, name, toModel.String()),
+	}
+}
+
+// oneofCaseToProto returns the oneof wrapper of field holding the model value
+// expr, which is not nil.
+func oneofCaseToProto(g *protogen.GeneratedFile, field *protogen.Field, expr string) string {
+	if isOptionalScalar(field) && field.Desc.Kind() != protoreflect.BytesKind {
+		expr = protoValue(g, field, "*"+expr)
+	}
+	return fmt.Sprintf("&%s{%s: %s}", g.QualifiedGoIdent(field.GoIdent), field.GoName, expr)
+}
+
+// oneofCaseToModel returns the statements assigning the value of the oneof
+// wrapper v of field to the model value target.
+func oneofCaseToModel(field *protogen.Field, target string) string {
+	value := "v." + field.GoName
+	switch {
+	case !isOptionalScalar(field) || field.Desc.Kind() == protoreflect.BytesKind:
+		return target + " = " + value
+	case field.Desc.Kind() == protoreflect.EnumKind:
+		return fmt.Sprintf(`x := %s
+				%s = &x`, modelValue(field, value), target)
+	default:
+		return target + " = &" + value
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"strings"
+
+	"github.com/wwengg/protoc-gen-simple/simple"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+)
+
+var modelSuffixFlag = flag.String("model_suffix", "", "also treat messages whose name ends with this suffix as models, e.g. Model")
+
+// isModel reports whether message is generated as a model. A message is a
+// model when it sets (simple.model) = true or, with the model_suffix
+// parameter, when its name ends with that suffix.
+func isModel(message *protogen.Message) bool {
+	if model, _ := proto.GetExtension(message.Desc.Options(), simple.E_Model).(bool); model {
+		return true
+	}
+	name := string(message.Desc.Name())
+	return *modelSuffixFlag != "" && name != *modelSuffixFlag && strings.HasSuffix(name, *modelSuffixFlag)
+}
+
+// modelName returns the Go name of the model generated for message, which is
+// the message name without its Model suffix.
+func modelName(message *protogen.Message) string {
+	suffix := *modelSuffixFlag
+	if suffix == "" {
+		suffix = "Model"
+	}
+	name := string(message.Desc.Name())
+	if trimmed := strings.TrimSuffix(name, suffix); trimmed != "" {
+		return trimmed
+	}
+	return name
+}
+
+// columnOptions returns the (simple.column) option of field, nil when unset.
+func columnOptions(field *protogen.Field) *simple.ColumnOptions {
+	opts, _ := proto.GetExtension(field.Desc.Options(), simple.E_Column).(*simple.ColumnOptions)
+	return opts
+}
+
+// tableOptions returns the (simple.table) option of message, nil when unset.
+func tableOptions(message *protogen.Message) *simple.TableOptions {
+	opts, _ := proto.GetExtension(message.Desc.Options(), simple.E_Table).(*simple.TableOptions)
+	return opts
+}
+
+// oneofOptions returns the (simple.oneof) option of oneof, nil when unset.
+func oneofOptions(oneof *protogen.Oneof) *simple.OneofOptions {
+	opts, _ := proto.GetExtension(oneof.Desc.Options(), simple.E_Oneof).(*simple.OneofOptions)
+	return opts
+}
+
+// methodOptions returns the (simple.method) option of method, nil when unset.
+func methodOptions(method *protogen.Method) *simple.MethodOptions {
+	opts, _ := proto.GetExtension(method.Desc.Options(), simple.E_Method).(*simple.MethodOptions)
+	return opts
+}
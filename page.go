@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wwengg/protoc-gen-simple/simple"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// isKeysetModel reports whether the model of message is paginated with
+// Get<Model>Page.
+func isKeysetModel(message *protogen.Message) bool {
+	return tableOptions(message).GetPagination() == simple.Pagination_PAGINATION_KEYSET
+}
+
+// generatePageMethod generates the GetPage method of the repository repo and
+// the cursor type it encodes the position of the last row of a page in.
+func generatePageMethod(g *protogen.GeneratedFile, modelName, repo string, key modelKey, preload bool) error {
+	cursor := lowerFirstLatter(modelName) + "Cursor"
+	var names, columns []string
+	g.P(fmt.Sprintf(`// %s is the position of the last row of a page of GetPage.
+		type %[1]s struct {`, cursor))
+	if key.fields == nil {
+		g.P("ID int64 `json:\"id\"`")
+		names, columns = []string{"ID"}, []string{"id"}
+	}
+	for _, field := range key.fields {
+		ct, err := fieldColumnType(g, field, simple.Storage_STORAGE_AUTO)
+		if err != nil {
+			return err
+		}
+		g.P(fmt.Sprintf("%s %s `json:\"%s\"`", field.GoName, ct.goType, columnName(field)))
+		names, columns = append(names, field.GoName), append(columns, columnName(field))
+	}
+	g.P("}")
+	g.P()
+	// The rows after the cursor are spelled out column by column,
+	// a > ? OR (a = ? AND b > ?), rather than compared as a row value, which
+	// not every database supports.
+	var conds, values []string
+	for i := range columns {
+		var terms []string
+		for j := 0; j < i; j++ {
+			terms = append(terms, columns[j]+" = ?")
+			values = append(values, "c."+names[j])
+		}
+		terms = append(terms, columns[i]+" > ?")
+		values = append(values, "c."+names[i])
+		cond := strings.Join(terms, " AND ")
+		if i > 0 {
+			cond = "(" + cond + ")"
+		}
+		conds = append(conds, cond)
+	}
+	where := fmt.Sprintf(`"%s", %s`, strings.Join(conds, " OR "), strings.Join(values, ", "))
+	var last []string
+	for _, name := range names {
+		last = append(last, name+": last."+name)
+	}
+	query := "db.Order(%q).Limit(limit + 1)"
+	if preload {
+		query = "Preload" + modelName + "(" + query + ")"
+	}
+	query = fmt.Sprintf(query, strings.Join(columns, ", "))
+	base64 := g.QualifiedGoIdent(base64Package.Ident("RawURLEncoding"))
+	g.P(fmt.Sprintf(`func (r *%[1]s) GetPage(ctx %[2]s, cursor string, limit int, filter *%[3]sFilter) (list []%[3]s, next string, err error) {
+			if limit <= 0 {
+				return nil, "", %[4]s("Get%[3]sPage: limit must be positive, got %%d", limit)
+			}
+			if filter != nil && len(filter.Sort) > 0 {
+				return nil, "", %[4]s("Get%[3]sPage: pages are ordered by the primary key, sort is not supported")
+			}
+			db := filter.Scope(r.db.WithContext(ctx).Model(&%[3]s{}))
+			if cursor != "" {
+				var c %[5]s
+				b, err := %[6]s.DecodeString(cursor)
+				if err == nil {
+					err = %[7]s(b, &c)
+				}
+				if err != nil {
+					return nil, "", %[4]s("Get%[3]sPage: invalid cursor %%q", cursor)
+				}
+				db = db.Where(%[8]s)
+			}
+			if err = %[9]s.Find(&list).Error; err != nil {
+				return nil, "", err
+			}
+			if len(list) > limit {
+				list = list[:limit]
+				last := list[limit-1]
+				b, err := %[10]s(%[5]s{%[11]s})
+				if err != nil {
+					return nil, "", err
+				}
+				next = %[6]s.EncodeToString(b)
+			}
+			return list, next, nil
+		}
+`, repo, g.QualifiedGoIdent(contextPackage.Ident("Context")), modelName, g.QualifiedGoIdent(fmtPackage.Ident("Errorf")), cursor, base64,
+		g.QualifiedGoIdent(jsonPackage.Ident("Unmarshal")), where, query, g.QualifiedGoIdent(jsonPackage.Ident("Marshal")), strings.Join(last, ", ")))
+	return nil
+}
+
+// pageArgs returns the statement setting limit, read from the limit or
+// page_info.page_size field of the request input and 10 when unset like the
+// page size of FindList, and the statement setting the next cursor of the
+// reply output. GetPage is called with the cursor field and limit. It
+// returns "" when input has no cursor field.
+func pageArgs(message, input, output *protogen.Message) (setLimit, setNext string, err error) {
+	cursor := messageField(input, "Cursor")
+	if cursor == nil {
+		return "", "", nil
+	}
+	if !isKeysetModel(message) {
+		return "", "", fmt.Errorf("field %s: a cursor requires the PAGINATION_KEYSET pagination of %s", cursor.Desc.FullName(), message.Desc.FullName())
+	}
+	if cursor.Desc.Kind() != protoreflect.StringKind || cursor.Desc.IsList() {
+		return "", "", fmt.Errorf("field %s: the cursor must be a string", cursor.Desc.FullName())
+	}
+	var limit, unset string
+	if f := messageField(input, "Limit"); f != nil && isIntegerKind(f.Desc.Kind()) && !f.Desc.IsList() {
+		limit, unset = "int(args.GetLimit())", "limit"
+	} else if f := messageField(input, "PageInfo"); f != nil && f.Message != nil && messageField(f.Message, "PageSize") != nil {
+		limit, unset = "int(args.GetPageInfo().GetPageSize())", "page_info"
+	} else {
+		return "", "", fmt.Errorf("message %s: a cursor requires an integer limit or a page_info with page_size", input.Desc.FullName())
+	}
+	next := messageField(output, "NextCursor")
+	if next == nil || next.Desc.Kind() != protoreflect.StringKind || next.Desc.IsList() || next.Desc.HasPresence() {
+		return "", "", fmt.Errorf("message %s: a cursor requires a string next_cursor in the reply %s", input.Desc.FullName(), output.Desc.FullName())
+	}
+	setLimit = fmt.Sprintf(`limit := %s
+		if limit == 0 {
+			// 未传 %s 时每页 10 条
+			limit = 10
+		}
+`, limit, unset)
+	return setLimit, "reply.NextCursor = next", nil
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGeneratePageMethodKeyset(t *testing.T) {
+	tests := []struct {
+		name    string
+		options string
+		want    string
+	}{{
+		name:    "id",
+		options: `pagination: PAGINATION_KEYSET`,
+		want:    `db.Where("id > ?", c.ID)`,
+	}, {
+		name:    "two columns",
+		options: `base_model: false primary_key: ["a", "b"] pagination: PAGINATION_KEYSET`,
+		want:    `db.Where("a > ? OR (a = ? AND b > ?)", c.A, c.A, c.B)`,
+	}, {
+		name:    "three columns",
+		options: `base_model: false primary_key: ["a", "b", "c"] pagination: PAGINATION_KEYSET`,
+		want:    `db.Where("a > ? OR (a = ? AND b > ?) OR (a = ? AND b = ? AND c > ?)", c.A, c.A, c.B, c.A, c.B, c.C)`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := testPlugin(t, testFile("page", `message_type {
+				name: "RowModel" options { [simple.table] { `+tt.options+` } }
+				field { name: "a" number: 1 type: TYPE_INT64 }
+				field { name: "b" number: 2 type: TYPE_STRING }
+				field { name: "c" number: 3 type: TYPE_INT32 }
+			}`))
+			message := testMessage(t, gen, "RowModel")
+			key, err := primaryKey(message)
+			if err != nil {
+				t.Fatal(err)
+			}
+			file := gen.Files[len(gen.Files)-1]
+			g := gen.NewGeneratedFile("page_model.go", file.GoImportPath)
+			g.P("package model")
+			if err := generatePageMethod(g, "Row", "rowRepository", key, false); err != nil {
+				t.Fatal(err)
+			}
+			b, err := g.Content()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(b), tt.want) {
+				t.Errorf("GetPage has no %s:\n%s", tt.want, b)
+			}
+		})
+	}
+}
+
+func TestPageArgsDefaultLimit(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{{
+		name:  "limit",
+		input: `field { name: "limit" number: 2 type: TYPE_INT32 }`,
+		want:  "limit := int(args.GetLimit())\n\t\tif limit == 0 {\n\t\t\t// 未传 limit 时每页 10 条\n\t\t\tlimit = 10\n\t\t}\n",
+	}, {
+		name:  "page_info",
+		input: `field { name: "page_info" number: 2 type: TYPE_MESSAGE type_name: ".page.PageInfo" }`,
+		want:  "limit := int(args.GetPageInfo().GetPageSize())\n\t\tif limit == 0 {\n\t\t\t// 未传 page_info 时每页 10 条\n\t\t\tlimit = 10\n\t\t}\n",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := testPlugin(t, testFile("page", `message_type {
+				name: "RowModel" options { [simple.table] { pagination: PAGINATION_KEYSET } }
+				field { name: "name" number: 1 type: TYPE_STRING }
+			}
+			message_type {
+				name: "PageInfo"
+				field { name: "page" number: 1 type: TYPE_INT64 }
+				field { name: "page_size" number: 2 type: TYPE_INT64 }
+			}
+			message_type {
+				name: "ListArgs"
+				field { name: "cursor" number: 1 type: TYPE_STRING }
+				`+tt.input+`
+			}
+			message_type {
+				name: "ListReply"
+				field { name: "next_cursor" number: 1 type: TYPE_STRING }
+			}`))
+			// A request without a limit used to call GetPage with 0, which
+			// it rejects.
+			setLimit, _, err := pageArgs(testMessage(t, gen, "RowModel"), testMessage(t, gen, "ListArgs"), testMessage(t, gen, "ListReply"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if setLimit != tt.want {
+				t.Errorf("pageArgs() = %q, want %q", setLimit, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"path"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// Output location parameters. Directories are relative to --simple_out.
+var (
+	modelDirFlag     = flag.String("model_dir", "", "output directory of the model files (default: <proto dir>/model)")
+	modelPackageFlag = flag.String("model_package", "", "Go import path of the model package, optionally followed by ;name (default: <go_package>/model)")
+	implDirFlag      = flag.String("impl_dir", "", "output directory of the service implementations (default: <proto dir>/impl)")
+	implPackageFlag  = flag.String("impl_package", "", "Go import path of the impl package, optionally followed by ;name (default: <go_package>/impl)")
+	vueDirFlag       = flag.String("vue_dir", "", "output directory of the vue pages")
+	jsDirFlag        = flag.String("js_dir", "", "output directory of the api files")
+)
+
+// Packages and identifiers the generated code depends on.
+var (
+	dbFlag            = goIdentFlag{GoImportPath: "github.com/wwengg/simple/core/global", GoName: "DB_"}
+	storePackageFlag  = flag.String("store_package", string(SimpleStorePackage), "Go import path of the package providing BASE_MODEL")
+	commonPackageFlag = flag.String("common_package", "", "Go import path of the package providing PageInfo and EnumCode (default: <go_package>)")
+)
+
+func init() {
+	flag.Var(&dbFlag, "db", "package level *gorm.DB used by the model functions and by default by the impl services, as importpath.Name")
+}
+
+// storePackage returns the package providing store.BASE_MODEL.
+func storePackage() protogen.GoImportPath {
+	return protogen.GoImportPath(*storePackageFlag)
+}
+
+// commonPackage returns the package providing PageInfo and the EnumCode values.
+func commonPackage(file *protogen.File) protogen.GoImportPath {
+	if *commonPackageFlag == "" {
+		return file.GoImportPath
+	}
+	return protogen.GoImportPath(*commonPackageFlag)
+}
+
+// goIdentFlag implements flag.Value for parameters naming a Go identifier,
+// such as db=github.com/wwengg/simple/core/global.DB_.
+type goIdentFlag protogen.GoIdent
+
+func (f *goIdentFlag) String() string {
+	if f.GoName == "" {
+		return ""
+	}
+	return string(f.GoImportPath) + "." + f.GoName
+}
+
+func (f *goIdentFlag) Set(value string) error {
+	i := strings.LastIndex(value, ".")
+	if i <= strings.LastIndex(value, "/") || i == len(value)-1 {
+		return fmt.Errorf("invalid Go identifier %q, want importpath.Name", value)
+	}
+	f.GoImportPath, f.GoName = protogen.GoImportPath(value[:i]), value[i+1:]
+	return nil
+}
+
+// ident returns the identifier as a protogen.GoIdent.
+func (f *goIdentFlag) ident() protogen.GoIdent {
+	return protogen.GoIdent(*f)
+}
+
+// goPackage is the Go package and directory an artifact is generated into.
+type goPackage struct {
+	importPath protogen.GoImportPath
+	name       protogen.GoPackageName
+	dir        string
+}
+
+// filename returns the output path of name inside the package directory.
+func (p goPackage) filename(name string) string {
+	return path.Join(p.dir, name)
+}
+
+func modelPackage(file *protogen.File) goPackage {
+	return artifactPackage(file, *modelDirFlag, *modelPackageFlag, "model")
+}
+
+// modelIdent returns the identifier name declared in the model package
+// generated for message, which may belong to another proto file.
+func modelIdent(message *protogen.Message, name string) protogen.GoIdent {
+	importPath, _ := artifactImportPath(message.GoIdent.GoImportPath, *modelPackageFlag, "model")
+	return importPath.Ident(name)
+}
+
+func implPackage(file *protogen.File) goPackage {
+	return artifactPackage(file, *implDirFlag, *implPackageFlag, "impl")
+}
+
+// artifactPackage resolves the package of an artifact. By default it is a
+// sub package named defaultName next to the Go package of file.
+func artifactPackage(file *protogen.File, dir, pkg, defaultName string) goPackage {
+	importPath, name := artifactImportPath(file.GoImportPath, pkg, defaultName)
+	p := goPackage{
+		importPath: importPath,
+		name:       name,
+		dir:        path.Join(path.Dir(file.GeneratedFilenamePrefix), defaultName),
+	}
+	if dir != "" {
+		p.dir = dir
+	}
+	return p
+}
+
+// artifactImportPath returns the import path and package name of an artifact
+// of the Go package pbPath, given the value pkg of its package parameter.
+func artifactImportPath(pbPath protogen.GoImportPath, pkg, defaultName string) (protogen.GoImportPath, protogen.GoPackageName) {
+	if pkg == "" {
+		return protogen.GoImportPath(path.Join(string(pbPath), defaultName)), protogen.GoPackageName(defaultName)
+	}
+	importPath, name := pkg, ""
+	if i := strings.Index(pkg, ";"); i >= 0 {
+		importPath, name = pkg[:i], pkg[i+1:]
+	}
+	if name == "" {
+		name = path.Base(importPath)
+	}
+	return protogen.GoImportPath(importPath), protogen.GoPackageName(name)
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+func TestGoIdentFlag(t *testing.T) {
+	tests := []struct {
+		value string
+		want  protogen.GoIdent
+		err   bool
+	}{
+		{value: "github.com/wwengg/simple/core/global.DB_", want: protogen.GoIdent{GoImportPath: "github.com/wwengg/simple/core/global", GoName: "DB_"}},
+		{value: "gopkg.in/db.v2.Conn", want: protogen.GoIdent{GoImportPath: "gopkg.in/db.v2", GoName: "Conn"}},
+		{value: "db.Conn", want: protogen.GoIdent{GoImportPath: "db", GoName: "Conn"}},
+		{value: "DB_", err: true},
+		{value: "example.com/global", err: true},
+		{value: "example.com/global.", err: true},
+		{value: "", err: true},
+	}
+	for _, tt := range tests {
+		var f goIdentFlag
+		err := f.Set(tt.value)
+		if tt.err {
+			if err == nil {
+				t.Errorf("Set(%q) = %v, want an error", tt.value, f.ident())
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Set(%q): %v", tt.value, err)
+			continue
+		}
+		if f.ident() != tt.want {
+			t.Errorf("Set(%q) = %v, want %v", tt.value, f.ident(), tt.want)
+		}
+		if f.String() != tt.value {
+			t.Errorf("String() = %q, want %q", f.String(), tt.value)
+		}
+	}
+}
+
+func TestArtifactPackage(t *testing.T) {
+	file := &protogen.File{GoImportPath: "example.com/app/pb/user", GeneratedFilenamePrefix: "pb/user/user"}
+	tests := []struct {
+		dir, pkg string
+		want     goPackage
+	}{
+		{"", "", goPackage{"example.com/app/pb/user/model", "model", "pb/user/model"}},
+		{"internal/model", "", goPackage{"example.com/app/pb/user/model", "model", "internal/model"}},
+		{"", "example.com/app/internal/store", goPackage{"example.com/app/internal/store", "store", "pb/user/model"}},
+		{"", "example.com/app/internal/store;dao", goPackage{"example.com/app/internal/store", "dao", "pb/user/model"}},
+		{"", "example.com/app/internal/store;", goPackage{"example.com/app/internal/store", "store", "pb/user/model"}},
+	}
+	for _, tt := range tests {
+		if got := artifactPackage(file, tt.dir, tt.pkg, "model"); got != tt.want {
+			t.Errorf("artifactPackage(%q, %q) = %+v, want %+v", tt.dir, tt.pkg, got, tt.want)
+		}
+	}
+	if got, want := artifactPackage(file, "", "", "model").filename("user_model.go"), "pb/user/model/user_model.go"; got != want {
+		t.Errorf("filename = %q, want %q", got, want)
+	}
+}
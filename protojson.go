@@ -0,0 +1,200 @@
+package main
+
+import (
+	"github.com/wwengg/protoc-gen-simple/simple"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+const (
+	reflectPackage   = protogen.GoImportPath("reflect")
+	protoPackage     = protogen.GoImportPath("google.golang.org/protobuf/proto")
+	protojsonPackage = protogen.GoImportPath("google.golang.org/protobuf/encoding/protojson")
+	schemaPackage    = protogen.GoImportPath("gorm.io/gorm/schema")
+)
+
+// protoJSONSerializerName is the name the serializer of the JSON columns
+// holding proto messages is registered with in gorm.
+const protoJSONSerializerName = "protojson"
+
+// protoJSONSerializers records the model packages whose serializer file was
+// generated, per plugin run.
+var protoJSONSerializers = make(map[*protogen.Plugin]map[protogen.GoImportPath]bool)
+
+// isProtoJSONField reports whether field, stored as JSON, holds proto
+// messages: a message, a list of messages or a map to messages. encoding/json
+// cannot decode their oneofs and ignores the protojson names and enum values,
+// so they are serialized with protojson. A Struct stored as a map is not.
+func isProtoJSONField(field *protogen.Field) bool {
+	if field.Desc.IsMap() {
+		return field.Message.Fields[1].Message != nil
+	}
+	return field.Message != nil && !(isStructField(field) && !isOneofField(field))
+}
+
+// jsonSerializer returns the gorm serializer of field stored as JSON.
+func jsonSerializer(field *protogen.Field) string {
+	if isProtoJSONField(field) {
+		return protoJSONSerializerName
+	}
+	return "json"
+}
+
+// usesProtoJSON reports whether the model of message has a column serialized
+// with protojson. The fields of a oneof stored as JSON are not columns.
+func usesProtoJSON(message *protogen.Message) (bool, error) {
+	for _, field := range modelFields(message) {
+		if isOneofField(field) && isJSONOneof(field.Oneof) {
+			continue
+		}
+		storage, err := fieldStorage(field)
+		if err != nil {
+			return false, err
+		}
+		if storage == simple.Storage_STORAGE_JSON && isProtoJSONField(field) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// generateProtoJSONSerializer generates, once per model package, the file
+// registering the gorm serializer of the JSON columns holding proto messages.
+func generateProtoJSONSerializer(gen *protogen.Plugin, file *protogen.File) {
+	pkg := modelPackage(file)
+	if protoJSONSerializers[gen] == nil {
+		protoJSONSerializers[gen] = make(map[protogen.GoImportPath]bool)
+	}
+	if protoJSONSerializers[gen][pkg.importPath] {
+		return
+	}
+	protoJSONSerializers[gen][pkg.importPath] = true
+	g := gen.NewGeneratedFile(pkg.filename("protojson_serializer.go"), pkg.importPath)
+	g.P("// Code generated by protoc-gen-simple. DO NOT EDIT.")
+	g.P("// versions:")
+	g.P("// - protoc-gen-simple v", version)
+	g.P("// - protoc          ", protocVersion(gen))
+	g.P()
+	g.P("package ", pkg.name)
+	g.P(`
+		func init() {
+			`, schemaPackage.Ident("RegisterSerializer"), `("`, protoJSONSerializerName, `", protoJSONSerializer{})
+		}
+
+		// protoJSONSerializer 以 protojson 序列化保存为 JSON 的 proto 消息、消息列表与消息 map 字段
+		type protoJSONSerializer struct{}
+
+		// Scan 将列值解码到字段
+		func (protoJSONSerializer) Scan(ctx `, contextPackage.Ident("Context"), `, field *`, schemaPackage.Ident("Field"), `, dst `, reflectPackage.Ident("Value"), `, dbValue interface{}) error {
+			var b []byte
+			switch v := dbValue.(type) {
+			case nil:
+			case []byte:
+				b = v
+			case string:
+				b = []byte(v)
+			default:
+				return `, fmtPackage.Ident("Errorf"), `("protojson: cannot scan %T into %s", dbValue, field.Name)
+			}
+			value := `, reflectPackage.Ident("New"), `(field.FieldType).Elem()
+			if len(b) > 0 {
+				if err := unmarshalProtoJSON(b, value); err != nil {
+					return `, fmtPackage.Ident("Errorf"), `("protojson: %s: %v", field.Name, err)
+				}
+			}
+			field.ReflectValueOf(ctx, dst).Set(value)
+			return nil
+		}
+
+		// Value 将字段编码为列值，nil 保存为 NULL
+		func (protoJSONSerializer) Value(ctx `, contextPackage.Ident("Context"), `, field *`, schemaPackage.Ident("Field"), `, dst `, reflectPackage.Ident("Value"), `, fieldValue interface{}) (interface{}, error) {
+			v := `, reflectPackage.Ident("ValueOf"), `(fieldValue)
+			if !v.IsValid() || v.IsNil() {
+				return nil, nil
+			}
+			b, err := marshalProtoJSON(v)
+			if err != nil {
+				return nil, err
+			}
+			return string(b), nil
+		}
+
+		// marshalProtoJSON 编码消息、消息列表或消息 map
+		func marshalProtoJSON(v `, reflectPackage.Ident("Value"), `) ([]byte, error) {
+			switch v.Kind() {
+			case `, reflectPackage.Ident("Slice"), `:
+				list := make([]`, jsonPackage.Ident("RawMessage"), `, v.Len())
+				for i := range list {
+					b, err := marshalProtoJSON(v.Index(i))
+					if err != nil {
+						return nil, err
+					}
+					list[i] = b
+				}
+				return `, jsonPackage.Ident("Marshal"), `(list)
+			case `, reflectPackage.Ident("Map"), `:
+				m := make(map[string]`, jsonPackage.Ident("RawMessage"), `, v.Len())
+				iter := v.MapRange()
+				for iter.Next() {
+					b, err := marshalProtoJSON(iter.Value())
+					if err != nil {
+						return nil, err
+					}
+					m[`, fmtPackage.Ident("Sprint"), `(iter.Key().Interface())] = b
+				}
+				return `, jsonPackage.Ident("Marshal"), `(m)
+			}
+			if v.IsNil() {
+				return []byte("null"), nil
+			}
+			return `, protojsonPackage.Ident("Marshal"), `(v.Interface().(`, protoPackage.Ident("Message"), `))
+		}
+
+		// unmarshalProtoJSON 将 b 解码到新建的消息、消息列表或消息 map 并赋给 v
+		func unmarshalProtoJSON(b []byte, v `, reflectPackage.Ident("Value"), `) error {
+			if string(b) == "null" {
+				return nil
+			}
+			switch v.Kind() {
+			case `, reflectPackage.Ident("Slice"), `:
+				var list []`, jsonPackage.Ident("RawMessage"), `
+				if err := `, jsonPackage.Ident("Unmarshal"), `(b, &list); err != nil {
+					return err
+				}
+				s := `, reflectPackage.Ident("MakeSlice"), `(v.Type(), len(list), len(list))
+				for i, item := range list {
+					if err := unmarshalProtoJSON(item, s.Index(i)); err != nil {
+						return err
+					}
+				}
+				v.Set(s)
+				return nil
+			case `, reflectPackage.Ident("Map"), `:
+				var m map[string]`, jsonPackage.Ident("RawMessage"), `
+				if err := `, jsonPackage.Ident("Unmarshal"), `(b, &m); err != nil {
+					return err
+				}
+				mv := `, reflectPackage.Ident("MakeMapWithSize"), `(v.Type(), len(m))
+				for k, item := range m {
+					key := `, reflectPackage.Ident("New"), `(v.Type().Key()).Elem()
+					if key.Kind() == `, reflectPackage.Ident("String"), ` {
+						key.SetString(k)
+					} else if err := `, jsonPackage.Ident("Unmarshal"), `([]byte(k), key.Addr().Interface()); err != nil {
+						return err
+					}
+					elem := `, reflectPackage.Ident("New"), `(v.Type().Elem()).Elem()
+					if err := unmarshalProtoJSON(item, elem); err != nil {
+						return err
+					}
+					mv.SetMapIndex(key, elem)
+				}
+				v.Set(mv)
+				return nil
+			}
+			m := `, reflectPackage.Ident("New"), `(v.Type().Elem())
+			if err := `, protojsonPackage.Ident("Unmarshal"), `(b, m.Interface().(`, protoPackage.Ident("Message"), `)); err != nil {
+				return err
+			}
+			v.Set(m)
+			return nil
+		}`)
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProtoJSONSerializer(t *testing.T) {
+	gen := testPlugin(t, testFile("pj", `message_type {
+		name: "Shape"
+		field { name: "circle" number: 1 type: TYPE_STRING oneof_index: 0 }
+		field { name: "side" number: 2 type: TYPE_INT64 oneof_index: 0 }
+		oneof_decl { name: "kind" }
+	}
+	message_type {
+		name: "PictureModel" options { [simple.model]: true }
+		field { name: "main" number: 1 type: TYPE_MESSAGE type_name: ".pj.Shape" }
+		field { name: "all" number: 2 label: LABEL_REPEATED type: TYPE_MESSAGE type_name: ".pj.Shape" }
+		field { name: "by_name" number: 3 label: LABEL_REPEATED type: TYPE_MESSAGE type_name: ".pj.PictureModel.ByNameEntry" }
+		field { name: "counters" number: 4 label: LABEL_REPEATED type: TYPE_MESSAGE type_name: ".pj.PictureModel.CountersEntry" }
+		field { name: "tags" number: 5 label: LABEL_REPEATED type: TYPE_STRING }
+		nested_type {
+			name: "ByNameEntry" options { map_entry: true }
+			field { name: "key" number: 1 type: TYPE_STRING }
+			field { name: "value" number: 2 type: TYPE_MESSAGE type_name: ".pj.Shape" }
+		}
+		nested_type {
+			name: "CountersEntry" options { map_entry: true }
+			field { name: "key" number: 1 type: TYPE_STRING }
+			field { name: "value" number: 2 type: TYPE_INT64 }
+		}
+	}
+	message_type {
+		name: "FrameModel" options { [simple.model]: true }
+		field { name: "shape" number: 1 type: TYPE_MESSAGE type_name: ".pj.Shape" }
+	}`))
+	want := map[string]string{
+		"main":     "protojson",
+		"all":      "protojson",
+		"by_name":  "protojson",
+		"counters": "json",
+		"tags":     "json",
+	}
+	for _, field := range testMessage(t, gen, "PictureModel").Fields {
+		if got := jsonSerializer(field); got != want[string(field.Desc.Name())] {
+			t.Errorf("%s serializer = %s, want %s", field.Desc.Name(), got, want[string(field.Desc.Name())])
+		}
+	}
+	// Both models share the serializer of their package.
+	file := gen.Files[len(gen.Files)-1]
+	for _, name := range []string{"PictureModel", "FrameModel"} {
+		if _, err := generateModelFile(gen, file, testMessage(t, gen, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var n int
+	for _, f := range gen.Response().File {
+		if strings.HasSuffix(f.GetName(), "/protojson_serializer.go") {
+			n++
+			if !strings.Contains(f.GetContent(), `schema.RegisterSerializer("protojson", protoJSONSerializer{})`) {
+				t.Errorf("%s registers no protojson serializer:\n%s", f.GetName(), f.GetContent())
+			}
+		}
+	}
+	if n != 1 {
+		t.Errorf("%d serializer files generated, want 1", n)
+	}
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wwengg/protoc-gen-simple/simple"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// fieldRelation returns the relation declared for field, mapping the legacy
+// STORAGE_CHILD_TABLE and STORAGE_FOREIGN_KEY onto has-many and belongs-to.
+func fieldRelation(field *protogen.Field) simple.RelationType {
+	opts := columnOptions(field)
+	switch opts.GetStorage() {
+	case simple.Storage_STORAGE_CHILD_TABLE:
+		return simple.RelationType_RELATION_HAS_MANY
+	case simple.Storage_STORAGE_FOREIGN_KEY:
+		return simple.RelationType_RELATION_BELONGS_TO
+	}
+	return opts.GetRelation().GetType()
+}
+
+// relationFields returns the fields of message stored as relations.
+func relationFields(message *protogen.Message) []*protogen.Field {
+	var fields []*protogen.Field
+	for _, field := range modelFields(message) {
+		if storage, _ := fieldStorage(field); storage == simple.Storage_STORAGE_RELATION {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// relationForeignKey returns the foreign key field of a relation: a field of
+// the related model for has-one and has-many relations, a field of the model
+// itself for belongs-to relations.
+func relationForeignKey(parent *protogen.Message, field *protogen.Field) string {
+	if fk := columnOptions(field).GetForeignKey(); fk != "" {
+		return fk
+	}
+	if fieldRelation(field) == simple.RelationType_RELATION_BELONGS_TO {
+		return field.GoName + "Id"
+	}
+	return modelName(parent) + "Id"
+}
+
+// referencedKey returns the model field a belongs-to relation refers to and
+// its column type: the references option or the primary key of the related
+// model.
+func referencedKey(g *protogen.GeneratedFile, field *protogen.Field) (string, columnType, error) {
+	if ref := columnOptions(field).GetRelation().GetReferences(); ref != "" {
+		if ref == "ID" && hasBaseModel(field.Message) {
+			return ref, kindColumnTypes[protoreflect.Int64Kind], nil
+		}
+		target := messageField(field.Message, ref)
+		if target == nil {
+			return "", columnType{}, fmt.Errorf("field %s: model %s has no field %s", field.Desc.FullName(), field.Message.Desc.FullName(), ref)
+		}
+		ct, err := fieldColumnType(g, target, simple.Storage_STORAGE_AUTO)
+		return ref, ct, err
+	}
+	ref, ct, err := keyColumn(g, field.Message)
+	if err != nil {
+		return "", columnType{}, fmt.Errorf("field %s: %v, set references", field.Desc.FullName(), err)
+	}
+	return ref, ct, nil
+}
+
+// keyColumn returns the model field and column type of the single column
+// primary key of the model of message.
+func keyColumn(g *protogen.GeneratedFile, message *protogen.Message) (string, columnType, error) {
+	key, err := primaryKey(message)
+	if err != nil {
+		return "", columnType{}, err
+	}
+	switch len(key.fields) {
+	case 0:
+		return "ID", kindColumnTypes[protoreflect.Int64Kind], nil
+	case 1:
+		ct, err := fieldColumnType(g, key.fields[0], simple.Storage_STORAGE_AUTO)
+		return key.fields[0].GoName, ct, err
+	}
+	return "", columnType{}, fmt.Errorf("model %s has a composite key", message.Desc.FullName())
+}
+
+// joinTable returns the join table of a many-to-many relation.
+func joinTable(parent *protogen.Message, field *protogen.Field) string {
+	if table := columnOptions(field).GetRelation().GetJoinTable(); table != "" {
+		return table
+	}
+	return ToSnakeCase(modelName(parent)) + "_" + ToSnakeCase(field.GoName)
+}
+
+// generateRelationField generates the association field of the model storing
+// the relation field, plus the foreign key column of a belongs-to relation
+// when the message does not declare it.
+func generateRelationField(g *protogen.GeneratedFile, message *protogen.Message, field *protogen.Field) error {
+	related := g.QualifiedGoIdent(modelIdent(field.Message, modelName(field.Message)))
+	var tag []string
+	switch fieldRelation(field) {
+	case simple.RelationType_RELATION_BELONGS_TO:
+		fk := relationForeignKey(message, field)
+		ref, ct, err := referencedKey(g, field)
+		if err != nil {
+			return err
+		}
+		if messageField(message, fk) == nil {
+			g.P(fmt.Sprintf(`		%s  %s `, fk, ct.goType) + "`" + fmt.Sprintf(`json:"%s" gorm:"column:%s;type:%s;index;"`, lowerFirstLatter(fk), ToSnakeCase(fk), ct.sqlType) + "`")
+		}
+		tag = append(tag, "foreignKey:"+fk)
+		if columnOptions(field).GetRelation().GetReferences() != "" {
+			tag = append(tag, "references:"+ref)
+		}
+	case simple.RelationType_RELATION_HAS_ONE, simple.RelationType_RELATION_HAS_MANY:
+		fk := relationForeignKey(message, field)
+		if messageField(field.Message, fk) == nil {
+			return fmt.Errorf("field %s: model %s has no foreign key field %s", field.Desc.FullName(), field.Message.Desc.FullName(), fk)
+		}
+		tag = append(tag, "foreignKey:"+fk)
+		if ref := columnOptions(field).GetRelation().GetReferences(); ref != "" {
+			tag = append(tag, "references:"+ref)
+		}
+	case simple.RelationType_RELATION_MANY_TO_MANY:
+		tag = append(tag, "many2many:"+joinTable(message, field))
+	}
+	goType := "*" + related
+	if field.Desc.IsList() {
+		goType = "[]" + related
+	}
+	g.P(fmt.Sprintf(`		%s  %s `, field.GoName, goType) + "`" + fmt.Sprintf(`json:"%s" gorm:"%s"`, field.Desc.JSONName(), strings.Join(tag, ";")) + "`")
+	return nil
+}
+
+// relationConverter converts a relation field. The related models are
+// converted when they are loaded, e.g. with Preload.
+func relationConverter(g *protogen.GeneratedFile, message *protogen.Message, field *protogen.Field) (fieldConverter, error) {
+	name := field.GoName
+	toModel := g.QualifiedGoIdent(modelIdent(field.Message, modelName(field.Message)+"ProtoToModel"))
+	if field.Desc.IsList() {
+		return fieldConverter{
+			goName: name,
+			toProtoStmt: fmt.Sprintf(`for _, v := range model.%[1]s {
+				proto.%[1]s = append(proto.%[1]s, v.Proto())
+			}`, name),
+			toModelStmt: fmt.Sprintf(`for _, v := range proto.%[1]s {
+				model.%[1]s = append(model.%[1]s, *%[2]s(v))
+			}`, name, toModel),
+		}, nil
+	}
+	c := fieldConverter{
+		goName: name,
+		toProtoStmt: fmt.Sprintf(`if model.%[1]s != nil {
+			proto.%[1]s = model.%[1]s.Proto()
+		}`, name),
+		toModelStmt: fmt.Sprintf(`if proto.%[1]s != nil {
+			model.%[1]s = %[2]s(proto.%[1]s)`, name, toModel),
+	}
+	if fieldRelation(field) == simple.RelationType_RELATION_BELONGS_TO {
+		if fk := relationForeignKey(message, field); messageField(message, fk) == nil {
+			ref, _, err := referencedKey(g, field)
+			if err != nil {
+				return fieldConverter{}, err
+			}
+			c.toModelStmt += fmt.Sprintf(`
+				model.%s = model.%s.%s`, fk, name, ref)
+		}
+	}
+	c.toModelStmt += `
+		}`
+	return c, nil
+}
+
+// keptForeignKeys returns the statement appending to the []string omit the
+// generated foreign key columns of the belongs-to relations of message whose
+// related model a does not carry. The column has no proto field and is only
+// set from the related model, so an update without it keeps the stored key
+// instead of clearing it. It returns "" when message has no such relation.
+func keptForeignKeys(message *protogen.Message) string {
+	var stmts []string
+	for _, field := range relationFields(message) {
+		if fieldRelation(field) != simple.RelationType_RELATION_BELONGS_TO {
+			continue
+		}
+		fk := relationForeignKey(message, field)
+		if messageField(message, fk) != nil {
+			continue
+		}
+		stmts = append(stmts, fmt.Sprintf(`if a.%s == nil {
+				omit = append(omit, %q)
+			}`, field.GoName, ToSnakeCase(fk)))
+	}
+	return strings.Join(stmts, "\n")
+}
+
+// omitAssociations returns the Omit call of the writes of a model, which
+// keeps gorm from creating or updating the related models a written model
+// carries. It returns "" for models without relations.
+func omitAssociations(g *protogen.GeneratedFile, relations bool) string {
+	if !relations {
+		return ""
+	}
+	return ".Omit(" + g.QualifiedGoIdent(clausePackage.Ident("Associations")) + ")"
+}
+
+// generatePreload generates Preload<Model>, which preloads every relation of
+// the model. It returns false when the model has no relations.
+func generatePreload(g *protogen.GeneratedFile, message *protogen.Message, modelName string) bool {
+	fields := relationFields(message)
+	if len(fields) == 0 {
+		return false
+	}
+	db := g.QualifiedGoIdent(GormPackage.Ident("DB"))
+	var b strings.Builder
+	for _, field := range fields {
+		fmt.Fprintf(&b, ".Preload(%q)", field.GoName)
+	}
+	g.P(fmt.Sprintf(`// Preload%[1]s preloads the relations of %[1]s.
+		func Preload%[1]s(db *%[2]s) *%[2]s {
+			return db%[3]s
+		}
+`, modelName, db, b.String()))
+	return true
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRepositoryOmitsAssociations(t *testing.T) {
+	tests := []struct {
+		name     string
+		relation string
+		options  string
+		extra    string
+		want     []string
+	}{{
+		name:     "relation",
+		relation: `options { [simple.column] { relation { type: RELATION_BELONGS_TO } } }`,
+		options:  `[simple.model]: true`,
+		want: []string{
+			`r.db.WithContext(ctx).Omit(clause.Associations).Create(a)`,
+			`r.db.WithContext(ctx).Omit(clause.Associations).CreateInBatches(list, batchSize)`,
+			// The generated foreign key is kept when a has no writer.
+			`omit := []string{clause.Associations}
+	if a.Writer == nil {
+		omit = append(omit, "writer_id")
+	}
+	return r.db.WithContext(ctx).Omit(omit...).Save(a).Error`,
+		},
+	}, {
+		name:     "locked relation",
+		relation: `options { [simple.column] { relation { type: RELATION_BELONGS_TO } } }`,
+		options:  `[simple.table] { optimistic_lock: true indexes { unique: true fields { name: "code" } upsert: true } }`,
+		want: []string{
+			`omit := []string{"created_at", "deleted_at", clause.Associations}
+	if a.Writer == nil {
+		omit = append(omit, "writer_id")
+	}`,
+			`.Select("*").Omit(omit...).Updates(a)`,
+			`return r.db.WithContext(ctx).Omit(clause.Associations).Clauses(clause.OnConflict{`,
+			`result := r.db.WithContext(ctx).Omit(clause.Associations).Clauses(clause.OnConflict{`,
+		},
+	}, {
+		name:     "declared foreign key",
+		relation: `options { [simple.column] { relation { type: RELATION_BELONGS_TO } } }`,
+		options:  `[simple.model]: true`,
+		extra:    `field { name: "writer_id" number: 4 type: TYPE_INT64 }`,
+		want:     []string{`r.db.WithContext(ctx).Omit(clause.Associations).Save(a)`},
+	}, {
+		name:     "json",
+		relation: `options { [simple.column] { storage: STORAGE_JSON } }`,
+		options:  `[simple.model]: true`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := testPlugin(t, testFile("rel", `message_type {
+				name: "NoteModel" options { `+tt.options+` }
+				field { name: "code" number: 1 type: TYPE_STRING }
+				field { name: "version" number: 2 type: TYPE_INT64 }
+				field { name: "writer" number: 3 type: TYPE_MESSAGE type_name: ".rel.WriterModel" `+tt.relation+` }
+				`+tt.extra+`
+			}
+			message_type {
+				name: "WriterModel" options { [simple.model]: true }
+				field { name: "name" number: 1 type: TYPE_STRING }
+			}
+			message_type {
+				name: "PageInfo"
+				field { name: "page" number: 1 type: TYPE_INT64 }
+				field { name: "page_size" number: 2 type: TYPE_INT64 }
+			}`))
+			file := gen.Files[len(gen.Files)-1]
+			message := testMessage(t, gen, "NoteModel")
+			key, err := primaryKey(message)
+			if err != nil {
+				t.Fatal(err)
+			}
+			g := gen.NewGeneratedFile("note_model.go", file.GoImportPath)
+			g.P("package model")
+			preload := generatePreload(g, message, "Note")
+			if err := generateRepository(g, file, message, "Note", key, preload); err != nil {
+				t.Fatal(err)
+			}
+			b, err := g.Content()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := string(b)
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("repository has no %s:\n%s", want, got)
+				}
+			}
+			if len(tt.want) == 0 && strings.Contains(got, "clause.Associations") {
+				t.Errorf("repository of a model without relations omits associations:\n%s", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// repositoryMethod is an optional method of a repository, with the package
+// level function running it on the db parameter.
+type repositoryMethod struct {
+	name    string
+	doc     string
+	params  string
+	results string
+	body    string
+	args    string
+	// funcName is the package level function running the method.
+	funcName string
+}
+
+// generateRepository generates the <Model>Repository interface, its gorm
+// implementation returned by New<Model>Repository, and the package level
+// functions running it on the db parameter.
+func generateRepository(g *protogen.GeneratedFile, file *protogen.File, message *protogen.Message, modelName string, key modelKey, preload bool) error {
+	keyParams, err := key.params(g)
+	if err != nil {
+		return err
+	}
+	lookups, err := uniqueLookups(g, message)
+	if err != nil {
+		return err
+	}
+	methods, err := batchMethods(g, modelName, key, preload)
+	if err != nil {
+		return err
+	}
+	upserts, err := upsertMethods(g, message, modelName, preload)
+	if err != nil {
+		return err
+	}
+	methods = append(methods, upserts...)
+	update := "return r.db.WithContext(ctx)" + omitAssociations(g, preload) + ".Save(a).Error"
+	kept := keptForeignKeys(message)
+	if kept != "" {
+		update = fmt.Sprintf(`omit := []string{%s}
+			%s
+			return r.db.WithContext(ctx).Omit(omit...).Save(a).Error`, g.QualifiedGoIdent(clausePackage.Ident("Associations")), kept)
+	}
+	if isLockedModel(message) {
+		// Every column is written except created_at and deleted_at, which a
+		// model converted from a request does not hold.
+		omit := `"created_at"`
+		if hasBaseModel(message) {
+			omit += `, "deleted_at"`
+		}
+		if preload {
+			omit += ", " + g.QualifiedGoIdent(clausePackage.Ident("Associations"))
+		}
+		query := `.Select("*").Omit(` + omit + `).Updates(a)`
+		if kept != "" {
+			query = `.Select("*").Omit(omit...).Updates(a)`
+		}
+		if update, err = lockedUpdate(message, modelName, query); err != nil {
+			return err
+		}
+		if kept != "" {
+			update = fmt.Sprintf(`omit := []string{%s}
+				%s
+				%s`, omit, kept, update)
+		}
+	}
+	db := g.QualifiedGoIdent(dbFlag.ident())
+	gormDB := g.QualifiedGoIdent(GormPackage.Ident("DB"))
+	pageInfo := g.QualifiedGoIdent(commonPackage(file).Ident("PageInfo"))
+	ctx := g.QualifiedGoIdent(contextPackage.Ident("Context"))
+	repo := lowerFirstLatter(modelName) + "Repository"
+	softDelete, err := hasSoftDelete(message)
+	if err != nil {
+		return err
+	}
+	// Without soft delete, the DeletedAt of the base model is ignored and
+	// rows are deleted permanently.
+	scope := "db.Unscoped()"
+	if softDelete {
+		methods = append(methods, softDeleteMethods(g, file, modelName, repo)...)
+		scope = "db"
+	} else if !hasBaseModel(message) {
+		scope = "db"
+	}
+	getQuery, listQuery := "r.db.WithContext(ctx)", "db.Limit(int(limit)).Offset(int(offset))"
+	if preload {
+		getQuery, listQuery = "Preload"+modelName+"(r.db.WithContext(ctx))", "Preload"+modelName+"("+listQuery+")"
+	}
+
+	g.P(fmt.Sprintf(`
+		// %[1]sRepository 数据访问接口
+		type %[1]sRepository interface {
+			// Create 创建
+			Create(ctx %[4]s, a *%[1]s) error
+			// Delete 删除，记录不存在时返回 gorm.ErrRecordNotFound
+			Delete(ctx %[4]s, a %[1]s) error
+			// Update 修改
+			Update(ctx %[4]s, a *%[1]s) error
+			// UpdateFields 只修改 paths（FieldMask 的 proto 字段名）对应的列，
+			// 未知或只读的字段返回错误
+			UpdateFields(ctx %[4]s, a *%[1]s, paths []string) error
+			// Get 查询
+			Get(ctx %[4]s, %[2]s) (%[1]s, error)
+			// GetList 分页查询，filter 为 nil 时不过滤
+			GetList(ctx %[4]s, info %[3]s, filter *%[1]sFilter) ([]%[1]s, int64, error)
+			// Transaction 在事务中运行 fn，fn 返回 nil 时提交；已在事务中时使用保存点
+			Transaction(ctx %[4]s, fn func(repo %[1]sRepository) error) error`, modelName, keyParams, pageInfo, ctx))
+	for _, l := range lookups {
+		g.P(fmt.Sprintf(`			// Get%[1]s 按唯一索引查询
+			Get%[1]s(ctx %[4]s, %[2]s) (%[3]s, error)`, l.name, l.params, modelName, ctx))
+	}
+	for _, m := range methods {
+		g.P(fmt.Sprintf(`			// %[1]s %[2]s
+			%[1]s(%[3]s) %[4]s`, m.name, m.doc, m.params, m.results))
+	}
+	if isKeysetModel(message) {
+		g.P(fmt.Sprintf(`			// GetPage 游标分页查询，按主键排序，不统计总数。cursor 为上一页返回的
+			// next，第一页为空；没有下一页时 next 为空
+			GetPage(ctx %[2]s, cursor string, limit int, filter *%[1]sFilter) (list []%[1]s, next string, err error)`, modelName, ctx))
+	}
+	g.P(`		}`)
+
+	g.P(fmt.Sprintf(`
+		// New%[1]sRepository returns the %[1]sRepository running on db, which
+		// may be a transaction.
+		func New%[1]sRepository(db *%[3]s) %[1]sRepository {
+			return &%[2]s{db: %[11]s}
+		}
+
+		type %[2]s struct {
+			db *%[3]s
+		}
+
+		func (r *%[2]s) Create(ctx %[9]s, a *%[1]s) error {
+			return r.db.WithContext(ctx)%[13]s.Create(a).Error
+		}
+
+		func (r *%[2]s) Delete(ctx %[9]s, a %[1]s) error {
+			result := r.db.WithContext(ctx).Delete(&a)
+			if result.Error == nil && result.RowsAffected == 0 {
+				return %[12]s
+			}
+			return result.Error
+		}
+
+		func (r *%[2]s) Update(ctx %[9]s, a *%[1]s) error {
+			%[10]s
+		}
+
+		func (r *%[2]s) Get(ctx %[9]s, %[4]s) (result %[1]s, err error) {
+			err = %[6]s.Where(%[5]s).First(&result).Error
+			return
+		}
+
+		func (r *%[2]s) GetList(ctx %[9]s, info %[8]s, filter *%[1]sFilter) (list []%[1]s, total int64, err error) {
+			limit := info.PageSize
+			offset := info.PageSize * (info.Page - 1)
+			db := filter.Scope(r.db.WithContext(ctx).Model(&%[1]s{}))
+			if err = db.Count(&total).Error; err != nil {
+				return
+			}
+			err = %[7]s.Find(&list).Error
+			return
+		}
+
+		func (r *%[2]s) Transaction(ctx %[9]s, fn func(repo %[1]sRepository) error) error {
+			return r.db.WithContext(ctx).Transaction(func(tx *%[3]s) error {
+				return fn(New%[1]sRepository(tx))
+			})
+		}
+
+		// DB returns the database the repository runs on, which may be a
+		// transaction.
+		func (r *%[2]s) DB() *%[3]s {
+			return r.db
+		}
+`, modelName, repo, gormDB, keyParams, key.where(), getQuery, listQuery, pageInfo, ctx, update, scope, g.QualifiedGoIdent(GormPackage.Ident("ErrRecordNotFound")), omitAssociations(g, preload)))
+	if err := generateUpdateFieldsMethod(g, message, modelName, repo); err != nil {
+		return err
+	}
+	for _, l := range lookups {
+		g.P(fmt.Sprintf(`func (r *%[1]s) Get%[2]s(ctx %[6]s, %[3]s) (result %[4]s, err error) {
+			err = r.db.WithContext(ctx).Where(%[5]s).First(&result).Error
+			return
+		}
+`, repo, l.name, l.params, modelName, l.where, ctx))
+	}
+	for _, m := range methods {
+		g.P(fmt.Sprintf(`func (r *%s) %s(%s) %s {
+			%s
+		}
+`, repo, m.name, m.params, m.results, m.body))
+	}
+	if isKeysetModel(message) {
+		if err := generatePageMethod(g, modelName, repo, key, preload); err != nil {
+			return err
+		}
+	}
+
+	g.P(fmt.Sprintf(`// Create%[1]s Func 创建
+		func Create%[1]s(ctx %[6]s, a %[1]s) (err error) {
+			return New%[1]sRepository(%[2]s).Create(ctx, &a)
+		}
+
+		// Delete%[1]s  删除
+		func Delete%[1]s(ctx %[6]s, a %[1]s) (err error) {
+			return New%[1]sRepository(%[2]s).Delete(ctx, a)
+		}
+
+		// Update%[1]s 修改
+		func Update%[1]s(ctx %[6]s, a *%[1]s) (err error) {
+			return New%[1]sRepository(%[2]s).Update(ctx, a)
+		}
+
+		// Update%[1]sFields 按 FieldMask 部分修改
+		func Update%[1]sFields(ctx %[6]s, a *%[1]s, paths []string) (err error) {
+			return New%[1]sRepository(%[2]s).UpdateFields(ctx, a, paths)
+		}
+
+		// Get%[1]s 查询
+		func Get%[1]s(ctx %[6]s, %[3]s) (result %[1]s, err error) {
+			return New%[1]sRepository(%[2]s).Get(ctx, %[4]s)
+		}
+
+		// Get%[1]sList 分页查询
+		func Get%[1]sList(ctx %[6]s, info %[5]s, filter *%[1]sFilter) (list []%[1]s, total int64, err error) {
+			return New%[1]sRepository(%[2]s).GetList(ctx, info, filter)
+		}
+`, modelName, db, keyParams, key.paramNames(), pageInfo, ctx))
+	for _, l := range lookups {
+		g.P(fmt.Sprintf(`// Get%[1]s%[2]s 按唯一索引查询
+		func Get%[1]s%[2]s(ctx %[6]s, %[3]s) (result %[1]s, err error) {
+			return New%[1]sRepository(%[4]s).Get%[2]s(ctx, %[5]s)
+		}
+`, modelName, l.name, l.params, db, l.args, ctx))
+	}
+	for _, m := range methods {
+		g.P(fmt.Sprintf(`// %[1]s %[2]s
+		func %[1]s(%[3]s) %[4]s {
+			return New%[5]sRepository(%[6]s).%[7]s(%[8]s)
+		}
+`, m.funcName, m.doc, m.params, m.results, modelName, db, m.name, m.args))
+	}
+	if isKeysetModel(message) {
+		g.P(fmt.Sprintf(`// Get%[1]sPage 游标分页查询
+		func Get%[1]sPage(ctx %[3]s, cursor string, limit int, filter *%[1]sFilter) (list []%[1]s, next string, err error) {
+			return New%[1]sRepository(%[2]s).GetPage(ctx, cursor, limit, filter)
+		}
+`, modelName, db, ctx))
+	}
+	return nil
+}
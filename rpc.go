@@ -9,18 +9,56 @@ import (
 	"strings"
 
 	"google.golang.org/protobuf/compiler/protogen"
-	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
-func generateModelFile(gen *protogen.Plugin, file *protogen.File, message *protogen.Message) *protogen.GeneratedFile {
-	name := string(message.Desc.Name())
-	fullName := string(message.Desc.FullName())
+func init() {
+	registerGenerator(&generator{
+		name:        "model",
+		description: "gorm model and data access functions for every model message (*_model.go)",
+		defaultOn:   true,
+		generate: func(gen *protogen.Plugin, file *protogen.File) error {
+			for _, message := range file.Messages {
+				if isModel(message) {
+					if _, err := generateModelFile(gen, file, message); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	})
+	registerGenerator(&generator{
+		name:        "impl",
+		description: "rpcx service implementation with CRUD conventions (*_service.go)",
+		defaultOn:   true,
+		generate: func(gen *protogen.Plugin, file *protogen.File) error {
+			for _, service := range file.Services {
+				if err := generateSimpleServerCode(gen, file, service); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
 
-	afterName, _ := strings.CutSuffix(name, "Model")
+func generateModelFile(gen *protogen.Plugin, file *protogen.File, message *protogen.Message) (*protogen.GeneratedFile, error) {
+	afterName := modelName(message)
 
 	lowerName := lowerFirstLatter(afterName)
 	filename := lowerName + "_model.go"
-	g := gen.NewGeneratedFile(filename, file.GoImportPath)
+	pkg := modelPackage(file)
+	g := gen.NewGeneratedFile(pkg.filename(filename), pkg.importPath)
+	protoName := g.QualifiedGoIdent(message.GoIdent)
+	key, err := primaryKey(message)
+	if err != nil {
+		return nil, err
+	}
+	if protoJSON, err := usesProtoJSON(message); err != nil {
+		return nil, err
+	} else if protoJSON {
+		generateProtoJSONSerializer(gen, file)
+	}
 	g.P("// Code generated by protoc-gen-simple. DO NOT EDIT.")
 	g.P("// versions:")
 	g.P("// - protoc-gen-simple v", version)
@@ -31,135 +69,172 @@ func generateModelFile(gen *protogen.Plugin, file *protogen.File, message *proto
 		g.P("// source: ", file.Desc.Path())
 	}
 	g.P()
-	g.P("package model")
+	g.P("package ", pkg.name)
 	g.P()
 	g.P("// Reference imports to suppress errors if they are not otherwise used.")
-	g.P("var _ = ", SimpleStorePackage.Ident("TODO"))
 	g.P("var _ = ", TimePackage.Ident("Now"))
 	g.P()
 	g.P(fmt.Sprintf(`// %s Model
-		type %s struct {
-			store.BASE_MODEL
-`, afterName, afterName))
-	for _, field := range message.Fields {
-		if field.GoName == "Id" || field.GoName == "CreatedAt" || field.GoName == "UpdatedAt" || field.GoName == "DeletedAt" {
+		type %s struct {`, afterName, afterName))
+	var converters []fieldConverter
+	if hasBaseModel(message) {
+		g.P(g.QualifiedGoIdent(storePackage().Ident("BASE_MODEL")))
+		g.P()
+		if converters, err = baseFieldConverters(g, message); err != nil {
+			return nil, err
+		}
+	}
+	var oneofs []*protogen.Oneof
+	for _, field := range modelFields(message) {
+		if isOneofField(field) {
+			if len(oneofs) > 0 && oneofs[len(oneofs)-1] == field.Oneof {
+				continue
+			}
+			oneofs = append(oneofs, field.Oneof)
+			c, err := generateModelOneof(g, message, field.Oneof)
+			if err != nil {
+				return nil, err
+			}
+			converters = append(converters, c)
 			continue
 		}
-		generateModelFiled(g, field)
-
+		if err := generateModelFiled(g, message, field); err != nil {
+			return nil, err
+		}
+		c, err := newFieldConverter(g, message, field)
+		if err != nil {
+			return nil, err
+		}
+		converters = append(converters, c)
 	}
 	g.P(`		}`)
 	g.P()
+	for _, oneof := range oneofs {
+		if isJSONOneof(oneof) {
+			if err := generateOneofStruct(g, message, oneof); err != nil {
+				return nil, err
+			}
+		}
+	}
+	generateTableMethods(g, message, afterName)
+	if err := generateKeyHook(g, key, afterName); err != nil {
+		return nil, err
+	}
+	if err := generateVersionConflictError(g, message, afterName); err != nil {
+		return nil, err
+	}
+	preload := generatePreload(g, message, afterName)
 	g.P(fmt.Sprintf(`
-
 		func (model *%[1]s) Proto() *%[2]s {
-			return &%[2]s{
-				Id: model.ID,
-				CreatedAt: model.CreatedAt.Format(time.DateTime),
-				UpdatedAt: model.UpdatedAt.Format(time.DateTime),
-`, afterName, fullName))
-	for _, field := range message.Fields {
-		if field.GoName == "Id" || field.GoName == "CreatedAt" || field.GoName == "UpdatedAt" || field.GoName == "DeletedAt" {
-			continue
+			proto := &%[2]s{`, afterName, protoName))
+	for _, c := range converters {
+		if c.toProto != "" {
+			g.P(fmt.Sprintf(`				%s: %s,`, c.goName, c.toProto))
+		}
+	}
+	g.P(`			}`)
+	for _, c := range converters {
+		if c.toProtoStmt != "" {
+			g.P(c.toProtoStmt)
 		}
-		g.P(fmt.Sprintf(`				%s: model.%s,`, field.GoName, field.GoName))
-
 	}
-	g.P(`			}
+	g.P(`			return proto
 		}`)
 
 	g.P(fmt.Sprintf(`
 		func %[1]sProtoToModel(proto *%[2]s) *%[1]s {
-			%[3]s := %[1]s{
-				BASE_MODEL: store.BASE_MODEL{
-					ID:        proto.Id,
-				},
-`, afterName, fullName, lowerFirstLatter(afterName)))
-	for _, field := range message.Fields {
-		if field.GoName == "Id" || field.GoName == "CreatedAt" || field.GoName == "UpdatedAt" || field.GoName == "DeletedAt" {
-			continue
+			model := %[1]s{`, afterName, protoName))
+	for _, c := range converters {
+		if c.toModel != "" {
+			g.P(fmt.Sprintf(`				%s: %s,`, c.goName, c.toModel))
+		}
+	}
+	g.P(`			}`)
+	for _, c := range converters {
+		if c.toModelStmt != "" {
+			g.P(c.toModelStmt)
 		}
-		g.P(fmt.Sprintf(`				%s: proto.%s,`, field.GoName, field.GoName))
+	}
+	g.P(`			return &model
+		}`)
 
+	if err := generateFilter(g, message, afterName); err != nil {
+		return nil, err
 	}
-	g.P(fmt.Sprintf(`			}
-			if createdAt,err := time.Parse(time.DateTime,proto.CreatedAt);err == nil{
-				%[1]s.CreatedAt = createdAt
-			}
-			if updatedAt,err := time.Parse(time.DateTime,proto.UpdatedAt);err == nil{
-				%[1]s.UpdatedAt = updatedAt
-			}
-			return &%[1]s
-		}`, lowerFirstLatter(afterName)))
+	if err := generateRepository(g, file, message, afterName, key, preload); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
 
-	g.P()
-	g.P(fmt.Sprintf(`// Create%[1]s Func 创建
-		func Create%[1]s(a %[1]s) (err error) {
-			err = global.DB_.Create(&a).Error
-			return err
-		}
-		
-		// Delete%[1]s  删除
-		func Delete%[1]s(a %[1]s) (err error) {
-			err = global.DB_.Delete(&a).Error
-			return err
+// generateTableMethods generates the methods describing the table of a model
+// declared with the (simple.table) option.
+func generateTableMethods(g *protogen.GeneratedFile, message *protogen.Message, modelName string) {
+	opts := tableOptions(message)
+	if opts.GetName() != "" {
+		g.P(fmt.Sprintf(`// TableName overrides the table name used by %[1]s.
+		func (*%[1]s) TableName() string {
+			return %[2]q
 		}
-
-		// Update%[1]s 修改
-		func Update%[1]s(a *%[1]s) (err error) {
-			err = global.DB_.Save(a).Error
-			return err
+`, modelName, opts.GetName()))
+	}
+	var tableOpts []string
+	if opts.GetEngine() != "" {
+		tableOpts = append(tableOpts, "ENGINE="+opts.GetEngine())
+	}
+	if opts.GetCharset() != "" {
+		tableOpts = append(tableOpts, "DEFAULT CHARSET="+opts.GetCharset())
+	}
+	if len(tableOpts) > 0 {
+		g.P(fmt.Sprintf(`// TableOptions returns the options used when creating the table of %[1]s,
+		// e.g. db.Set("gorm:table_options", (*%[1]s)(nil).TableOptions()).AutoMigrate(&%[1]s{})
+		func (*%[1]s) TableOptions() string {
+			return %[2]q
 		}
+`, modelName, strings.Join(tableOpts, " ")))
+	}
+}
 
-		// Update%[1]s 查询
-		func Get%[1]s(id int64) (result %[1]s, err error) {
-			err = global.DB_.Where("id = ?", id).First(&result).Error
-			return
+// baseFieldConverters returns the converters of the proto fields backed by
+// the embedded base model: Id, CreatedAt and UpdatedAt.
+func baseFieldConverters(g *protogen.GeneratedFile, message *protogen.Message) ([]fieldConverter, error) {
+	var converters []fieldConverter
+	if messageField(message, "Id") != nil {
+		converters = append(converters, fieldConverter{goName: "Id", toProto: "model.ID", toModelStmt: "model.ID = proto.Id"})
+	}
+	for _, name := range []string{"CreatedAt", "UpdatedAt"} {
+		field := messageField(message, name)
+		if field == nil {
+			continue
 		}
-
-		// 分页查询
-		func Get%[1]sList(info pbcommon.PageInfo) (list []%[1]s, total int64, err error) {
-			limit := info.PageSize
-			offset := info.PageSize * (info.Page - 1)
-			db := global.DB_.Model(&%[1]s{})
-			var %[1]sList []%[1]s
-			// 此处增加查询条件
-			//if info.Keyword != "" {
-			//	db.Where("keywaord = ?", info.Keyword)
-			//}
-			err = db.Count(&total).Error
-			if err != nil {
-				return %[1]sList, total, err
-			} else {
-				err = db.Limit(int(limit)).Offset(int(offset)).Find(&%[1]sList).Error
-			}
-			return %[1]sList, total, err
+		c, err := timeConverter(g, field, "model."+name)
+		if err != nil {
+			return nil, err
 		}
-`, afterName, fullName))
-	return g
+		converters = append(converters, c)
+	}
+	return converters, nil
 }
 
-func generateModelFiled(g *protogen.GeneratedFile, field *protogen.Field) {
-	switch field.Desc.Kind() {
-	case protoreflect.StringKind:
-		g.P(fmt.Sprintf(`		%s  string `, field.GoName) + "`" + fmt.Sprintf(`json:"%s" gorm:"column:%s;comment: ;type:varchar(20);size:20;"`, field.Desc.JSONName(), ToSnakeCase(field.GoName)) + "`")
-	case protoreflect.DoubleKind:
-		g.P(fmt.Sprintf(`		%s  float64 `, field.GoName) + "`" + fmt.Sprintf(`json:"%s" gorm:"column:%s;comment: ;"`, field.Desc.JSONName(), ToSnakeCase(field.GoName)) + "`")
-	case protoreflect.Int64Kind:
-		g.P(fmt.Sprintf(`		%s  int64 `, field.GoName) + "`" + fmt.Sprintf(`json:"%s" gorm:"column:%s;comment: ;type:bigint(20);size:20;"`, field.Desc.JSONName(), ToSnakeCase(field.GoName)) + "`")
-	case protoreflect.Int32Kind:
-		g.P(fmt.Sprintf(`		%s  int32 `, field.GoName) + "`" + fmt.Sprintf(`json:"%s" gorm:"column:%s;comment: ;type:smallint(6);size:6;"`, field.Desc.JSONName(), ToSnakeCase(field.GoName)) + "`")
-	default:
-		g.P(fmt.Sprintf(`		%s  interface{} `, field.GoName) + "`" + fmt.Sprintf(`json:"%s" gorm:"column:%s;comment: ;type:any(20);size:20;"`, field.Desc.JSONName(), ToSnakeCase(field.GoName)) + "`")
-
+// serviceModel returns the model a service manages through the CRUD method
+// conventions, which is the model named after the service. It returns nil
+// when the file declares no such model.
+func serviceModel(file *protogen.File, service *protogen.Service) *protogen.Message {
+	for _, message := range file.Messages {
+		if isModel(message) && modelName(message) == upperFirstLatter(service.GoName) {
+			return message
+		}
 	}
+	return nil
 }
 
-func generateSimpleServerCode(gen *protogen.Plugin, file *protogen.File, service *protogen.Service) {
+func generateSimpleServerCode(gen *protogen.Plugin, file *protogen.File, service *protogen.Service) error {
 	serviceName := upperFirstLatter(service.GoName)
 
 	filename := lowerFirstLatter(serviceName) + "_service.go"
-	g := gen.NewGeneratedFile(filename, file.GoImportPath)
+	pkg := implPackage(file)
+	g := gen.NewGeneratedFile(pkg.filename(filename), pkg.importPath)
+	modelPath := modelPackage(file).importPath
 	g.P("// Code generated by protoc-gen-simple. DO NOT EDIT.")
 	g.P("// versions:")
 	g.P("// - protoc-gen-simple v", version)
@@ -170,21 +245,76 @@ func generateSimpleServerCode(gen *protogen.Plugin, file *protogen.File, service
 		g.P("// source: ", file.Desc.Path())
 	}
 	g.P()
-	g.P("package impl")
+	g.P("package ", pkg.name)
 	g.P()
 	g.P("// Reference imports to suppress errors if they are not otherwise used.")
-	g.P("var _ = ", SimpleStorePackage.Ident("TODO"))
 	g.P("var _ = ", contextPackage.Ident("TODO"))
 	g.P()
-	g.P(fmt.Sprintf(`type %[1]s struct {}
+	model := serviceModel(file, service)
+	transactional := false
+	for _, method := range service.Methods {
+		transactional = transactional || methodOptions(method).GetTransactional()
+	}
+	db := fmt.Sprintf(`// DB is the database of the service, by default %[2]s.
+			DB *%[3]s
+		}
+
+		func (s *%[1]s) db() *%[3]s {
+			if s.DB != nil {
+				return s.DB
+			}
+			return %[2]s
+		}
+	`, serviceName, g.QualifiedGoIdent(dbFlag.ident()), g.QualifiedGoIdent(GormPackage.Ident("DB")))
+	var key modelKey
+	switch {
+	case model != nil:
+		var err error
+		if key, err = primaryKey(model); err != nil {
+			return err
+		}
+		g.P(fmt.Sprintf(`type %[1]s struct {
+			// Repository is the data access of the service, by default
+			// %[2]s(s.db()).
+			Repository %[3]s
+			%[4]s
+		func (s *%[1]s) repository() %[3]s {
+			if s.Repository != nil {
+				return s.Repository
+			}
+			return %[2]s(s.db())
+		}
+	`, serviceName, g.QualifiedGoIdent(modelPath.Ident("New"+serviceName+"Repository")),
+			g.QualifiedGoIdent(modelPath.Ident(serviceName+"Repository")), db))
+	case transactional:
+		g.P(fmt.Sprintf(`type %s struct {
+			%s`, serviceName, db))
+	default:
+		g.P(fmt.Sprintf(`type %[1]s struct {}
 	`, serviceName))
+	}
+	if transactional {
+		generateServiceTx(g, file, serviceName, model)
+	}
 	for _, method := range service.Methods {
-		//inType := g.QualifiedGoIdent(method.Input.GoIdent)
-		//method.Input.Desc.FullName()
-		//outType := g.QualifiedGoIdent(method.Output.GoIdent)
-		outType := method.Output.Desc.FullName()
+		inType := g.QualifiedGoIdent(method.Input.GoIdent)
+		outType := g.QualifiedGoIdent(method.Output.GoIdent)
 		methodName := upperFirstLatter(method.GoName)
-		if len(methodName) < 6 {
+		repo := "s.repository()"
+		if methodOptions(method).GetTransactional() {
+			repo = "repos." + serviceName
+		}
+		var body string
+		if model != nil {
+			var err error
+			if body, err = crudMethodBody(g, file, model, key, method, repo); err != nil {
+				return err
+			}
+		}
+		switch {
+		case methodOptions(method).GetTransactional():
+			generateTransactionalMethod(g, file, method, serviceName, body)
+		case body == "":
 			g.P(fmt.Sprintf(`// %s is server rpc method as defined
 			func (s *%s) %s(ctx context.Context, args *%s, reply *%s) (err error){
 				// TODO: add business logics
@@ -194,91 +324,123 @@ func generateSimpleServerCode(gen *protogen.Plugin, file *protogen.File, service
 	
 				return nil
 			}
-		`, methodName, serviceName, methodName, method.Input.Desc.FullName(), outType, outType))
-		} else {
-			if methodName[:6] == "Create" {
-				g.P(fmt.Sprintf(`// %s is server rpc method as defined
-		func (s *%s) %s(ctx context.Context, args *%s, reply *%s) (err error){
-			*reply = %s{}
-			if err = model.%s(*model.%sProtoToModel(args));err == nil{
-				reply.Code = pbcommon.EnumCode_Success
-			}else{
-				reply.Code = pbcommon.EnumCode_CreateError
-			}
-
-			return nil
-		}
-	`, methodName, serviceName, methodName, method.Input.Desc.FullName(), outType, outType, methodName, serviceName))
-			} else if methodName[:6] == "Update" {
-				g.P(fmt.Sprintf(`// %s is server rpc method as defined
-			func (s *%s) %s(ctx context.Context, args *%s, reply *%s) (err error){
-				*reply = %s{}
-				if err = model.%s(model.%sProtoToModel(args));err == nil{
-					reply.Code = pbcommon.EnumCode_Success
-				}else{
-					reply.Code = pbcommon.EnumCode_UpdateError
-				}
-	
-				return nil
-			}
-		`, methodName, serviceName, methodName, method.Input.Desc.FullName(), outType, outType, methodName, serviceName))
-			} else if methodName[:6] == "Delete" {
-				g.P(fmt.Sprintf(`// %s is server rpc method as defined
+		`, methodName, serviceName, methodName, inType, outType, outType))
+		default:
+			g.P(fmt.Sprintf(`// %s is server rpc method as defined
 			func (s *%s) %s(ctx context.Context, args *%s, reply *%s) (err error){
 				*reply = %s{}
-				if err = model.%s(model.%s{BASE_MODEL: store.BASE_MODEL{
-					ID: args.Id,
-				},});err == nil{
-					reply.Code = pbcommon.EnumCode_Success
-				}else{
-					reply.Code = pbcommon.EnumCode_DeleteError
-				}
-	
+				%s
 				return nil
 			}
-		`, methodName, serviceName, methodName, method.Input.Desc.FullName(), outType, outType, methodName, serviceName))
-			} else if methodName[:4] == "Find" && methodName[len(methodName)-4:] == "ById" {
-				g.P(fmt.Sprintf(`// %s is server rpc method as defined
-			func (s *%s) %s(ctx context.Context, args *%s, reply *%s) (err error){
-				*reply = %s{}
-				if result,err := model.Get%s(args.Id);err == nil{
-					reply.Data = result.Proto()
-					reply.Code = pbcommon.EnumCode_Success
-				}else{
-					reply.Code = pbcommon.EnumCode_FindError
-				}
-				return nil
+		`, methodName, serviceName, methodName, inType, outType, outType, body))
+		}
+	}
+	return nil
+}
+
+// crudMethodBody returns the statements implementing method with the CRUD
+// conventions for the service model, using the model repository repo. It
+// returns "" when the method follows no convention.
+func crudMethodBody(g *protogen.GeneratedFile, file *protogen.File, model *protogen.Message, key modelKey, method *protogen.Method, repo string) (string, error) {
+	serviceName := modelName(model)
+	methodName := upperFirstLatter(method.GoName)
+	modelPath := modelPackage(file).importPath
+	commonPath := commonPackage(file)
+	success := g.QualifiedGoIdent(commonPath.Ident("EnumCode_Success"))
+	if body, err := batchMethodBody(g, file, model, key, method, repo); body != "" || err != nil {
+		return body, err
+	}
+	if body, err := softDeleteMethodBody(g, file, model, key, method, repo); body != "" || err != nil {
+		return body, err
+	}
+	if body, err := upsertMethodBody(g, file, model, method, repo); body != "" || err != nil {
+		return body, err
+	}
+	switch {
+	case strings.HasPrefix(methodName, "Create"):
+		return fmt.Sprintf(`if err = %s.Create(ctx, %s(args));err == nil{
+				reply.Code = %s
+			}else{
+				reply.Code = %s
 			}
-		`, methodName, serviceName, methodName, method.Input.Desc.FullName(), outType, outType, serviceName))
-			} else if methodName[:4] == "Find" && methodName[len(methodName)-4:] == "List" {
-				g.P(fmt.Sprintf(`// %s is server rpc method as defined
-			func (s *%s) %s(ctx context.Context, args *%s, reply *%s) (err error){
-				*reply = %s{}
-				if list,total,err :=model.Get%sList(*args.PageInfo);err == nil{
-					for _, v := range list {
-						reply.List = append(reply.List, v.Proto())
-					}
-					reply.Total = total
-					reply.Code = pbcommon.EnumCode_Success
-				}else{
-					reply.Code = pbcommon.EnumCode_FindError
-				}
-				return nil
+`, repo, g.QualifiedGoIdent(modelPath.Ident(serviceName+"ProtoToModel")), success, g.QualifiedGoIdent(commonPath.Ident("EnumCode_CreateError"))), nil
+	case strings.HasPrefix(methodName, "Update"):
+		mask, data, err := maskArgs(model, method.Input)
+		if err != nil {
+			return "", err
+		}
+		conflict, err := versionConflictCase(g, file, model, "reply.Code = %s")
+		if err != nil {
+			return "", err
+		}
+		if mask != nil {
+			return fmt.Sprintf(`if args.%[2]s == nil {
+				reply.Code = %[6]s
+			}else if err = %[1]s.UpdateFields(ctx, %[4]s(args.%[2]s), args.%[3]s.GetPaths());err == nil{
+				reply.Code = %[5]s
+			%[7]s}else{
+				reply.Code = %[6]s
 			}
-		`, methodName, serviceName, methodName, method.Input.Desc.FullName(), outType, outType, serviceName))
-			} else {
-				g.P(fmt.Sprintf(`// %s is server rpc method as defined
-			func (s *%s) %s(ctx context.Context, args *%s, reply *%s) (err error){
-				// TODO: add business logics
-	
-				// TODO: setting return values
-				*reply = %s{}
-	
-				return nil
+`, repo, data.GoName, mask.GoName, g.QualifiedGoIdent(modelPath.Ident(serviceName+"ProtoToModel")), success, g.QualifiedGoIdent(commonPath.Ident("EnumCode_UpdateError")), conflict), nil
+		}
+		return fmt.Sprintf(`if err = %s.Update(ctx, %s(args));err == nil{
+				reply.Code = %s
+			%s}else{
+				reply.Code = %s
 			}
-		`, methodName, serviceName, methodName, method.Input.Desc.FullName(), outType, outType))
+`, repo, g.QualifiedGoIdent(modelPath.Ident(serviceName+"ProtoToModel")), success, conflict, g.QualifiedGoIdent(commonPath.Ident("EnumCode_UpdateError"))), nil
+	case strings.HasPrefix(methodName, "Delete"):
+		return fmt.Sprintf(`if err = %s.Delete(ctx, %s);err == nil{
+				reply.Code = %s
+			}else{
+				reply.Code = %s
 			}
+`, repo, key.literal(g, modelPath.Ident(serviceName), "args"), success, g.QualifiedGoIdent(commonPath.Ident("EnumCode_DeleteError"))), nil
+	case strings.HasPrefix(methodName, "Find") && strings.HasSuffix(methodName, "ById"):
+		return fmt.Sprintf(`if result,err := %s.Get(ctx, %s);err == nil{
+				reply.Data = result.Proto()
+				reply.Code = %s
+			}else{
+				reply.Code = %s
+			}`, repo, key.args("args"), success, g.QualifiedGoIdent(commonPath.Ident("EnumCode_FindError"))), nil
+	case strings.HasPrefix(methodName, "Find") && strings.HasSuffix(methodName, "List"):
+		filter, err := filterArgs(g, model, method.Input)
+		if err != nil {
+			return "", err
+		}
+		arg := "listFilter"
+		if filter == "" {
+			arg = "nil"
+		}
+		setLimit, setNext, err := pageArgs(model, method.Input, method.Output)
+		if err != nil {
+			return "", err
 		}
+		if setLimit != "" {
+			return filter + setLimit + fmt.Sprintf(`if list,next,err := %s.GetPage(ctx, args.GetCursor(), limit, %s);err == nil{
+				for _, v := range list {
+					reply.List = append(reply.List, v.Proto())
+				}
+				%s
+				reply.Code = %s
+			}else{
+				reply.Code = %s
+			}`, repo, arg, setNext, success, g.QualifiedGoIdent(commonPath.Ident("EnumCode_FindError"))), nil
+		}
+		return filter + fmt.Sprintf(`pageInfo := args.GetPageInfo()
+			if pageInfo == nil {
+				// 未传 page_info 时查询第一页
+				pageInfo = &%s{Page: 1, PageSize: 10}
+			}
+			if list,total,err := %s.GetList(ctx, *pageInfo, %s);err == nil{
+				for _, v := range list {
+					reply.List = append(reply.List, v.Proto())
+				}
+				reply.Total = total
+				reply.Code = %s
+			}else{
+				reply.Code = %s
+			}`, g.QualifiedGoIdent(commonPath.Ident("PageInfo")), repo, arg, success, g.QualifiedGoIdent(commonPath.Ident("EnumCode_FindError"))), nil
 	}
-
+	return "", nil
 }
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/wwengg/protoc-gen-simple/simple"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var schemaCheckFlag = flag.String("schema_check", "warn", "how model changes against the previous parameter are reported: warn, strict or off")
+
+// modelColumn is a column of a model stored from a proto field.
+type modelColumn struct {
+	field   *protogen.Field
+	name    string
+	sqlType string
+}
+
+// modelColumns returns the columns of the model of message by field number,
+// leaving out relations and oneofs stored as JSON.
+func modelColumns(g *protogen.GeneratedFile, message *protogen.Message) (map[protoreflect.FieldNumber]modelColumn, error) {
+	columns := make(map[protoreflect.FieldNumber]modelColumn)
+	for _, field := range modelFields(message) {
+		if isOneofField(field) && isJSONOneof(field.Oneof) {
+			continue
+		}
+		storage, err := fieldStorage(field)
+		if err != nil {
+			return nil, err
+		}
+		if storage == simple.Storage_STORAGE_RELATION {
+			continue
+		}
+		ct, err := fieldColumnType(g, field, storage)
+		if err != nil {
+			return nil, err
+		}
+		columns[field.Desc.Number()] = modelColumn{field: field, name: columnName(field), sqlType: ct.sqlType}
+	}
+	return columns, nil
+}
+
+// checkSchema compares the models of file with the ones of the previous
+// schema and reports the changes that break the mapping of existing tables:
+// dropped models and columns, renamed and renumbered fields, changed primary
+// keys and narrowed column types.
+// They are written to w, or returned as an error with schema_check=strict.
+func checkSchema(gen *protogen.Plugin, file *protogen.File, w io.Writer) error {
+	if *previousFlag == "" || *schemaCheckFlag == "off" {
+		return nil
+	}
+	if *schemaCheckFlag != "warn" && *schemaCheckFlag != "strict" {
+		return fmt.Errorf("unknown schema_check %q, want warn, strict or off", *schemaCheckFlag)
+	}
+	prev, err := previousPlugin(gen)
+	if err != nil {
+		return err
+	}
+	prevFile, ok := prev.FilesByPath[file.Desc.Path()]
+	if !ok {
+		return nil
+	}
+	// The column types are computed with a file that is never written.
+	g := gen.NewGeneratedFile(file.GeneratedFilenamePrefix+".schema_check", file.GoImportPath)
+	g.Skip()
+	var changes []string
+	for _, old := range prevFile.Messages {
+		if !isModel(old) {
+			continue
+		}
+		c, err := modelChanges(g, old, findModel(file, old.Desc.FullName()))
+		if err != nil {
+			return fmt.Errorf("%s: %v", file.Desc.Path(), err)
+		}
+		changes = append(changes, c...)
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	if *schemaCheckFlag == "strict" {
+		return fmt.Errorf("%s: breaking model changes against %s:\n  %s", file.Desc.Path(), *previousFlag, strings.Join(changes, "\n  "))
+	}
+	for _, change := range changes {
+		fmt.Fprintf(w, "protoc-gen-simple: warning: %s: %s\n", file.Desc.Path(), change)
+	}
+	return nil
+}
+
+// findModel returns the model message of file named name, nil if there is none.
+func findModel(file *protogen.File, name protoreflect.FullName) *protogen.Message {
+	for _, message := range file.Messages {
+		if message.Desc.FullName() == name && isModel(message) {
+			return message
+		}
+	}
+	return nil
+}
+
+// modelChanges returns the breaking changes from the model old to the model
+// message, which is nil when the model was removed.
+func modelChanges(g *protogen.GeneratedFile, old, message *protogen.Message) ([]string, error) {
+	if message == nil {
+		return []string{fmt.Sprintf("model %s removed, table %s is no longer mapped", old.Desc.Name(), tableName(old))}, nil
+	}
+	var changes []string
+	if tableName(old) != tableName(message) {
+		changes = append(changes, fmt.Sprintf("model %s: table renamed from %s to %s", message.Desc.Name(), tableName(old), tableName(message)))
+	}
+	oldKey, err := keyColumns(old)
+	if err != nil {
+		return nil, fmt.Errorf("previous schema: %v", err)
+	}
+	key, err := keyColumns(message)
+	if err != nil {
+		return nil, err
+	}
+	if oldKey != key {
+		changes = append(changes, fmt.Sprintf("model %s: primary key changed from (%s) to (%s)", message.Desc.Name(), oldKey, key))
+	}
+	oldColumns, err := modelColumns(g, old)
+	if err != nil {
+		return nil, fmt.Errorf("previous schema: %v", err)
+	}
+	columns, err := modelColumns(g, message)
+	if err != nil {
+		return nil, err
+	}
+	// A column is matched by name first, so that a renumbered field keeps its
+	// column, and then by field number.
+	byName := make(map[string]modelColumn)
+	for _, c := range columns {
+		byName[c.name] = c
+	}
+	named := make(map[protoreflect.FieldNumber]bool)
+	for _, o := range oldColumns {
+		if c, ok := byName[o.name]; ok {
+			named[c.field.Desc.Number()] = true
+		}
+	}
+	for _, field := range old.Fields {
+		o, ok := oldColumns[field.Desc.Number()]
+		if !ok {
+			continue
+		}
+		c, ok := byName[o.name]
+		if ok {
+			if c.field.Desc.Number() != field.Desc.Number() {
+				changes = append(changes, fmt.Sprintf("%s: field %s renumbered from %d to %d, column %s unchanged", message.Desc.Name(), c.field.Desc.Name(), field.Desc.Number(), c.field.Desc.Number(), c.name))
+			}
+		} else {
+			c, ok = columns[field.Desc.Number()]
+			if !ok || named[field.Desc.Number()] {
+				changes = append(changes, fmt.Sprintf("%s: column %s dropped (field %d %s)", message.Desc.Name(), o.name, field.Desc.Number(), field.Desc.Name()))
+				continue
+			}
+			changes = append(changes, fmt.Sprintf("%s: field %d renamed from %s to %s, column %s becomes %s", message.Desc.Name(), field.Desc.Number(), field.Desc.Name(), c.field.Desc.Name(), o.name, c.name))
+		}
+		if !sqlTypeWidens(o.sqlType, c.sqlType) {
+			changes = append(changes, fmt.Sprintf("%s: column %s narrowed from %s to %s", message.Desc.Name(), c.name, o.sqlType, c.sqlType))
+		}
+	}
+	return changes, nil
+}
+
+// keyColumns returns the primary key columns of the model of message,
+// separated by commas.
+func keyColumns(message *protogen.Message) (string, error) {
+	if hasBaseModel(message) {
+		return "id", nil
+	}
+	key, err := primaryKey(message)
+	if err != nil {
+		return "", err
+	}
+	var names []string
+	for _, field := range key.fields {
+		names = append(names, columnName(field))
+	}
+	return strings.Join(names, ", "), nil
+}
+
+// sqlTypeWidenings lists the column type changes keeping every stored value,
+// besides longer varchars.
+var sqlTypeWidenings = map[string][]string{
+	"tinyint(1)":   {"int", "int unsigned", "bigint", "bigint unsigned"},
+	"int":          {"bigint"},
+	"int unsigned": {"bigint", "bigint unsigned"},
+	"float":        {"double"},
+	"varchar":      {"text"},
+}
+
+// sqlTypeWidens reports whether changing a column from the type old to the
+// type new keeps every stored value.
+func sqlTypeWidens(old, new string) bool {
+	if old == new {
+		return true
+	}
+	oldBase, oldArgs := splitSQLType(old)
+	newBase, newArgs := splitSQLType(new)
+	if oldBase == "varchar" && newBase == "varchar" {
+		oldSize, err1 := strconv.Atoi(strings.Trim(oldArgs, "()"))
+		newSize, err2 := strconv.Atoi(strings.Trim(newArgs, "()"))
+		return err1 == nil && err2 == nil && newSize >= oldSize
+	}
+	for _, t := range append(sqlTypeWidenings[old], sqlTypeWidenings[oldBase]...) {
+		if t == new {
+			return true
+		}
+	}
+	return false
+}
+
+// splitSQLType splits varchar(64) into varchar and (64).
+func splitSQLType(sqlType string) (string, string) {
+	if i := strings.Index(sqlType, "("); i >= 0 {
+		return sqlType[:i], sqlType[i:]
+	}
+	return sqlType, ""
+}
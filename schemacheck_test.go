@@ -0,0 +1,182 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestSQLTypeWidens(t *testing.T) {
+	tests := []struct {
+		old, new string
+		want     bool
+	}{
+		{"bigint", "bigint", true},
+		{"int", "bigint", true},
+		{"bigint", "int", false},
+		{"tinyint(1)", "int", true},
+		{"int unsigned", "bigint unsigned", true},
+		{"int unsigned", "int", false},
+		{"float", "double", true},
+		{"double", "float", false},
+		{"varchar(64)", "varchar(128)", true},
+		{"varchar(128)", "varchar(64)", false},
+		{"varchar(64)", "text", true},
+		{"text", "varchar(255)", false},
+		{"varchar", "varchar(64)", false},
+		{"datetime(3)", "datetime(3)", true},
+		{"json", "blob", false},
+	}
+	for _, tt := range tests {
+		if got := sqlTypeWidens(tt.old, tt.new); got != tt.want {
+			t.Errorf("sqlTypeWidens(%q, %q) = %v, want %v", tt.old, tt.new, got, tt.want)
+		}
+	}
+}
+
+const itemModel = `message_type {
+	name: "ItemModel" options { [simple.model]: true }
+	field { name: "id" number: 1 type: TYPE_INT64 }
+	field { name: "name" number: 2 type: TYPE_STRING }
+	field { name: "count" number: 3 type: TYPE_INT64 }
+}`
+
+func TestModelChanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    []string
+	}{{
+		name:    "unchanged",
+		message: itemModel,
+	}, {
+		name: "widened and added",
+		message: `message_type {
+			name: "ItemModel" options { [simple.model]: true }
+			field { name: "id" number: 1 type: TYPE_INT64 }
+			field { name: "name" number: 2 type: TYPE_STRING options { [simple.column] { type: "text" } } }
+			field { name: "count" number: 3 type: TYPE_INT64 }
+			field { name: "price" number: 4 type: TYPE_INT32 }
+		}`,
+	}, {
+		name:    "removed",
+		message: `message_type { name: "ItemModel" }`,
+		want:    []string{"model ItemModel removed, table items is no longer mapped"},
+	}, {
+		name: "dropped, renamed and narrowed",
+		message: `message_type {
+			name: "ItemModel" options { [simple.model]: true [simple.table] { name: "goods" } }
+			field { name: "id" number: 1 type: TYPE_INT64 }
+			field { name: "title" number: 2 type: TYPE_STRING }
+		}`,
+		want: []string{
+			"model ItemModel: table renamed from items to goods",
+			"ItemModel: field 2 renamed from name to title, column name becomes title",
+			"ItemModel: column count dropped (field 3 count)",
+		},
+	}, {
+		name: "renumbered",
+		message: `message_type {
+			name: "ItemModel" options { [simple.model]: true }
+			field { name: "id" number: 1 type: TYPE_INT64 }
+			field { name: "count" number: 2 type: TYPE_INT64 }
+			field { name: "name" number: 3 type: TYPE_STRING }
+		}`,
+		want: []string{
+			"ItemModel: field name renumbered from 2 to 3, column name unchanged",
+			"ItemModel: field count renumbered from 3 to 2, column count unchanged",
+		},
+	}, {
+		name: "renumbered over a dropped field",
+		message: `message_type {
+			name: "ItemModel" options { [simple.model]: true }
+			field { name: "id" number: 1 type: TYPE_INT64 }
+			field { name: "count" number: 2 type: TYPE_INT32 }
+		}`,
+		want: []string{
+			"ItemModel: column name dropped (field 2 name)",
+			"ItemModel: field count renumbered from 3 to 2, column count unchanged",
+			"ItemModel: column count narrowed from bigint to int",
+		},
+	}, {
+		name: "narrowed",
+		message: `message_type {
+			name: "ItemModel" options { [simple.model]: true }
+			field { name: "id" number: 1 type: TYPE_INT64 }
+			field { name: "name" number: 2 type: TYPE_STRING }
+			field { name: "count" number: 3 type: TYPE_INT32 }
+		}`,
+		want: []string{"ItemModel: column count narrowed from bigint to int"},
+	}, {
+		name: "primary key",
+		message: `message_type {
+			name: "ItemModel" options { [simple.model]: true [simple.table] { base_model: false primary_key: "name" } }
+			field { name: "id" number: 1 type: TYPE_INT64 }
+			field { name: "name" number: 2 type: TYPE_STRING }
+			field { name: "count" number: 3 type: TYPE_INT64 }
+		}`,
+		want: []string{"model ItemModel: primary key changed from (id) to (name)"},
+	}}
+	old := testMessage(t, testPlugin(t, testFile("shop", itemModel)), "ItemModel")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := testPlugin(t, testFile("shop", tt.message))
+			file := gen.Files[len(gen.Files)-1]
+			g := gen.NewGeneratedFile("shop.schema_check", file.GoImportPath)
+			got, err := modelChanges(g, old, findModel(file, old.Desc.FullName()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("modelChanges() =\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestCheckSchema(t *testing.T) {
+	set := &descriptorpb.FileDescriptorSet{File: testPlugin(t, testFile("shop", itemModel)).Request.ProtoFile}
+	b, err := proto.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	previous := filepath.Join(t.TempDir(), "previous.pb")
+	if err := os.WriteFile(previous, b, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	changed := `message_type {
+		name: "ItemModel" options { [simple.model]: true }
+		field { name: "id" number: 1 type: TYPE_INT64 }
+		field { name: "name" number: 2 type: TYPE_STRING }
+	}`
+	tests := []struct {
+		check, message string
+		err, warning   string
+	}{
+		{check: "warn", message: itemModel},
+		{check: "strict", message: itemModel},
+		{check: "warn", message: changed, warning: "protoc-gen-simple: warning: shop.proto: ItemModel: column count dropped (field 3 count)\n"},
+		{check: "strict", message: changed, err: "shop.proto: breaking model changes against " + previous + ":\n  ItemModel: column count dropped (field 3 count)"},
+		{check: "off", message: changed},
+		{check: "loose", message: changed, err: `unknown schema_check "loose", want warn, strict or off`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.check, func(t *testing.T) {
+			setParams(t, "previous="+previous+",schema_check="+tt.check)
+			gen := testPlugin(t, testFile("shop", tt.message))
+			var w bytes.Buffer
+			err := checkSchema(gen, gen.Files[len(gen.Files)-1], &w)
+			if tt.err == "" && err != nil || tt.err != "" && (err == nil || err.Error() != tt.err) {
+				t.Errorf("checkSchema() = %v, want %q", err, tt.err)
+			}
+			if w.String() != tt.warning {
+				t.Errorf("checkSchema() wrote %q, want %q", w.String(), tt.warning)
+			}
+		})
+	}
+}
@@ -10,15 +10,39 @@ import (
 
 const (
 	contextPackage      = protogen.GoImportPath("context")
+	errorsPackage       = protogen.GoImportPath("errors")
+	base64Package       = protogen.GoImportPath("encoding/base64")
+	jsonPackage         = protogen.GoImportPath("encoding/json")
+	fmtPackage          = protogen.GoImportPath("fmt")
+	stringsPackage      = protogen.GoImportPath("strings")
 	rpcxServerPackage   = protogen.GoImportPath("github.com/smallnest/rpcx/server")
 	rpcxClientPackage   = protogen.GoImportPath("github.com/smallnest/rpcx/client")
 	rpcxProtocolPackage = protogen.GoImportPath("github.com/smallnest/rpcx/protocol")
 	SimplesrpcPackage   = protogen.GoImportPath("github.com/wwengg/simple/core/srpc")
 	SimpleStorePackage  = protogen.GoImportPath("github.com/wwengg/simple/core/store")
+	SimpleConfigPackage = protogen.GoImportPath("github.com/wwengg/simple/core/sconfig")
 	GormPackage         = protogen.GoImportPath("gorm.io/gorm")
+	clausePackage       = protogen.GoImportPath("gorm.io/gorm/clause")
 	TimePackage         = protogen.GoImportPath("time")
+	strconvPackage      = protogen.GoImportPath("strconv")
+	uuidPackage         = protogen.GoImportPath("github.com/google/uuid")
+	timestamppbPackage  = protogen.GoImportPath("google.golang.org/protobuf/types/known/timestamppb")
+	durationpbPackage   = protogen.GoImportPath("google.golang.org/protobuf/types/known/durationpb")
+	wrapperspbPackage   = protogen.GoImportPath("google.golang.org/protobuf/types/known/wrapperspb")
+	structpbPackage     = protogen.GoImportPath("google.golang.org/protobuf/types/known/structpb")
 )
 
+func init() {
+	registerGenerator(&generator{
+		name:        "rpcx",
+		description: "rpcx server skeleton and client stubs in the proto package (*.simple.pb.go)",
+		generate: func(gen *protogen.Plugin, file *protogen.File) error {
+			generateFile(gen, file)
+			return nil
+		},
+	})
+}
+
 // generateFile generates a _grpc.pb.go file containing gRPC service definitions.
 func generateFile(gen *protogen.Plugin, file *protogen.File) *protogen.GeneratedFile {
 	if len(file.Services) == 0 {
@@ -66,19 +90,7 @@ func generateFileContent(gen *protogen.Plugin, file *protogen.File, g *protogen.
 	g.P("var _ = ", rpcxClientPackage.Ident("NewClient"))
 	g.P("var _ = ", rpcxProtocolPackage.Ident("NewMessage"))
 	g.P("var _ = ", SimplesrpcPackage.Ident("TODO"))
-	g.P("var _ = ", SimpleStorePackage.Ident("TODO"))
-	g.P("var _ = ", GormPackage.Ident("ErrEmptySlice"))
 	g.P()
-	g.P("//================== Model ===================")
-	for _, message := range file.Messages {
-
-		name := string(message.Desc.Name())
-		if strings.Contains(name, "Model") {
-			generateModelCode(g, message)
-		}
-	}
-	g.P("//================== Model End ===================")
-
 	for _, service := range file.Services {
 		genService(gen, file, g, service)
 	}
@@ -103,7 +115,7 @@ func genService(gen *protogen.Plugin, file *protogen.File, g *protogen.Generated
 		// ServeFor%[1]s starts a server only registers one service.
 		// You can register more services and only start one server.
 		// It blocks until the application exits.
-		func ServeFor%[1]s(addr string, rpc sconfig.RPC, rpcService sconfig.RpcService) error{
+		func ServeFor%[1]s(addr string, rpc %[2]s, rpcService %[3]s) error{
 			s := server.NewServer()
 			// 开启rpcx监控
 			s.EnableProfile = true
@@ -112,7 +124,7 @@ func genService(gen *protogen.Plugin, file *protogen.File, g *protogen.Generated
 			s.RegisterName("%[1]s", new(%[1]sImpl), "")
 			return s.Serve("tcp", addr)
 		}
-	`, serviceName))
+	`, serviceName, g.QualifiedGoIdent(SimpleConfigPackage.Ident("RPC")), g.QualifiedGoIdent(SimpleConfigPackage.Ident("RpcService"))))
 	g.P()
 	for _, method := range service.Methods {
 		generateServerCode(g, service, method)
@@ -176,78 +188,6 @@ func genService(gen *protogen.Plugin, file *protogen.File, g *protogen.Generated
 	}
 }
 
-func generateModelCode(g *protogen.GeneratedFile, message *protogen.Message) {
-	name := g.QualifiedGoIdent(message.GoIdent)
-	fullName := message.Desc.FullName()
-	afterName, _ := strings.CutSuffix(name, "Model")
-	g.P(fmt.Sprintf("//================== %s Model ===================", afterName))
-	g.P()
-	g.P(fmt.Sprintf(`// %s Model
-		type %s struct {
-			store.BASE_MODEL
-`, afterName, afterName))
-
-	for _, field := range message.Fields {
-		if field.GoName == "Id" || field.GoName == "CreatedAt" || field.GoName == "UpdatedAt" || field.GoName == "DeletedAt" {
-			continue
-		}
-		generateModelFiled(g, field)
-
-	}
-	g.P(`		}`)
-	g.P()
-	g.P(fmt.Sprintf(`
-
-		func (model *%[1]s) Proto() *%[2]s {
-			return &%[2]s{}
-		}
-
-		
-		// Create%[1]s Func 创建
-		func Create%[1]s(a %[1]s) (err error) {
-			err = global.DB_.Create(&a).Error
-			return err
-		}
-		
-		// Delete%[1]s  删除
-		func Delete%[1]s(a %[1]s) (err error) {
-			err = global.DB_.Delete(&a).Error
-			return err
-		}
-
-		// Update%[1]s 修改
-		func Update%[1]s(a *%[1]s) (err error) {
-			err = global.DB_.Save(a).Error
-			return err
-		}
-
-		// Update%[1]s 查询
-		func Get%[1]s(id int64) (result %[1]s, err error) {
-			err = global.DB_.Where("id = ?", id).First(&result).Error
-			return
-		}
-
-		// 分页查询
-		func Get%[1]sList(info pbbase.PageInfo) (list []%[1]s, total int64, err error) {
-			limit := info.PageSize
-			offset := info.PageSize * (info.Page - 1)
-			db := global.DB_.Model(&%[1]s{})
-			var %[1]sList []%[1]s
-			// 此处增加查询条件
-			//if info.Keyword != "" {
-			//	db.Where("keywaord = ?", info.Keyword)
-			//}
-			err = db.Count(&total).Error
-			if err != nil {
-				return %[1]sList, total, err
-			} else {
-				err = db.Limit(int(limit)).Offset(int(offset)).Find(&%[1]sList).Error
-			}
-			return %[1]sList, total, err
-		}
-`, afterName, fullName))
-}
-
 func generateServerCode(g *protogen.GeneratedFile, service *protogen.Service, method *protogen.Method) {
 	methodName := upperFirstLatter(method.GoName)
 	serviceName := upperFirstLatter(service.GoName)
@@ -0,0 +1,5 @@
+#!/bin/sh
+# Run from the repository root.
+
+protoc -I. \
+  --go_out=. --go_opt=paths=source_relative simple/options.proto
@@ -0,0 +1,1493 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        v3.21.12
+// source: simple/options.proto
+
+package simple
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// FilterOp is a condition of the filter of a model on a column. The FindList
+// implementation reads each condition from the request field named after it:
+//
+//	FILTER_OP_EQ      <field>, optional to filter zero values
+//	FILTER_OP_RANGE   <field>_min and <field>_max, both inclusive
+//	FILTER_OP_IN      repeated <field>_in
+//	FILTER_OP_LIKE    string <field>_like, matching anywhere in the column
+//	FILTER_OP_PREFIX  string <field>_prefix
+//
+// Besides, string keyword matches the keyword columns and repeated string
+// sort lists the sort columns.
+type FilterOp int32
+
+const (
+	FilterOp_FILTER_OP_NONE   FilterOp = 0
+	FilterOp_FILTER_OP_EQ     FilterOp = 1
+	FilterOp_FILTER_OP_RANGE  FilterOp = 2
+	FilterOp_FILTER_OP_IN     FilterOp = 3
+	FilterOp_FILTER_OP_LIKE   FilterOp = 4
+	FilterOp_FILTER_OP_PREFIX FilterOp = 5
+)
+
+// Enum value maps for FilterOp.
+var (
+	FilterOp_name = map[int32]string{
+		0: "FILTER_OP_NONE",
+		1: "FILTER_OP_EQ",
+		2: "FILTER_OP_RANGE",
+		3: "FILTER_OP_IN",
+		4: "FILTER_OP_LIKE",
+		5: "FILTER_OP_PREFIX",
+	}
+	FilterOp_value = map[string]int32{
+		"FILTER_OP_NONE":   0,
+		"FILTER_OP_EQ":     1,
+		"FILTER_OP_RANGE":  2,
+		"FILTER_OP_IN":     3,
+		"FILTER_OP_LIKE":   4,
+		"FILTER_OP_PREFIX": 5,
+	}
+)
+
+func (x FilterOp) Enum() *FilterOp {
+	p := new(FilterOp)
+	*p = x
+	return p
+}
+
+func (x FilterOp) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FilterOp) Descriptor() protoreflect.EnumDescriptor {
+	return file_simple_options_proto_enumTypes[0].Descriptor()
+}
+
+func (FilterOp) Type() protoreflect.EnumType {
+	return &file_simple_options_proto_enumTypes[0]
+}
+
+func (x FilterOp) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FilterOp.Descriptor instead.
+func (FilterOp) EnumDescriptor() ([]byte, []int) {
+	return file_simple_options_proto_rawDescGZIP(), []int{0}
+}
+
+// RelationType is the kind of a Relation.
+type RelationType int32
+
+const (
+	RelationType_RELATION_NONE RelationType = 0
+	// The model holds the foreign key <Field>Id of a singular field.
+	RelationType_RELATION_BELONGS_TO RelationType = 1
+	// The model of a singular field holds the foreign key <Model>Id.
+	RelationType_RELATION_HAS_ONE RelationType = 2
+	// The models of a repeated field hold the foreign key <Model>Id.
+	RelationType_RELATION_HAS_MANY RelationType = 3
+	// The models of a repeated field are linked through a join table.
+	RelationType_RELATION_MANY_TO_MANY RelationType = 4
+)
+
+// Enum value maps for RelationType.
+var (
+	RelationType_name = map[int32]string{
+		0: "RELATION_NONE",
+		1: "RELATION_BELONGS_TO",
+		2: "RELATION_HAS_ONE",
+		3: "RELATION_HAS_MANY",
+		4: "RELATION_MANY_TO_MANY",
+	}
+	RelationType_value = map[string]int32{
+		"RELATION_NONE":         0,
+		"RELATION_BELONGS_TO":   1,
+		"RELATION_HAS_ONE":      2,
+		"RELATION_HAS_MANY":     3,
+		"RELATION_MANY_TO_MANY": 4,
+	}
+)
+
+func (x RelationType) Enum() *RelationType {
+	p := new(RelationType)
+	*p = x
+	return p
+}
+
+func (x RelationType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RelationType) Descriptor() protoreflect.EnumDescriptor {
+	return file_simple_options_proto_enumTypes[1].Descriptor()
+}
+
+func (RelationType) Type() protoreflect.EnumType {
+	return &file_simple_options_proto_enumTypes[1]
+}
+
+func (x RelationType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RelationType.Descriptor instead.
+func (RelationType) EnumDescriptor() ([]byte, []int) {
+	return file_simple_options_proto_rawDescGZIP(), []int{1}
+}
+
+// Storage selects how a repeated, map or message field is stored.
+type Storage int32
+
+const (
+	// Scalars are stored as a column, everything else as JSON.
+	Storage_STORAGE_AUTO Storage = 0
+	// A JSON column holding the value serialized by encoding/json.
+	Storage_STORAGE_JSON Storage = 1
+	// A has-many association to the model of a repeated message field, same
+	// as RELATION_HAS_MANY.
+	Storage_STORAGE_CHILD_TABLE Storage = 2
+	// A belongs-to association to the model of a message field, stored as a
+	// foreign key column. Same as RELATION_BELONGS_TO.
+	Storage_STORAGE_FOREIGN_KEY Storage = 3
+	// An association declared by relation, implied when relation is set.
+	Storage_STORAGE_RELATION Storage = 4
+)
+
+// Enum value maps for Storage.
+var (
+	Storage_name = map[int32]string{
+		0: "STORAGE_AUTO",
+		1: "STORAGE_JSON",
+		2: "STORAGE_CHILD_TABLE",
+		3: "STORAGE_FOREIGN_KEY",
+		4: "STORAGE_RELATION",
+	}
+	Storage_value = map[string]int32{
+		"STORAGE_AUTO":        0,
+		"STORAGE_JSON":        1,
+		"STORAGE_CHILD_TABLE": 2,
+		"STORAGE_FOREIGN_KEY": 3,
+		"STORAGE_RELATION":    4,
+	}
+)
+
+func (x Storage) Enum() *Storage {
+	p := new(Storage)
+	*p = x
+	return p
+}
+
+func (x Storage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Storage) Descriptor() protoreflect.EnumDescriptor {
+	return file_simple_options_proto_enumTypes[2].Descriptor()
+}
+
+func (Storage) Type() protoreflect.EnumType {
+	return &file_simple_options_proto_enumTypes[2]
+}
+
+func (x Storage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Storage.Descriptor instead.
+func (Storage) EnumDescriptor() ([]byte, []int) {
+	return file_simple_options_proto_rawDescGZIP(), []int{2}
+}
+
+// Pagination selects how lists of a model are paginated.
+type Pagination int32
+
+const (
+	// Get<Model>List with a page number, counting the rows.
+	Pagination_PAGINATION_OFFSET Pagination = 0
+	// Also Get<Model>Page, ordered by the primary key from an opaque cursor
+	// returned by the previous page, without counting the rows. The FindList
+	// implementation uses it when the request has a string cursor field.
+	Pagination_PAGINATION_KEYSET Pagination = 1
+)
+
+// Enum value maps for Pagination.
+var (
+	Pagination_name = map[int32]string{
+		0: "PAGINATION_OFFSET",
+		1: "PAGINATION_KEYSET",
+	}
+	Pagination_value = map[string]int32{
+		"PAGINATION_OFFSET": 0,
+		"PAGINATION_KEYSET": 1,
+	}
+)
+
+func (x Pagination) Enum() *Pagination {
+	p := new(Pagination)
+	*p = x
+	return p
+}
+
+func (x Pagination) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Pagination) Descriptor() protoreflect.EnumDescriptor {
+	return file_simple_options_proto_enumTypes[3].Descriptor()
+}
+
+func (Pagination) Type() protoreflect.EnumType {
+	return &file_simple_options_proto_enumTypes[3]
+}
+
+func (x Pagination) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Pagination.Descriptor instead.
+func (Pagination) EnumDescriptor() ([]byte, []int) {
+	return file_simple_options_proto_rawDescGZIP(), []int{3}
+}
+
+// IndexOrder is the sort order of an indexed column.
+type IndexOrder int32
+
+const (
+	IndexOrder_INDEX_ORDER_ASC  IndexOrder = 0
+	IndexOrder_INDEX_ORDER_DESC IndexOrder = 1
+)
+
+// Enum value maps for IndexOrder.
+var (
+	IndexOrder_name = map[int32]string{
+		0: "INDEX_ORDER_ASC",
+		1: "INDEX_ORDER_DESC",
+	}
+	IndexOrder_value = map[string]int32{
+		"INDEX_ORDER_ASC":  0,
+		"INDEX_ORDER_DESC": 1,
+	}
+)
+
+func (x IndexOrder) Enum() *IndexOrder {
+	p := new(IndexOrder)
+	*p = x
+	return p
+}
+
+func (x IndexOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (IndexOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_simple_options_proto_enumTypes[4].Descriptor()
+}
+
+func (IndexOrder) Type() protoreflect.EnumType {
+	return &file_simple_options_proto_enumTypes[4]
+}
+
+func (x IndexOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use IndexOrder.Descriptor instead.
+func (IndexOrder) EnumDescriptor() ([]byte, []int) {
+	return file_simple_options_proto_rawDescGZIP(), []int{4}
+}
+
+// KeyStrategy selects how the primary key of a new row is generated.
+type KeyStrategy int32
+
+const (
+	// Auto increment for a single integer key, assigned by the caller otherwise.
+	KeyStrategy_KEY_STRATEGY_AUTO KeyStrategy = 0
+	// An auto increment column, requires a single integer key.
+	KeyStrategy_KEY_STRATEGY_AUTO_INCREMENT KeyStrategy = 1
+	// A UUID version 7 generated before create, requires a single string key.
+	KeyStrategy_KEY_STRATEGY_UUID_V7 KeyStrategy = 2
+	// An ID returned by the function named by the snowflake parameter,
+	// generated before create. Requires a single integer or string key.
+	KeyStrategy_KEY_STRATEGY_SNOWFLAKE KeyStrategy = 3
+	// Assigned by the caller.
+	KeyStrategy_KEY_STRATEGY_NONE KeyStrategy = 4
+)
+
+// Enum value maps for KeyStrategy.
+var (
+	KeyStrategy_name = map[int32]string{
+		0: "KEY_STRATEGY_AUTO",
+		1: "KEY_STRATEGY_AUTO_INCREMENT",
+		2: "KEY_STRATEGY_UUID_V7",
+		3: "KEY_STRATEGY_SNOWFLAKE",
+		4: "KEY_STRATEGY_NONE",
+	}
+	KeyStrategy_value = map[string]int32{
+		"KEY_STRATEGY_AUTO":           0,
+		"KEY_STRATEGY_AUTO_INCREMENT": 1,
+		"KEY_STRATEGY_UUID_V7":        2,
+		"KEY_STRATEGY_SNOWFLAKE":      3,
+		"KEY_STRATEGY_NONE":           4,
+	}
+)
+
+func (x KeyStrategy) Enum() *KeyStrategy {
+	p := new(KeyStrategy)
+	*p = x
+	return p
+}
+
+func (x KeyStrategy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (KeyStrategy) Descriptor() protoreflect.EnumDescriptor {
+	return file_simple_options_proto_enumTypes[5].Descriptor()
+}
+
+func (KeyStrategy) Type() protoreflect.EnumType {
+	return &file_simple_options_proto_enumTypes[5]
+}
+
+func (x KeyStrategy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use KeyStrategy.Descriptor instead.
+func (KeyStrategy) EnumDescriptor() ([]byte, []int) {
+	return file_simple_options_proto_rawDescGZIP(), []int{5}
+}
+
+// OneofStorage selects how a oneof is stored.
+type OneofStorage int32
+
+const (
+	// A discriminator column holding the name of the set field, plus one
+	// nullable column per field.
+	OneofStorage_ONEOF_STORAGE_COLUMNS OneofStorage = 0
+	// A single JSON column holding the set field.
+	OneofStorage_ONEOF_STORAGE_JSON OneofStorage = 1
+)
+
+// Enum value maps for OneofStorage.
+var (
+	OneofStorage_name = map[int32]string{
+		0: "ONEOF_STORAGE_COLUMNS",
+		1: "ONEOF_STORAGE_JSON",
+	}
+	OneofStorage_value = map[string]int32{
+		"ONEOF_STORAGE_COLUMNS": 0,
+		"ONEOF_STORAGE_JSON":    1,
+	}
+)
+
+func (x OneofStorage) Enum() *OneofStorage {
+	p := new(OneofStorage)
+	*p = x
+	return p
+}
+
+func (x OneofStorage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OneofStorage) Descriptor() protoreflect.EnumDescriptor {
+	return file_simple_options_proto_enumTypes[6].Descriptor()
+}
+
+func (OneofStorage) Type() protoreflect.EnumType {
+	return &file_simple_options_proto_enumTypes[6]
+}
+
+func (x OneofStorage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OneofStorage.Descriptor instead.
+func (OneofStorage) EnumDescriptor() ([]byte, []int) {
+	return file_simple_options_proto_rawDescGZIP(), []int{6}
+}
+
+// ColumnOptions customizes the model column generated for a field.
+//
+//	string name = 2 [(simple.column) = {size: 64, not_null: true, comment: "user name"}];
+type ColumnOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Column name, defaults to the snake case field name.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// SQL type, defaults to the type derived from the field kind.
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// Column size, for strings the length of the varchar.
+	Size int32 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	// Column comment, defaults to the leading comment of the field.
+	Comment string `protobuf:"bytes,4,opt,name=comment,proto3" json:"comment,omitempty"`
+	NotNull bool   `protobuf:"varint,5,opt,name=not_null,json=notNull,proto3" json:"not_null,omitempty"`
+	// Default value as an SQL literal.
+	Default string `protobuf:"bytes,6,opt,name=default,proto3" json:"default,omitempty"`
+	// A unique constraint. A Get<Model>By<Field> function is generated for it.
+	Unique bool `protobuf:"varint,7,opt,name=unique,proto3" json:"unique,omitempty"`
+	// A single column index, see indexes of TableOptions for composite ones.
+	Index bool `protobuf:"varint,8,opt,name=index,proto3" json:"index,omitempty"`
+	// Leave the field out of the model.
+	Ignore bool `protobuf:"varint,9,opt,name=ignore,proto3" json:"ignore,omitempty"`
+	// How the field is stored, see Storage.
+	Storage Storage `protobuf:"varint,10,opt,name=storage,proto3,enum=simple.Storage" json:"storage,omitempty"`
+	// Foreign key field of the related model (has-one, has-many) or of the
+	// model itself (belongs-to). Defaults to <Parent>Id for has-one and
+	// has-many relations and <Field>Id for belongs-to relations.
+	ForeignKey string `protobuf:"bytes,11,opt,name=foreign_key,json=foreignKey,proto3" json:"foreign_key,omitempty"`
+	// Sort order of the index created by index.
+	IndexOrder IndexOrder `protobuf:"varint,12,opt,name=index_order,json=indexOrder,proto3,enum=simple.IndexOrder" json:"index_order,omitempty"`
+	// Association to the model of a message field, see Relation.
+	Relation *Relation `protobuf:"bytes,13,opt,name=relation,proto3" json:"relation,omitempty"`
+	// Conditions of the <Model>Filter of Get<Model>List on the column, see
+	// FilterOp. Only for singular scalar fields.
+	Filter []FilterOp `protobuf:"varint,14,rep,packed,name=filter,proto3,enum=simple.FilterOp" json:"filter,omitempty"`
+	// Allow sorting Get<Model>List by the column. Primary key columns are
+	// always sortable.
+	Sortable bool `protobuf:"varint,15,opt,name=sortable,proto3" json:"sortable,omitempty"`
+	// Match the keyword of Get<Model>List against the column with LIKE. Only
+	// for string fields.
+	Keyword bool `protobuf:"varint,16,opt,name=keyword,proto3" json:"keyword,omitempty"`
+}
+
+func (x *ColumnOptions) Reset() {
+	*x = ColumnOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_simple_options_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ColumnOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColumnOptions) ProtoMessage() {}
+
+func (x *ColumnOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_simple_options_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColumnOptions.ProtoReflect.Descriptor instead.
+func (*ColumnOptions) Descriptor() ([]byte, []int) {
+	return file_simple_options_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ColumnOptions) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ColumnOptions) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ColumnOptions) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ColumnOptions) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *ColumnOptions) GetNotNull() bool {
+	if x != nil {
+		return x.NotNull
+	}
+	return false
+}
+
+func (x *ColumnOptions) GetDefault() string {
+	if x != nil {
+		return x.Default
+	}
+	return ""
+}
+
+func (x *ColumnOptions) GetUnique() bool {
+	if x != nil {
+		return x.Unique
+	}
+	return false
+}
+
+func (x *ColumnOptions) GetIndex() bool {
+	if x != nil {
+		return x.Index
+	}
+	return false
+}
+
+func (x *ColumnOptions) GetIgnore() bool {
+	if x != nil {
+		return x.Ignore
+	}
+	return false
+}
+
+func (x *ColumnOptions) GetStorage() Storage {
+	if x != nil {
+		return x.Storage
+	}
+	return Storage_STORAGE_AUTO
+}
+
+func (x *ColumnOptions) GetForeignKey() string {
+	if x != nil {
+		return x.ForeignKey
+	}
+	return ""
+}
+
+func (x *ColumnOptions) GetIndexOrder() IndexOrder {
+	if x != nil {
+		return x.IndexOrder
+	}
+	return IndexOrder_INDEX_ORDER_ASC
+}
+
+func (x *ColumnOptions) GetRelation() *Relation {
+	if x != nil {
+		return x.Relation
+	}
+	return nil
+}
+
+func (x *ColumnOptions) GetFilter() []FilterOp {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ColumnOptions) GetSortable() bool {
+	if x != nil {
+		return x.Sortable
+	}
+	return false
+}
+
+func (x *ColumnOptions) GetKeyword() bool {
+	if x != nil {
+		return x.Keyword
+	}
+	return false
+}
+
+// Relation declares an association between the model of a message and the
+// model of one of its message fields.
+//
+//	UserModel user = 5 [(simple.column).relation = {type: RELATION_BELONGS_TO}];
+//	repeated TagModel tags = 6 [(simple.column).relation = {type: RELATION_MANY_TO_MANY, join_table: "article_tags"}];
+type Relation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type RelationType `protobuf:"varint,1,opt,name=type,proto3,enum=simple.RelationType" json:"type,omitempty"`
+	// Join table of a many-to-many relation, defaults to <model>_<field>.
+	JoinTable string `protobuf:"bytes,2,opt,name=join_table,json=joinTable,proto3" json:"join_table,omitempty"`
+	// Field of the referenced model (belongs-to) or of this model (has-one,
+	// has-many) the foreign key refers to, defaults to the primary key.
+	References string `protobuf:"bytes,3,opt,name=references,proto3" json:"references,omitempty"`
+}
+
+func (x *Relation) Reset() {
+	*x = Relation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_simple_options_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Relation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Relation) ProtoMessage() {}
+
+func (x *Relation) ProtoReflect() protoreflect.Message {
+	mi := &file_simple_options_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Relation.ProtoReflect.Descriptor instead.
+func (*Relation) Descriptor() ([]byte, []int) {
+	return file_simple_options_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Relation) GetType() RelationType {
+	if x != nil {
+		return x.Type
+	}
+	return RelationType_RELATION_NONE
+}
+
+func (x *Relation) GetJoinTable() string {
+	if x != nil {
+		return x.JoinTable
+	}
+	return ""
+}
+
+func (x *Relation) GetReferences() string {
+	if x != nil {
+		return x.References
+	}
+	return ""
+}
+
+// TableOptions customizes the table of a model message.
+type TableOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Table name, defaults to the gorm naming strategy.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Storage engine, such as InnoDB.
+	Engine string `protobuf:"bytes,2,opt,name=engine,proto3" json:"engine,omitempty"`
+	// Default character set, such as utf8mb4.
+	Charset string `protobuf:"bytes,3,opt,name=charset,proto3" json:"charset,omitempty"`
+	// Names of the primary key fields, defaults to id.
+	PrimaryKey []string `protobuf:"bytes,4,rep,name=primary_key,json=primaryKey,proto3" json:"primary_key,omitempty"`
+	// How the primary key of a new row is generated, see KeyStrategy.
+	KeyStrategy KeyStrategy `protobuf:"varint,5,opt,name=key_strategy,json=keyStrategy,proto3,enum=simple.KeyStrategy" json:"key_strategy,omitempty"`
+	// Embed store.BASE_MODEL providing an int64 auto increment ID, CreatedAt,
+	// UpdatedAt and DeletedAt. Defaults to true. Without it every field, id
+	// included, is a column of the model.
+	BaseModel *bool `protobuf:"varint,6,opt,name=base_model,json=baseModel,proto3,oneof" json:"base_model,omitempty"`
+	// Indexes and unique constraints, possibly spanning several fields.
+	Indexes []*Index `protobuf:"bytes,7,rep,name=indexes,proto3" json:"indexes,omitempty"`
+	// How lists of the model are paginated, see Pagination.
+	Pagination Pagination `protobuf:"varint,8,opt,name=pagination,proto3,enum=simple.Pagination" json:"pagination,omitempty"`
+	// Optimistic locking on the integer version field: updates match the
+	// version read by the client and increment it, and fail with
+	// Err<Model>VersionConflict when the row was changed in between.
+	OptimisticLock bool `protobuf:"varint,9,opt,name=optimistic_lock,json=optimisticLock,proto3" json:"optimistic_lock,omitempty"`
+	// Soft delete through the DeletedAt of the base model: Delete<Model> only
+	// marks rows deleted, which Restore<Model> undoes and HardDelete<Model>
+	// removes. Defaults to true; false deletes rows permanently and ignores
+	// DeletedAt. Requires base_model.
+	SoftDelete *bool `protobuf:"varint,10,opt,name=soft_delete,json=softDelete,proto3,oneof" json:"soft_delete,omitempty"`
+}
+
+func (x *TableOptions) Reset() {
+	*x = TableOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_simple_options_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TableOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TableOptions) ProtoMessage() {}
+
+func (x *TableOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_simple_options_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TableOptions.ProtoReflect.Descriptor instead.
+func (*TableOptions) Descriptor() ([]byte, []int) {
+	return file_simple_options_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TableOptions) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TableOptions) GetEngine() string {
+	if x != nil {
+		return x.Engine
+	}
+	return ""
+}
+
+func (x *TableOptions) GetCharset() string {
+	if x != nil {
+		return x.Charset
+	}
+	return ""
+}
+
+func (x *TableOptions) GetPrimaryKey() []string {
+	if x != nil {
+		return x.PrimaryKey
+	}
+	return nil
+}
+
+func (x *TableOptions) GetKeyStrategy() KeyStrategy {
+	if x != nil {
+		return x.KeyStrategy
+	}
+	return KeyStrategy_KEY_STRATEGY_AUTO
+}
+
+func (x *TableOptions) GetBaseModel() bool {
+	if x != nil && x.BaseModel != nil {
+		return *x.BaseModel
+	}
+	return false
+}
+
+func (x *TableOptions) GetIndexes() []*Index {
+	if x != nil {
+		return x.Indexes
+	}
+	return nil
+}
+
+func (x *TableOptions) GetPagination() Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return Pagination_PAGINATION_OFFSET
+}
+
+func (x *TableOptions) GetOptimisticLock() bool {
+	if x != nil {
+		return x.OptimisticLock
+	}
+	return false
+}
+
+func (x *TableOptions) GetSoftDelete() bool {
+	if x != nil && x.SoftDelete != nil {
+		return *x.SoftDelete
+	}
+	return false
+}
+
+// Index declares an index of a model table.
+//
+//	option (simple.table) = {
+//	  indexes: {fields: [{name: "tenant_id"}, {name: "created_at", order: INDEX_ORDER_DESC}]}
+//	};
+type Index struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Index name, defaults to idx_<model>_<columns>.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Indexed fields, in index order.
+	Fields []*IndexField `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty"`
+	// A unique index. A Get<Model>By<Fields> function is generated for it.
+	Unique bool `protobuf:"varint,3,opt,name=unique,proto3" json:"unique,omitempty"`
+	// The conflict target of Upsert and FindOrCreate, for a unique index. It
+	// may be left out when the model has a single unique column or index.
+	Upsert bool `protobuf:"varint,4,opt,name=upsert,proto3" json:"upsert,omitempty"`
+}
+
+func (x *Index) Reset() {
+	*x = Index{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_simple_options_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Index) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Index) ProtoMessage() {}
+
+func (x *Index) ProtoReflect() protoreflect.Message {
+	mi := &file_simple_options_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Index.ProtoReflect.Descriptor instead.
+func (*Index) Descriptor() ([]byte, []int) {
+	return file_simple_options_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Index) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Index) GetFields() []*IndexField {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *Index) GetUnique() bool {
+	if x != nil {
+		return x.Unique
+	}
+	return false
+}
+
+func (x *Index) GetUpsert() bool {
+	if x != nil {
+		return x.Upsert
+	}
+	return false
+}
+
+// IndexField is a field of an Index.
+type IndexField struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Field name.
+	Name  string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Order IndexOrder `protobuf:"varint,2,opt,name=order,proto3,enum=simple.IndexOrder" json:"order,omitempty"`
+}
+
+func (x *IndexField) Reset() {
+	*x = IndexField{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_simple_options_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IndexField) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndexField) ProtoMessage() {}
+
+func (x *IndexField) ProtoReflect() protoreflect.Message {
+	mi := &file_simple_options_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndexField.ProtoReflect.Descriptor instead.
+func (*IndexField) Descriptor() ([]byte, []int) {
+	return file_simple_options_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *IndexField) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *IndexField) GetOrder() IndexOrder {
+	if x != nil {
+		return x.Order
+	}
+	return IndexOrder_INDEX_ORDER_ASC
+}
+
+// OneofOptions customizes how a oneof of a model message is stored.
+//
+//	oneof contact {
+//	  option (simple.oneof) = {storage: ONEOF_STORAGE_JSON};
+//	  string email = 5;
+//	  string phone = 6;
+//	}
+type OneofOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Column name of the discriminator (ONEOF_STORAGE_COLUMNS) or of the JSON
+	// column (ONEOF_STORAGE_JSON). Defaults to <oneof>_case and <oneof>.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// How the oneof is stored, see OneofStorage.
+	Storage OneofStorage `protobuf:"varint,2,opt,name=storage,proto3,enum=simple.OneofStorage" json:"storage,omitempty"`
+}
+
+func (x *OneofOptions) Reset() {
+	*x = OneofOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_simple_options_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OneofOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OneofOptions) ProtoMessage() {}
+
+func (x *OneofOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_simple_options_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OneofOptions.ProtoReflect.Descriptor instead.
+func (*OneofOptions) Descriptor() ([]byte, []int) {
+	return file_simple_options_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *OneofOptions) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OneofOptions) GetStorage() OneofStorage {
+	if x != nil {
+		return x.Storage
+	}
+	return OneofStorage_ONEOF_STORAGE_COLUMNS
+}
+
+// MethodOptions customizes the implementation generated for an rpc method.
+//
+//	rpc Transfer(TransferArgs) returns (CommonReply) {
+//	  option (simple.method) = {transactional: true};
+//	}
+type MethodOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Run the method in a transaction, rolled back when the method returns an
+	// error or a reply code other than Success.
+	Transactional bool `protobuf:"varint,1,opt,name=transactional,proto3" json:"transactional,omitempty"`
+}
+
+func (x *MethodOptions) Reset() {
+	*x = MethodOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_simple_options_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MethodOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MethodOptions) ProtoMessage() {}
+
+func (x *MethodOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_simple_options_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MethodOptions.ProtoReflect.Descriptor instead.
+func (*MethodOptions) Descriptor() ([]byte, []int) {
+	return file_simple_options_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MethodOptions) GetTransactional() bool {
+	if x != nil {
+		return x.Transactional
+	}
+	return false
+}
+
+var file_simple_options_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*ColumnOptions)(nil),
+		Field:         52101,
+		Name:          "simple.column",
+		Tag:           "bytes,52101,opt,name=column",
+		Filename:      "simple/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*TableOptions)(nil),
+		Field:         52101,
+		Name:          "simple.table",
+		Tag:           "bytes,52101,opt,name=table",
+		Filename:      "simple/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         52102,
+		Name:          "simple.model",
+		Tag:           "varint,52102,opt,name=model",
+		Filename:      "simple/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.OneofOptions)(nil),
+		ExtensionType: (*OneofOptions)(nil),
+		Field:         52101,
+		Name:          "simple.oneof",
+		Tag:           "bytes,52101,opt,name=oneof",
+		Filename:      "simple/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*MethodOptions)(nil),
+		Field:         52101,
+		Name:          "simple.method",
+		Tag:           "bytes,52101,opt,name=method",
+		Filename:      "simple/options.proto",
+	},
+}
+
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// optional simple.ColumnOptions column = 52101;
+	E_Column = &file_simple_options_proto_extTypes[0]
+)
+
+// Extension fields to descriptorpb.MessageOptions.
+var (
+	// optional simple.TableOptions table = 52101;
+	E_Table = &file_simple_options_proto_extTypes[1]
+	// Generate a gorm model, vue page and data access functions for the message.
+	//
+	// optional bool model = 52102;
+	E_Model = &file_simple_options_proto_extTypes[2]
+)
+
+// Extension fields to descriptorpb.OneofOptions.
+var (
+	// optional simple.OneofOptions oneof = 52101;
+	E_Oneof = &file_simple_options_proto_extTypes[3]
+)
+
+// Extension fields to descriptorpb.MethodOptions.
+var (
+	// optional simple.MethodOptions method = 52101;
+	E_Method = &file_simple_options_proto_extTypes[4]
+)
+
+var File_simple_options_proto protoreflect.FileDescriptor
+
+var file_simple_options_proto_rawDesc = []byte{
+	0x0a, 0x14, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x1a, 0x20,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0xef, 0x03, 0x0a, 0x0d, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x6f, 0x74, 0x5f,
+	0x6e, 0x75, 0x6c, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6e, 0x6f, 0x74, 0x4e,
+	0x75, 0x6c, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x75,
+	0x6e, 0x69, 0x71, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x69,
+	0x67, 0x6e, 0x6f, 0x72, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x69, 0x67, 0x6e,
+	0x6f, 0x72, 0x65, 0x12, 0x29, 0x0a, 0x07, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x53, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x52, 0x07, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x12, 0x1f,
+	0x0a, 0x0b, 0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x4b, 0x65, 0x79, 0x12,
+	0x33, 0x0a, 0x0b, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x0a, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x12, 0x2c, 0x0a, 0x08, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x2e,
+	0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x0e, 0x20, 0x03,
+	0x28, 0x0e, 0x32, 0x10, 0x2e, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x4f, 0x70, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08,
+	0x73, 0x6f, 0x72, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
+	0x73, 0x6f, 0x72, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6b, 0x65, 0x79, 0x77,
+	0x6f, 0x72, 0x64, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6b, 0x65, 0x79, 0x77, 0x6f,
+	0x72, 0x64, 0x22, 0x73, 0x0a, 0x08, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x28,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x73,
+	0x69, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79,
+	0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6a, 0x6f, 0x69, 0x6e,
+	0x5f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6a, 0x6f,
+	0x69, 0x6e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x66,
+	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x22, 0x9c, 0x03, 0x0a, 0x0c, 0x54, 0x61, 0x62, 0x6c,
+	0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x65, 0x6e,
+	0x67, 0x69, 0x6e, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x72, 0x73, 0x65, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x68, 0x61, 0x72, 0x73, 0x65, 0x74, 0x12, 0x1f,
+	0x0a, 0x0b, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x4b, 0x65, 0x79, 0x12,
+	0x36, 0x0a, 0x0c, 0x6b, 0x65, 0x79, 0x5f, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x4b,
+	0x65, 0x79, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x52, 0x0b, 0x6b, 0x65, 0x79, 0x53,
+	0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x22, 0x0a, 0x0a, 0x62, 0x61, 0x73, 0x65, 0x5f,
+	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x09, 0x62,
+	0x61, 0x73, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x88, 0x01, 0x01, 0x12, 0x27, 0x0a, 0x07, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x73,
+	0x69, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x07, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x73, 0x69, 0x6d, 0x70, 0x6c,
+	0x65, 0x2e, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x70, 0x61,
+	0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x6f, 0x70, 0x74, 0x69,
+	0x6d, 0x69, 0x73, 0x74, 0x69, 0x63, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x73, 0x74, 0x69, 0x63, 0x4c, 0x6f, 0x63,
+	0x6b, 0x12, 0x24, 0x0a, 0x0b, 0x73, 0x6f, 0x66, 0x74, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x48, 0x01, 0x52, 0x0a, 0x73, 0x6f, 0x66, 0x74, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x88, 0x01, 0x01, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x62, 0x61, 0x73, 0x65,
+	0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x73, 0x6f, 0x66, 0x74, 0x5f,
+	0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x22, 0x77, 0x0a, 0x05, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x2a, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x49, 0x6e, 0x64,
+	0x65, 0x78, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12,
+	0x16, 0x0a, 0x06, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x70, 0x73, 0x65, 0x72,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x75, 0x70, 0x73, 0x65, 0x72, 0x74, 0x22,
+	0x4a, 0x0a, 0x0a, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x28, 0x0a, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x12, 0x2e, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x52, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x22, 0x52, 0x0a, 0x0c, 0x4f,
+	0x6e, 0x65, 0x6f, 0x66, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x2e, 0x0a, 0x07, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x14, 0x2e, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x4f, 0x6e, 0x65, 0x6f, 0x66, 0x53,
+	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x52, 0x07, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x22,
+	0x35, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x24, 0x0a, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x61,
+	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x2a, 0x81, 0x01, 0x0a, 0x08, 0x46, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x4f, 0x70, 0x12, 0x12, 0x0a, 0x0e, 0x46, 0x49, 0x4c, 0x54, 0x45, 0x52, 0x5f, 0x4f, 0x50,
+	0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x46, 0x49, 0x4c, 0x54, 0x45,
+	0x52, 0x5f, 0x4f, 0x50, 0x5f, 0x45, 0x51, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x46, 0x49, 0x4c,
+	0x54, 0x45, 0x52, 0x5f, 0x4f, 0x50, 0x5f, 0x52, 0x41, 0x4e, 0x47, 0x45, 0x10, 0x02, 0x12, 0x10,
+	0x0a, 0x0c, 0x46, 0x49, 0x4c, 0x54, 0x45, 0x52, 0x5f, 0x4f, 0x50, 0x5f, 0x49, 0x4e, 0x10, 0x03,
+	0x12, 0x12, 0x0a, 0x0e, 0x46, 0x49, 0x4c, 0x54, 0x45, 0x52, 0x5f, 0x4f, 0x50, 0x5f, 0x4c, 0x49,
+	0x4b, 0x45, 0x10, 0x04, 0x12, 0x14, 0x0a, 0x10, 0x46, 0x49, 0x4c, 0x54, 0x45, 0x52, 0x5f, 0x4f,
+	0x50, 0x5f, 0x50, 0x52, 0x45, 0x46, 0x49, 0x58, 0x10, 0x05, 0x2a, 0x82, 0x01, 0x0a, 0x0c, 0x52,
+	0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x11, 0x0a, 0x0d, 0x52,
+	0x45, 0x4c, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00, 0x12, 0x17,
+	0x0a, 0x13, 0x52, 0x45, 0x4c, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x42, 0x45, 0x4c, 0x4f, 0x4e,
+	0x47, 0x53, 0x5f, 0x54, 0x4f, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x52, 0x45, 0x4c, 0x41, 0x54,
+	0x49, 0x4f, 0x4e, 0x5f, 0x48, 0x41, 0x53, 0x5f, 0x4f, 0x4e, 0x45, 0x10, 0x02, 0x12, 0x15, 0x0a,
+	0x11, 0x52, 0x45, 0x4c, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x48, 0x41, 0x53, 0x5f, 0x4d, 0x41,
+	0x4e, 0x59, 0x10, 0x03, 0x12, 0x19, 0x0a, 0x15, 0x52, 0x45, 0x4c, 0x41, 0x54, 0x49, 0x4f, 0x4e,
+	0x5f, 0x4d, 0x41, 0x4e, 0x59, 0x5f, 0x54, 0x4f, 0x5f, 0x4d, 0x41, 0x4e, 0x59, 0x10, 0x04, 0x2a,
+	0x75, 0x0a, 0x07, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x54,
+	0x4f, 0x52, 0x41, 0x47, 0x45, 0x5f, 0x41, 0x55, 0x54, 0x4f, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c,
+	0x53, 0x54, 0x4f, 0x52, 0x41, 0x47, 0x45, 0x5f, 0x4a, 0x53, 0x4f, 0x4e, 0x10, 0x01, 0x12, 0x17,
+	0x0a, 0x13, 0x53, 0x54, 0x4f, 0x52, 0x41, 0x47, 0x45, 0x5f, 0x43, 0x48, 0x49, 0x4c, 0x44, 0x5f,
+	0x54, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x02, 0x12, 0x17, 0x0a, 0x13, 0x53, 0x54, 0x4f, 0x52, 0x41,
+	0x47, 0x45, 0x5f, 0x46, 0x4f, 0x52, 0x45, 0x49, 0x47, 0x4e, 0x5f, 0x4b, 0x45, 0x59, 0x10, 0x03,
+	0x12, 0x14, 0x0a, 0x10, 0x53, 0x54, 0x4f, 0x52, 0x41, 0x47, 0x45, 0x5f, 0x52, 0x45, 0x4c, 0x41,
+	0x54, 0x49, 0x4f, 0x4e, 0x10, 0x04, 0x2a, 0x3a, 0x0a, 0x0a, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x15, 0x0a, 0x11, 0x50, 0x41, 0x47, 0x49, 0x4e, 0x41, 0x54, 0x49,
+	0x4f, 0x4e, 0x5f, 0x4f, 0x46, 0x46, 0x53, 0x45, 0x54, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x50,
+	0x41, 0x47, 0x49, 0x4e, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4b, 0x45, 0x59, 0x53, 0x45, 0x54,
+	0x10, 0x01, 0x2a, 0x37, 0x0a, 0x0a, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x12, 0x13, 0x0a, 0x0f, 0x49, 0x4e, 0x44, 0x45, 0x58, 0x5f, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f,
+	0x41, 0x53, 0x43, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x49, 0x4e, 0x44, 0x45, 0x58, 0x5f, 0x4f,
+	0x52, 0x44, 0x45, 0x52, 0x5f, 0x44, 0x45, 0x53, 0x43, 0x10, 0x01, 0x2a, 0x92, 0x01, 0x0a, 0x0b,
+	0x4b, 0x65, 0x79, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x15, 0x0a, 0x11, 0x4b,
+	0x45, 0x59, 0x5f, 0x53, 0x54, 0x52, 0x41, 0x54, 0x45, 0x47, 0x59, 0x5f, 0x41, 0x55, 0x54, 0x4f,
+	0x10, 0x00, 0x12, 0x1f, 0x0a, 0x1b, 0x4b, 0x45, 0x59, 0x5f, 0x53, 0x54, 0x52, 0x41, 0x54, 0x45,
+	0x47, 0x59, 0x5f, 0x41, 0x55, 0x54, 0x4f, 0x5f, 0x49, 0x4e, 0x43, 0x52, 0x45, 0x4d, 0x45, 0x4e,
+	0x54, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x4b, 0x45, 0x59, 0x5f, 0x53, 0x54, 0x52, 0x41, 0x54,
+	0x45, 0x47, 0x59, 0x5f, 0x55, 0x55, 0x49, 0x44, 0x5f, 0x56, 0x37, 0x10, 0x02, 0x12, 0x1a, 0x0a,
+	0x16, 0x4b, 0x45, 0x59, 0x5f, 0x53, 0x54, 0x52, 0x41, 0x54, 0x45, 0x47, 0x59, 0x5f, 0x53, 0x4e,
+	0x4f, 0x57, 0x46, 0x4c, 0x41, 0x4b, 0x45, 0x10, 0x03, 0x12, 0x15, 0x0a, 0x11, 0x4b, 0x45, 0x59,
+	0x5f, 0x53, 0x54, 0x52, 0x41, 0x54, 0x45, 0x47, 0x59, 0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x04,
+	0x2a, 0x41, 0x0a, 0x0c, 0x4f, 0x6e, 0x65, 0x6f, 0x66, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x12, 0x19, 0x0a, 0x15, 0x4f, 0x4e, 0x45, 0x4f, 0x46, 0x5f, 0x53, 0x54, 0x4f, 0x52, 0x41, 0x47,
+	0x45, 0x5f, 0x43, 0x4f, 0x4c, 0x55, 0x4d, 0x4e, 0x53, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x4f,
+	0x4e, 0x45, 0x4f, 0x46, 0x5f, 0x53, 0x54, 0x4f, 0x52, 0x41, 0x47, 0x45, 0x5f, 0x4a, 0x53, 0x4f,
+	0x4e, 0x10, 0x01, 0x3a, 0x4e, 0x0a, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x1d, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x85, 0x97, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x43, 0x6f,
+	0x6c, 0x75, 0x6d, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x06, 0x63, 0x6f, 0x6c,
+	0x75, 0x6d, 0x6e, 0x3a, 0x4d, 0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x1f, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x85, 0x97,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x54,
+	0x61, 0x62, 0x6c, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x05, 0x74, 0x61, 0x62,
+	0x6c, 0x65, 0x3a, 0x37, 0x0a, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x1f, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x86, 0x97, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x3a, 0x4b, 0x0a, 0x05, 0x6f,
+	0x6e, 0x65, 0x6f, 0x66, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4f, 0x6e, 0x65, 0x6f, 0x66, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x85, 0x97, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x69,
+	0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x4f, 0x6e, 0x65, 0x6f, 0x66, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x52, 0x05, 0x6f, 0x6e, 0x65, 0x6f, 0x66, 0x3a, 0x4f, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68,
+	0x6f, 0x64, 0x12, 0x1e, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x85, 0x97, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73, 0x69, 0x6d,
+	0x70, 0x6c, 0x65, 0x2e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x42, 0x33, 0x5a, 0x31, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x77, 0x77, 0x65, 0x6e, 0x67, 0x67, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65,
+	0x2f, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x3b, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_simple_options_proto_rawDescOnce sync.Once
+	file_simple_options_proto_rawDescData = file_simple_options_proto_rawDesc
+)
+
+func file_simple_options_proto_rawDescGZIP() []byte {
+	file_simple_options_proto_rawDescOnce.Do(func() {
+		file_simple_options_proto_rawDescData = protoimpl.X.CompressGZIP(file_simple_options_proto_rawDescData)
+	})
+	return file_simple_options_proto_rawDescData
+}
+
+var file_simple_options_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
+var file_simple_options_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_simple_options_proto_goTypes = []interface{}{
+	(FilterOp)(0),                       // 0: simple.FilterOp
+	(RelationType)(0),                   // 1: simple.RelationType
+	(Storage)(0),                        // 2: simple.Storage
+	(Pagination)(0),                     // 3: simple.Pagination
+	(IndexOrder)(0),                     // 4: simple.IndexOrder
+	(KeyStrategy)(0),                    // 5: simple.KeyStrategy
+	(OneofStorage)(0),                   // 6: simple.OneofStorage
+	(*ColumnOptions)(nil),               // 7: simple.ColumnOptions
+	(*Relation)(nil),                    // 8: simple.Relation
+	(*TableOptions)(nil),                // 9: simple.TableOptions
+	(*Index)(nil),                       // 10: simple.Index
+	(*IndexField)(nil),                  // 11: simple.IndexField
+	(*OneofOptions)(nil),                // 12: simple.OneofOptions
+	(*MethodOptions)(nil),               // 13: simple.MethodOptions
+	(*descriptorpb.FieldOptions)(nil),   // 14: google.protobuf.FieldOptions
+	(*descriptorpb.MessageOptions)(nil), // 15: google.protobuf.MessageOptions
+	(*descriptorpb.OneofOptions)(nil),   // 16: google.protobuf.OneofOptions
+	(*descriptorpb.MethodOptions)(nil),  // 17: google.protobuf.MethodOptions
+}
+var file_simple_options_proto_depIdxs = []int32{
+	2,  // 0: simple.ColumnOptions.storage:type_name -> simple.Storage
+	4,  // 1: simple.ColumnOptions.index_order:type_name -> simple.IndexOrder
+	8,  // 2: simple.ColumnOptions.relation:type_name -> simple.Relation
+	0,  // 3: simple.ColumnOptions.filter:type_name -> simple.FilterOp
+	1,  // 4: simple.Relation.type:type_name -> simple.RelationType
+	5,  // 5: simple.TableOptions.key_strategy:type_name -> simple.KeyStrategy
+	10, // 6: simple.TableOptions.indexes:type_name -> simple.Index
+	3,  // 7: simple.TableOptions.pagination:type_name -> simple.Pagination
+	11, // 8: simple.Index.fields:type_name -> simple.IndexField
+	4,  // 9: simple.IndexField.order:type_name -> simple.IndexOrder
+	6,  // 10: simple.OneofOptions.storage:type_name -> simple.OneofStorage
+	14, // 11: simple.column:extendee -> google.protobuf.FieldOptions
+	15, // 12: simple.table:extendee -> google.protobuf.MessageOptions
+	15, // 13: simple.model:extendee -> google.protobuf.MessageOptions
+	16, // 14: simple.oneof:extendee -> google.protobuf.OneofOptions
+	17, // 15: simple.method:extendee -> google.protobuf.MethodOptions
+	7,  // 16: simple.column:type_name -> simple.ColumnOptions
+	9,  // 17: simple.table:type_name -> simple.TableOptions
+	12, // 18: simple.oneof:type_name -> simple.OneofOptions
+	13, // 19: simple.method:type_name -> simple.MethodOptions
+	20, // [20:20] is the sub-list for method output_type
+	20, // [20:20] is the sub-list for method input_type
+	16, // [16:20] is the sub-list for extension type_name
+	11, // [11:16] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_simple_options_proto_init() }
+func file_simple_options_proto_init() {
+	if File_simple_options_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_simple_options_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ColumnOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_simple_options_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Relation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_simple_options_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TableOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_simple_options_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Index); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_simple_options_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IndexField); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_simple_options_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OneofOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_simple_options_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MethodOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_simple_options_proto_msgTypes[2].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_simple_options_proto_rawDesc,
+			NumEnums:      7,
+			NumMessages:   7,
+			NumExtensions: 5,
+			NumServices:   0,
+		},
+		GoTypes:           file_simple_options_proto_goTypes,
+		DependencyIndexes: file_simple_options_proto_depIdxs,
+		EnumInfos:         file_simple_options_proto_enumTypes,
+		MessageInfos:      file_simple_options_proto_msgTypes,
+		ExtensionInfos:    file_simple_options_proto_extTypes,
+	}.Build()
+	File_simple_options_proto = out.File
+	file_simple_options_proto_rawDesc = nil
+	file_simple_options_proto_goTypes = nil
+	file_simple_options_proto_depIdxs = nil
+}
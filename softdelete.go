@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// hasSoftDelete reports whether the model of message is soft deleted through
+// the DeletedAt of the base model, which is the default.
+func hasSoftDelete(message *protogen.Message) (bool, error) {
+	opts := tableOptions(message)
+	if !hasBaseModel(message) {
+		if opts.GetSoftDelete() {
+			return false, fmt.Errorf("message %s: soft_delete requires the base model", message.Desc.FullName())
+		}
+		return false, nil
+	}
+	return opts == nil || opts.SoftDelete == nil || *opts.SoftDelete, nil
+}
+
+// softDeleteMethods returns the repository methods of the soft deleted model
+// modelName handling the deleted rows.
+func softDeleteMethods(g *protogen.GeneratedFile, file *protogen.File, modelName, repo string) []repositoryMethod {
+	ctx := g.QualifiedGoIdent(contextPackage.Ident("Context"))
+	pageInfo := g.QualifiedGoIdent(commonPackage(file).Ident("PageInfo"))
+	return []repositoryMethod{{
+		name:     "Restore",
+		doc:      "恢复软删除的记录",
+		params:   fmt.Sprintf("ctx %s, a %s", ctx, modelName),
+		results:  "error",
+		body:     `return r.db.WithContext(ctx).Unscoped().Model(&a).Update("deleted_at", nil).Error`,
+		args:     "ctx, a",
+		funcName: "Restore" + modelName,
+	}, {
+		name:     "HardDelete",
+		doc:      "永久删除，包括已软删除的记录",
+		params:   fmt.Sprintf("ctx %s, a %s", ctx, modelName),
+		results:  "error",
+		body:     "return r.db.WithContext(ctx).Unscoped().Delete(&a).Error",
+		args:     "ctx, a",
+		funcName: "HardDelete" + modelName,
+	}, {
+		name:     "GetListWithDeleted",
+		doc:      "分页查询，包括已软删除的记录",
+		params:   fmt.Sprintf("ctx %s, info %s, filter *%sFilter", ctx, pageInfo, modelName),
+		results:  fmt.Sprintf("(list []%s, total int64, err error)", modelName),
+		body:     fmt.Sprintf("return (&%s{db: r.db.Unscoped()}).GetList(ctx, info, filter)", repo),
+		args:     "ctx, info, filter",
+		funcName: "Get" + modelName + "ListWithDeleted",
+	}}
+}
+
+// softDeleteMethodBody returns the statements implementing method with the
+// Restore and Purge conventions for the service model, using the model
+// repository repo. It returns "" when the method follows neither.
+func softDeleteMethodBody(g *protogen.GeneratedFile, file *protogen.File, model *protogen.Message, key modelKey, method *protogen.Method, repo string) (string, error) {
+	name := upperFirstLatter(method.GoName)
+	var call, errorCode string
+	switch {
+	case strings.HasPrefix(name, "Restore"):
+		call, errorCode = "Restore", "EnumCode_UpdateError"
+	case strings.HasPrefix(name, "Purge"):
+		call, errorCode = "HardDelete", "EnumCode_DeleteError"
+	default:
+		return "", nil
+	}
+	softDelete, err := hasSoftDelete(model)
+	if err != nil {
+		return "", err
+	}
+	if !softDelete {
+		if call == "Restore" {
+			return "", fmt.Errorf("method %s: %s requires the soft delete of %s", method.Desc.FullName(), name, model.Desc.FullName())
+		}
+		// Delete already removes the rows.
+		call = "Delete"
+	}
+	commonPath := commonPackage(file)
+	literal := key.literal(g, modelPackage(file).importPath.Ident(modelName(model)), "args")
+	return fmt.Sprintf(`if err = %s.%s(ctx, %s);err == nil{
+				reply.Code = %s
+			}else{
+				reply.Code = %s
+			}
+`, repo, call, literal, g.QualifiedGoIdent(commonPath.Ident("EnumCode_Success")), g.QualifiedGoIdent(commonPath.Ident(errorCode))), nil
+}
@@ -0,0 +1,469 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/jinzhu/inflection"
+	"github.com/wwengg/protoc-gen-simple/simple"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+var (
+	dialectFlag = flag.String("dialect", "mysql", "SQL dialect of the sql generator: mysql, postgres or sqlite")
+	sqlDirFlag  = flag.String("sql_dir", "", "output directory of the sql files (default: <proto dir>/sql)")
+)
+
+func init() {
+	registerGenerator(&generator{
+		name:        "sql",
+		description: "CREATE TABLE statements for every model, and a migration with the previous parameter (*.sql)",
+		generate:    generateSQLFiles,
+	})
+}
+
+// sqlTable is the schema of a model table, with the column types written as
+// in the gorm tags.
+type sqlTable struct {
+	name       string
+	columns    []sqlColumn
+	primaryKey []string
+	indexes    []sqlIndex
+	// options are the MySQL table options, such as ENGINE=InnoDB.
+	options string
+}
+
+type sqlColumn struct {
+	name          string
+	sqlType       string
+	notNull       bool
+	defaultValue  string
+	autoIncrement bool
+	comment       string
+}
+
+type sqlIndex struct {
+	name    string
+	unique  bool
+	columns []string
+	desc    []bool
+}
+
+// sqlDialect renders the statements of one SQL dialect.
+type sqlDialect struct {
+	name  string
+	quote string
+	// types translates the column types of the gorm tags, which are written
+	// for MySQL. Keys are either a full type or a type without arguments, in
+	// which case %s in the value stands for the arguments.
+	types map[string]string
+}
+
+var sqlDialects = map[string]*sqlDialect{
+	"mysql": {name: "mysql", quote: "`"},
+	"postgres": {name: "postgres", quote: `"`, types: map[string]string{
+		"tinyint(1)":      "boolean",
+		"int":             "integer",
+		"int unsigned":    "bigint",
+		"bigint unsigned": "numeric(20)",
+		"float":           "real",
+		"double":          "double precision",
+		"blob":            "bytea",
+		"json":            "jsonb",
+		"datetime":        "timestamp%s",
+	}},
+	"sqlite": {name: "sqlite", quote: `"`, types: map[string]string{
+		"tinyint(1)":      "numeric",
+		"int":             "integer",
+		"int unsigned":    "integer",
+		"bigint":          "integer",
+		"bigint unsigned": "integer",
+		"float":           "real",
+		"double":          "real",
+		"varchar":         "text",
+		"json":            "text",
+		"datetime":        "datetime",
+	}},
+}
+
+// currentDialect returns the dialect selected by the dialect parameter.
+func currentDialect() (*sqlDialect, error) {
+	d, ok := sqlDialects[*dialectFlag]
+	if !ok {
+		return nil, fmt.Errorf("unknown dialect %q, want mysql, postgres or sqlite", *dialectFlag)
+	}
+	return d, nil
+}
+
+func generateSQLFiles(gen *protogen.Plugin, file *protogen.File) error {
+	d, err := currentDialect()
+	if err != nil {
+		return err
+	}
+	dir := *sqlDirFlag
+	if dir == "" {
+		dir = path.Join(path.Dir(file.GeneratedFilenamePrefix), "sql")
+	}
+	base := path.Base(file.GeneratedFilenamePrefix)
+	g := gen.NewGeneratedFile(path.Join(dir, base+".sql"), file.GoImportPath)
+	tables, err := fileTables(g, file)
+	if err != nil {
+		return err
+	}
+	if len(tables) == 0 {
+		g.Skip()
+		return nil
+	}
+	generateSQLHeader(g, gen, file, d)
+	for _, t := range tables {
+		for _, stmt := range d.createTable(t) {
+			g.P(stmt)
+		}
+		g.P()
+	}
+	return generateMigration(gen, file, d, path.Join(dir, "migrations"), tables)
+}
+
+func generateSQLHeader(g *protogen.GeneratedFile, gen *protogen.Plugin, file *protogen.File, d *sqlDialect) {
+	g.P("-- Code generated by protoc-gen-simple. DO NOT EDIT.")
+	g.P("-- versions:")
+	g.P("-- - protoc-gen-simple v", version)
+	g.P("-- - protoc          ", protocVersion(gen))
+	g.P("-- source: ", file.Desc.Path())
+	g.P("-- dialect: ", d.name)
+	g.P()
+}
+
+// fileTables returns the tables of the models declared in file, including
+// the join tables of their many-to-many relations.
+func fileTables(g *protogen.GeneratedFile, file *protogen.File) ([]sqlTable, error) {
+	var tables []sqlTable
+	for _, message := range file.Messages {
+		if !isModel(message) {
+			continue
+		}
+		t, err := modelTables(g, message)
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, t...)
+	}
+	return tables, nil
+}
+
+// tableName returns the table name of the model of message, following the
+// default gorm naming strategy unless the table option names it.
+func tableName(message *protogen.Message) string {
+	if name := tableOptions(message).GetName(); name != "" {
+		return name
+	}
+	return inflection.Plural(ToSnakeCase(modelName(message)))
+}
+
+// modelTables returns the table of the model of message followed by the join
+// tables of its many-to-many relations.
+func modelTables(g *protogen.GeneratedFile, message *protogen.Message) ([]sqlTable, error) {
+	key, err := primaryKey(message)
+	if err != nil {
+		return nil, err
+	}
+	t := sqlTable{name: tableName(message)}
+	opts := tableOptions(message)
+	var tableOpts []string
+	if opts.GetEngine() != "" {
+		tableOpts = append(tableOpts, "ENGINE="+opts.GetEngine())
+	}
+	if opts.GetCharset() != "" {
+		tableOpts = append(tableOpts, "DEFAULT CHARSET="+opts.GetCharset())
+	}
+	t.options = strings.Join(tableOpts, " ")
+	if hasBaseModel(message) {
+		t.columns = append(t.columns,
+			sqlColumn{name: "id", sqlType: "bigint", notNull: true, autoIncrement: true},
+			sqlColumn{name: "created_at", sqlType: "datetime(3)"},
+			sqlColumn{name: "updated_at", sqlType: "datetime(3)"},
+			sqlColumn{name: "deleted_at", sqlType: "datetime(3)"},
+		)
+		t.primaryKey = []string{"id"}
+		t.addIndex("idx_"+t.name+"_deleted_at", false, "deleted_at")
+	} else {
+		for _, field := range key.fields {
+			t.primaryKey = append(t.primaryKey, columnName(field))
+		}
+	}
+	var joinTables []sqlTable
+	var oneofs []*protogen.Oneof
+	for _, field := range modelFields(message) {
+		if isOneofField(field) {
+			if len(oneofs) > 0 && oneofs[len(oneofs)-1] == field.Oneof {
+				continue
+			}
+			oneofs = append(oneofs, field.Oneof)
+			if err := t.addOneof(g, field.Oneof); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		storage, err := fieldStorage(field)
+		if err != nil {
+			return nil, err
+		}
+		if storage != simple.Storage_STORAGE_RELATION {
+			if err := t.addField(g, field, storage, key); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		switch fieldRelation(field) {
+		case simple.RelationType_RELATION_BELONGS_TO:
+			fk := relationForeignKey(message, field)
+			if messageField(message, fk) != nil {
+				continue
+			}
+			_, ct, err := referencedKey(g, field)
+			if err != nil {
+				return nil, err
+			}
+			column := ToSnakeCase(fk)
+			t.columns = append(t.columns, sqlColumn{name: column, sqlType: ct.sqlType})
+			t.addIndex("idx_"+t.name+"_"+column, false, column)
+		case simple.RelationType_RELATION_MANY_TO_MANY:
+			join, err := manyToManyTable(g, message, field)
+			if err != nil {
+				return nil, err
+			}
+			joinTables = append(joinTables, join)
+		}
+	}
+	indexes, err := modelIndexes(message)
+	if err != nil {
+		return nil, err
+	}
+	for _, index := range indexes {
+		i := sqlIndex{name: index.name, unique: index.unique, desc: index.desc}
+		for _, field := range index.fields {
+			i.columns = append(i.columns, columnName(field))
+		}
+		t.indexes = append(t.indexes, i)
+	}
+	return append([]sqlTable{t}, joinTables...), nil
+}
+
+func (t *sqlTable) addIndex(name string, unique bool, column string) {
+	t.indexes = append(t.indexes, sqlIndex{name: name, unique: unique, columns: []string{column}, desc: []bool{false}})
+}
+
+// addField adds the column storing field and its single column indexes.
+func (t *sqlTable) addField(g *protogen.GeneratedFile, field *protogen.Field, storage simple.Storage, key modelKey) error {
+	ct, err := fieldColumnType(g, field, storage)
+	if err != nil {
+		return err
+	}
+	opts := columnOptions(field)
+	c := sqlColumn{
+		name:         columnName(field),
+		sqlType:      ct.sqlType,
+		notNull:      opts.GetNotNull(),
+		defaultValue: opts.GetDefault(),
+		comment:      columnComment(field),
+	}
+	if isKeyField(field) {
+		c.notNull = true
+		c.autoIncrement = key.strategy == simple.KeyStrategy_KEY_STRATEGY_AUTO_INCREMENT
+	}
+	t.columns = append(t.columns, c)
+	if opts.GetUnique() {
+		t.addIndex("uni_"+t.name+"_"+c.name, true, c.name)
+	}
+	if opts.GetIndex() {
+		t.addIndex("idx_"+t.name+"_"+c.name, false, c.name)
+		t.indexes[len(t.indexes)-1].desc[0] = opts.GetIndexOrder() == simple.IndexOrder_INDEX_ORDER_DESC
+	}
+	return nil
+}
+
+// addOneof adds the columns storing oneof.
+func (t *sqlTable) addOneof(g *protogen.GeneratedFile, oneof *protogen.Oneof) error {
+	column := oneofOptions(oneof).GetName()
+	if isJSONOneof(oneof) {
+		if column == "" {
+			column = ToSnakeCase(oneof.GoName)
+		}
+		t.columns = append(t.columns, sqlColumn{name: column, sqlType: "json"})
+		return nil
+	}
+	if column == "" {
+		column = ToSnakeCase(oneof.GoName) + "_case"
+	}
+	t.columns = append(t.columns, sqlColumn{name: column, sqlType: "varchar(64)"})
+	for _, field := range oneofFields(oneof) {
+		storage, err := fieldStorage(field)
+		if err != nil {
+			return err
+		}
+		if err := t.addField(g, field, storage, modelKey{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// manyToManyTable returns the join table of a many-to-many relation, with
+// the columns gorm names <Model><Key> for both sides.
+func manyToManyTable(g *protogen.GeneratedFile, message *protogen.Message, field *protogen.Field) (sqlTable, error) {
+	t := sqlTable{name: joinTable(message, field)}
+	for _, m := range []*protogen.Message{message, field.Message} {
+		key, ct, err := keyColumn(g, m)
+		if err != nil {
+			return t, fmt.Errorf("field %s: %v", field.Desc.FullName(), err)
+		}
+		column := ToSnakeCase(modelName(m) + key)
+		t.columns = append(t.columns, sqlColumn{name: column, sqlType: ct.sqlType, notNull: true})
+		t.primaryKey = append(t.primaryKey, column)
+	}
+	return t, nil
+}
+
+func (d *sqlDialect) ident(name string) string {
+	return d.quote + name + d.quote
+}
+
+func (d *sqlDialect) idents(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = d.ident(name)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// columnType translates a column type of the gorm tags to the dialect.
+func (d *sqlDialect) columnType(sqlType string) string {
+	if t, ok := d.types[sqlType]; ok {
+		return t
+	}
+	base, args := splitSQLType(sqlType)
+	if t, ok := d.types[base]; ok {
+		if strings.Contains(t, "%s") {
+			return fmt.Sprintf(t, args)
+		}
+		return t
+	}
+	return sqlType
+}
+
+// inlineKey reports whether the primary key of t is declared with its column,
+// which SQLite requires for auto increment keys.
+func (d *sqlDialect) inlineKey(t sqlTable) bool {
+	return d.name == "sqlite" && len(t.primaryKey) == 1 && t.column(t.primaryKey[0]).autoIncrement
+}
+
+func (t sqlTable) column(name string) sqlColumn {
+	for _, c := range t.columns {
+		if c.name == name {
+			return c
+		}
+	}
+	return sqlColumn{}
+}
+
+// columnDef returns the definition of c in CREATE TABLE and ADD COLUMN.
+func (d *sqlDialect) columnDef(t sqlTable, c sqlColumn) string {
+	def := d.ident(c.name) + " " + d.columnType(c.sqlType)
+	switch {
+	case c.autoIncrement && d.name == "sqlite":
+		def = d.ident(c.name) + " integer"
+		if d.inlineKey(t) {
+			return def + " PRIMARY KEY AUTOINCREMENT"
+		}
+	case c.autoIncrement && d.name == "postgres":
+		def += " GENERATED BY DEFAULT AS IDENTITY"
+	}
+	if c.notNull {
+		def += " NOT NULL"
+	}
+	if c.defaultValue != "" {
+		def += " DEFAULT " + c.defaultValue
+	}
+	if c.autoIncrement && d.name == "mysql" {
+		def += " AUTO_INCREMENT"
+	}
+	if c.comment != "" && d.name == "mysql" {
+		def += " COMMENT " + sqlString(c.comment)
+	}
+	return def
+}
+
+func sqlString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// createTable returns the statements creating t and its indexes.
+func (d *sqlDialect) createTable(t sqlTable) []string {
+	var defs []string
+	for _, c := range t.columns {
+		defs = append(defs, "  "+d.columnDef(t, c))
+	}
+	if len(t.primaryKey) > 0 && !d.inlineKey(t) {
+		defs = append(defs, "  PRIMARY KEY ("+d.idents(t.primaryKey)+")")
+	}
+	create := "CREATE TABLE " + d.ident(t.name) + " (\n" + strings.Join(defs, ",\n") + "\n)"
+	if t.options != "" && d.name == "mysql" {
+		create += " " + t.options
+	}
+	stmts := []string{create + ";"}
+	for _, c := range t.columns {
+		if stmt := d.commentOn(t, c); stmt != "" {
+			stmts = append(stmts, stmt)
+		}
+	}
+	for _, index := range t.indexes {
+		stmts = append(stmts, d.createIndex(t, index))
+	}
+	return stmts
+}
+
+// commentOn returns the statement setting the comment of c in dialects
+// without inline column comments.
+func (d *sqlDialect) commentOn(t sqlTable, c sqlColumn) string {
+	if d.name != "postgres" || c.comment == "" {
+		return ""
+	}
+	return fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s;", d.ident(t.name), d.ident(c.name), sqlString(c.comment))
+}
+
+func (d *sqlDialect) createIndex(t sqlTable, index sqlIndex) string {
+	var columns []string
+	for i, c := range index.columns {
+		if index.desc[i] {
+			c = d.ident(c) + " DESC"
+		} else {
+			c = d.ident(c)
+		}
+		columns = append(columns, c)
+	}
+	create := "CREATE INDEX "
+	if index.unique {
+		create = "CREATE UNIQUE INDEX "
+	}
+	return create + d.ident(index.name) + " ON " + d.ident(t.name) + " (" + strings.Join(columns, ", ") + ");"
+}
+
+func (d *sqlDialect) dropIndex(t sqlTable, index sqlIndex) string {
+	if d.name == "mysql" {
+		return "DROP INDEX " + d.ident(index.name) + " ON " + d.ident(t.name) + ";"
+	}
+	return "DROP INDEX " + d.ident(index.name) + ";"
+}
+
+// sortedTableNames returns the names of tables in order.
+func sortedTableNames(tables map[string]sqlTable) []string {
+	names := make([]string, 0, len(tables))
+	for name := range tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,246 @@
+// Code generated by protoc-gen-simple. DO NOT EDIT.
+// versions:
+// - protoc-gen-simple v0.0.7
+// - protoc          (unknown)
+// source: batch.proto
+
+package model
+
+import (
+	context "context"
+	batch "example.com/app/pb/batch"
+	fmt "fmt"
+	global "github.com/wwengg/simple/core/global"
+	gorm "gorm.io/gorm"
+	clause "gorm.io/gorm/clause"
+	strings "strings"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = time.Now
+
+// Tag Model
+type Tag struct {
+	Code  string `json:"code" gorm:"column:code;type:varchar(255);primaryKey;"`
+	Label string `json:"label" gorm:"column:label;type:varchar(255);"`
+}
+
+func (model *Tag) Proto() *batch.TagModel {
+	proto := &batch.TagModel{
+		Code:  model.Code,
+		Label: model.Label,
+	}
+	return proto
+}
+
+func TagProtoToModel(proto *batch.TagModel) *Tag {
+	model := Tag{
+		Code:  proto.Code,
+		Label: proto.Label,
+	}
+	return &model
+}
+
+// TagFilter 分页查询条件，零值的条件不生效
+type TagFilter struct {
+	// Sort 排序字段，"-" 前缀表示降序，可选 code
+	Sort []string
+}
+
+var tagSortColumns = map[string]string{
+	"code": "code",
+}
+
+// Scope adds the conditions and the order of f to db. An unknown sort
+// field is reported as an error of db.
+func (f *TagFilter) Scope(db *gorm.DB) *gorm.DB {
+	if f == nil {
+		return db
+	}
+	for _, s := range f.Sort {
+		column, ok := tagSortColumns[strings.TrimPrefix(s, "-")]
+		if !ok {
+			db.AddError(fmt.Errorf("TagFilter: cannot sort by %q", s))
+			return db
+		}
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: strings.HasPrefix(s, "-")})
+	}
+	return db
+}
+
+// TagRepository 数据访问接口
+type TagRepository interface {
+	// Create 创建
+	Create(ctx context.Context, a *Tag) error
+	// Delete 删除，记录不存在时返回 gorm.ErrRecordNotFound
+	Delete(ctx context.Context, a Tag) error
+	// Update 修改
+	Update(ctx context.Context, a *Tag) error
+	// UpdateFields 只修改 paths（FieldMask 的 proto 字段名）对应的列，
+	// 未知或只读的字段返回错误
+	UpdateFields(ctx context.Context, a *Tag, paths []string) error
+	// Get 查询
+	Get(ctx context.Context, code string) (Tag, error)
+	// GetList 分页查询，filter 为 nil 时不过滤
+	GetList(ctx context.Context, info batch.PageInfo, filter *TagFilter) ([]Tag, int64, error)
+	// Transaction 在事务中运行 fn，fn 返回 nil 时提交；已在事务中时使用保存点
+	Transaction(ctx context.Context, fn func(repo TagRepository) error) error
+	// BatchCreate 批量创建，每条 INSERT 语句最多 batchSize 行
+	BatchCreate(ctx context.Context, list []*Tag, batchSize int) error
+	// BatchUpdate 在一个事务中逐条 Update
+	BatchUpdate(ctx context.Context, list []*Tag) error
+	// BatchDelete 按主键批量删除
+	BatchDelete(ctx context.Context, ids []string) error
+	// GetByIds 按主键批量查询
+	GetByIds(ctx context.Context, ids []string) (list []Tag, err error)
+}
+
+// NewTagRepository returns the TagRepository running on db, which
+// may be a transaction.
+func NewTagRepository(db *gorm.DB) TagRepository {
+	return &tagRepository{db: db}
+}
+
+type tagRepository struct {
+	db *gorm.DB
+}
+
+func (r *tagRepository) Create(ctx context.Context, a *Tag) error {
+	return r.db.WithContext(ctx).Create(a).Error
+}
+
+func (r *tagRepository) Delete(ctx context.Context, a Tag) error {
+	result := r.db.WithContext(ctx).Delete(&a)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return result.Error
+}
+
+func (r *tagRepository) Update(ctx context.Context, a *Tag) error {
+	return r.db.WithContext(ctx).Save(a).Error
+}
+
+func (r *tagRepository) Get(ctx context.Context, code string) (result Tag, err error) {
+	err = r.db.WithContext(ctx).Where("code = ?", code).First(&result).Error
+	return
+}
+
+func (r *tagRepository) GetList(ctx context.Context, info batch.PageInfo, filter *TagFilter) (list []Tag, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := filter.Scope(r.db.WithContext(ctx).Model(&Tag{}))
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Limit(int(limit)).Offset(int(offset)).Find(&list).Error
+	return
+}
+
+func (r *tagRepository) Transaction(ctx context.Context, fn func(repo TagRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewTagRepository(tx))
+	})
+}
+
+// DB returns the database the repository runs on, which may be a
+// transaction.
+func (r *tagRepository) DB() *gorm.DB {
+	return r.db
+}
+
+// tagUpdateColumns maps the field mask paths of UpdateFields to the columns they
+// update.
+var tagUpdateColumns = map[string][]string{
+	"label": {"label"},
+}
+
+func (r *tagRepository) UpdateFields(ctx context.Context, a *Tag, paths []string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("UpdateTagFields: empty field mask")
+	}
+	var columns []string
+	for _, path := range paths {
+		c, ok := tagUpdateColumns[path]
+		if !ok {
+			return fmt.Errorf("UpdateTagFields: unknown or read-only field %q", path)
+		}
+		columns = append(columns, c...)
+	}
+	return r.db.WithContext(ctx).Model(a).Select(columns).Updates(a).Error
+}
+
+func (r *tagRepository) BatchCreate(ctx context.Context, list []*Tag, batchSize int) error {
+	return r.db.WithContext(ctx).CreateInBatches(list, batchSize).Error
+}
+
+func (r *tagRepository) BatchUpdate(ctx context.Context, list []*Tag) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, a := range list {
+			if err := NewTagRepository(tx).Update(ctx, a); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *tagRepository) BatchDelete(ctx context.Context, ids []string) error {
+	return r.db.WithContext(ctx).Where("code IN ?", ids).Delete(&Tag{}).Error
+}
+
+func (r *tagRepository) GetByIds(ctx context.Context, ids []string) (list []Tag, err error) {
+	err = r.db.WithContext(ctx).Where("code IN ?", ids).Find(&list).Error
+	return
+}
+
+// CreateTag Func 创建
+func CreateTag(ctx context.Context, a Tag) (err error) {
+	return NewTagRepository(global.DB_).Create(ctx, &a)
+}
+
+// DeleteTag  删除
+func DeleteTag(ctx context.Context, a Tag) (err error) {
+	return NewTagRepository(global.DB_).Delete(ctx, a)
+}
+
+// UpdateTag 修改
+func UpdateTag(ctx context.Context, a *Tag) (err error) {
+	return NewTagRepository(global.DB_).Update(ctx, a)
+}
+
+// UpdateTagFields 按 FieldMask 部分修改
+func UpdateTagFields(ctx context.Context, a *Tag, paths []string) (err error) {
+	return NewTagRepository(global.DB_).UpdateFields(ctx, a, paths)
+}
+
+// GetTag 查询
+func GetTag(ctx context.Context, code string) (result Tag, err error) {
+	return NewTagRepository(global.DB_).Get(ctx, code)
+}
+
+// GetTagList 分页查询
+func GetTagList(ctx context.Context, info batch.PageInfo, filter *TagFilter) (list []Tag, total int64, err error) {
+	return NewTagRepository(global.DB_).GetList(ctx, info, filter)
+}
+
+// BatchCreateTag 批量创建，每条 INSERT 语句最多 batchSize 行
+func BatchCreateTag(ctx context.Context, list []*Tag, batchSize int) error {
+	return NewTagRepository(global.DB_).BatchCreate(ctx, list, batchSize)
+}
+
+// BatchUpdateTag 在一个事务中逐条 Update
+func BatchUpdateTag(ctx context.Context, list []*Tag) error {
+	return NewTagRepository(global.DB_).BatchUpdate(ctx, list)
+}
+
+// BatchDeleteTag 按主键批量删除
+func BatchDeleteTag(ctx context.Context, ids []string) error {
+	return NewTagRepository(global.DB_).BatchDelete(ctx, ids)
+}
+
+// GetTagByIds 按主键批量查询
+func GetTagByIds(ctx context.Context, ids []string) (list []Tag, err error) {
+	return NewTagRepository(global.DB_).GetByIds(ctx, ids)
+}
@@ -0,0 +1,23 @@
+-- Code generated by protoc-gen-simple. DO NOT EDIT.
+-- versions:
+-- - protoc-gen-simple v0.0.7
+-- - protoc          (unknown)
+-- source: batch.proto
+-- dialect: mysql
+
+CREATE TABLE `items` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `created_at` datetime(3),
+  `updated_at` datetime(3),
+  `deleted_at` datetime(3),
+  `name` varchar(255),
+  PRIMARY KEY (`id`)
+);
+CREATE INDEX `idx_items_deleted_at` ON `items` (`deleted_at`);
+
+CREATE TABLE `tags` (
+  `code` varchar(255) NOT NULL,
+  `label` varchar(255),
+  PRIMARY KEY (`code`)
+);
+
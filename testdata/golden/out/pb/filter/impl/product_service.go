@@ -0,0 +1,112 @@
+// Code generated by protoc-gen-simple. DO NOT EDIT.
+// versions:
+// - protoc-gen-simple v0.0.7
+// - protoc          (unknown)
+// source: filter.proto
+
+package impl
+
+import (
+	context "context"
+	filter "example.com/app/pb/filter"
+	model "example.com/app/pb/filter/model"
+	global "github.com/wwengg/simple/core/global"
+	gorm "gorm.io/gorm"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = context.TODO
+
+type Product struct {
+	// Repository is the data access of the service, by default
+	// model.NewProductRepository(s.db()).
+	Repository model.ProductRepository
+	// DB is the database of the service, by default global.DB_.
+	DB *gorm.DB
+}
+
+func (s *Product) db() *gorm.DB {
+	if s.DB != nil {
+		return s.DB
+	}
+	return global.DB_
+}
+
+func (s *Product) repository() model.ProductRepository {
+	if s.Repository != nil {
+		return s.Repository
+	}
+	return model.NewProductRepository(s.db())
+}
+
+// FindProductList is server rpc method as defined
+func (s *Product) FindProductList(ctx context.Context, args *filter.ProductListArgs, reply *filter.ProductListReply) (err error) {
+	*reply = filter.ProductListReply{}
+	listFilter := &model.ProductFilter{}
+	if args.Name != nil {
+		v := *args.Name
+		listFilter.Name = &v
+	}
+	listFilter.NameLike = args.GetNameLike()
+	listFilter.NamePrefix = args.GetNamePrefix()
+	if args.PriceMin != 0 {
+		v := args.PriceMin
+		listFilter.PriceMin = &v
+	}
+	if args.PriceMax != nil {
+		v := *args.PriceMax
+		listFilter.PriceMax = &v
+	}
+	if args.Status != 0 {
+		v := int32(args.Status)
+		listFilter.Status = &v
+	}
+	for _, v := range args.StatusIn {
+		listFilter.StatusIn = append(listFilter.StatusIn, int32(v))
+	}
+	if args.Active {
+		v := args.Active
+		listFilter.Active = &v
+	}
+	if args.Stock != 0 {
+		v := args.Stock
+		listFilter.Stock = &v
+	}
+	listFilter.Keyword = args.GetKeyword()
+	listFilter.Sort = args.GetSort()
+	pageInfo := args.GetPageInfo()
+	if pageInfo == nil {
+		// 未传 page_info 时查询第一页
+		pageInfo = &filter.PageInfo{Page: 1, PageSize: 10}
+	}
+	if list, total, err := s.repository().GetList(ctx, *pageInfo, listFilter); err == nil {
+		for _, v := range list {
+			reply.List = append(reply.List, v.Proto())
+		}
+		reply.Total = total
+		reply.Code = filter.EnumCode_Success
+	} else {
+		reply.Code = filter.EnumCode_FindError
+	}
+	return nil
+}
+
+// FindAllProductList is server rpc method as defined
+func (s *Product) FindAllProductList(ctx context.Context, args *filter.ListArgs, reply *filter.ProductListReply) (err error) {
+	*reply = filter.ProductListReply{}
+	pageInfo := args.GetPageInfo()
+	if pageInfo == nil {
+		// 未传 page_info 时查询第一页
+		pageInfo = &filter.PageInfo{Page: 1, PageSize: 10}
+	}
+	if list, total, err := s.repository().GetList(ctx, *pageInfo, nil); err == nil {
+		for _, v := range list {
+			reply.List = append(reply.List, v.Proto())
+		}
+		reply.Total = total
+		reply.Code = filter.EnumCode_Success
+	} else {
+		reply.Code = filter.EnumCode_FindError
+	}
+	return nil
+}
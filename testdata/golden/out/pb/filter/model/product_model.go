@@ -0,0 +1,359 @@
+// Code generated by protoc-gen-simple. DO NOT EDIT.
+// versions:
+// - protoc-gen-simple v0.0.7
+// - protoc          (unknown)
+// source: filter.proto
+
+package model
+
+import (
+	context "context"
+	filter "example.com/app/pb/filter"
+	fmt "fmt"
+	global "github.com/wwengg/simple/core/global"
+	store "github.com/wwengg/simple/core/store"
+	gorm "gorm.io/gorm"
+	clause "gorm.io/gorm/clause"
+	strings "strings"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = time.Now
+
+// Product Model
+type Product struct {
+	store.BASE_MODEL
+
+	Name   string `json:"name" gorm:"column:name;type:varchar(255);"`
+	Remark string `json:"remark" gorm:"column:remark;type:varchar(255);"`
+	Price  int32  `json:"price" gorm:"column:price;type:int;"`
+	Status int32  `json:"status" gorm:"column:status;type:int;"`
+	Active bool   `json:"active" gorm:"column:active;type:tinyint(1);"`
+	Stock  *int64 `json:"stock" gorm:"column:stock;type:bigint;"`
+}
+
+func (model *Product) Proto() *filter.ProductModel {
+	proto := &filter.ProductModel{
+		Id:        model.ID,
+		CreatedAt: model.CreatedAt.Format(time.DateTime),
+		Name:      model.Name,
+		Remark:    model.Remark,
+		Price:     model.Price,
+		Status:    filter.Status(model.Status),
+		Active:    model.Active,
+		Stock:     model.Stock,
+	}
+	return proto
+}
+
+func ProductProtoToModel(proto *filter.ProductModel) *Product {
+	model := Product{
+		Name:   proto.Name,
+		Remark: proto.Remark,
+		Price:  proto.Price,
+		Status: int32(proto.Status),
+		Active: proto.Active,
+		Stock:  proto.Stock,
+	}
+	model.ID = proto.Id
+	if t, err := time.Parse(time.DateTime, proto.CreatedAt); err == nil {
+		model.CreatedAt = t
+	}
+	return &model
+}
+
+// ProductFilter 分页查询条件，零值的条件不生效
+type ProductFilter struct {
+	Name       *string // name = ?
+	NameLike   string  // name LIKE %?%
+	NamePrefix string  // name LIKE ?%
+	PriceMin   *int32  // price >= ?
+	PriceMax   *int32  // price <= ?
+	Price      *int32  // price = ?
+	Status     *int32  // status = ?
+	StatusIn   []int32 // status IN ?
+	Active     *bool   // active = ?
+	Stock      *int64  // stock = ?
+	// Keyword 模糊匹配 name, remark
+	Keyword string
+	// Sort 排序字段，"-" 前缀表示降序，可选 id, created_at, updated_at, name, price
+	Sort []string
+}
+
+var productSortColumns = map[string]string{
+	"id":         "id",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+	"name":       "name",
+	"price":      "price",
+}
+
+// productLikeEscaper escapes the wildcards of the LIKE conditions, which are
+// matched with ESCAPE '!'.
+var productLikeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// Scope adds the conditions and the order of f to db. An unknown sort
+// field is reported as an error of db.
+func (f *ProductFilter) Scope(db *gorm.DB) *gorm.DB {
+	if f == nil {
+		return db
+	}
+	if f.Name != nil {
+		db = db.Where("name = ?", *f.Name)
+	}
+	if f.NameLike != "" {
+		db = db.Where("name LIKE ? ESCAPE '!'", "%"+productLikeEscaper.Replace(f.NameLike)+"%")
+	}
+	if f.NamePrefix != "" {
+		db = db.Where("name LIKE ? ESCAPE '!'", productLikeEscaper.Replace(f.NamePrefix)+"%")
+	}
+	if f.PriceMin != nil {
+		db = db.Where("price >= ?", *f.PriceMin)
+	}
+	if f.PriceMax != nil {
+		db = db.Where("price <= ?", *f.PriceMax)
+	}
+	if f.Price != nil {
+		db = db.Where("price = ?", *f.Price)
+	}
+	if f.Status != nil {
+		db = db.Where("status = ?", *f.Status)
+	}
+	if len(f.StatusIn) > 0 {
+		db = db.Where("status IN ?", f.StatusIn)
+	}
+	if f.Active != nil {
+		db = db.Where("active = ?", *f.Active)
+	}
+	if f.Stock != nil {
+		db = db.Where("stock = ?", *f.Stock)
+	}
+	if f.Keyword != "" {
+		keyword := "%" + productLikeEscaper.Replace(f.Keyword) + "%"
+		db = db.Where("name LIKE ? ESCAPE '!' OR remark LIKE ? ESCAPE '!'", keyword, keyword)
+	}
+	for _, s := range f.Sort {
+		column, ok := productSortColumns[strings.TrimPrefix(s, "-")]
+		if !ok {
+			db.AddError(fmt.Errorf("ProductFilter: cannot sort by %q", s))
+			return db
+		}
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: strings.HasPrefix(s, "-")})
+	}
+	return db
+}
+
+// ProductRepository 数据访问接口
+type ProductRepository interface {
+	// Create 创建
+	Create(ctx context.Context, a *Product) error
+	// Delete 删除，记录不存在时返回 gorm.ErrRecordNotFound
+	Delete(ctx context.Context, a Product) error
+	// Update 修改
+	Update(ctx context.Context, a *Product) error
+	// UpdateFields 只修改 paths（FieldMask 的 proto 字段名）对应的列，
+	// 未知或只读的字段返回错误
+	UpdateFields(ctx context.Context, a *Product, paths []string) error
+	// Get 查询
+	Get(ctx context.Context, id int64) (Product, error)
+	// GetList 分页查询，filter 为 nil 时不过滤
+	GetList(ctx context.Context, info filter.PageInfo, filter *ProductFilter) ([]Product, int64, error)
+	// Transaction 在事务中运行 fn，fn 返回 nil 时提交；已在事务中时使用保存点
+	Transaction(ctx context.Context, fn func(repo ProductRepository) error) error
+	// BatchCreate 批量创建，每条 INSERT 语句最多 batchSize 行
+	BatchCreate(ctx context.Context, list []*Product, batchSize int) error
+	// BatchUpdate 在一个事务中逐条 Update
+	BatchUpdate(ctx context.Context, list []*Product) error
+	// BatchDelete 按主键批量删除
+	BatchDelete(ctx context.Context, ids []int64) error
+	// GetByIds 按主键批量查询
+	GetByIds(ctx context.Context, ids []int64) (list []Product, err error)
+	// Restore 恢复软删除的记录
+	Restore(ctx context.Context, a Product) error
+	// HardDelete 永久删除，包括已软删除的记录
+	HardDelete(ctx context.Context, a Product) error
+	// GetListWithDeleted 分页查询，包括已软删除的记录
+	GetListWithDeleted(ctx context.Context, info filter.PageInfo, filter *ProductFilter) (list []Product, total int64, err error)
+}
+
+// NewProductRepository returns the ProductRepository running on db, which
+// may be a transaction.
+func NewProductRepository(db *gorm.DB) ProductRepository {
+	return &productRepository{db: db}
+}
+
+type productRepository struct {
+	db *gorm.DB
+}
+
+func (r *productRepository) Create(ctx context.Context, a *Product) error {
+	return r.db.WithContext(ctx).Create(a).Error
+}
+
+func (r *productRepository) Delete(ctx context.Context, a Product) error {
+	result := r.db.WithContext(ctx).Delete(&a)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return result.Error
+}
+
+func (r *productRepository) Update(ctx context.Context, a *Product) error {
+	return r.db.WithContext(ctx).Save(a).Error
+}
+
+func (r *productRepository) Get(ctx context.Context, id int64) (result Product, err error) {
+	err = r.db.WithContext(ctx).Where("id = ?", id).First(&result).Error
+	return
+}
+
+func (r *productRepository) GetList(ctx context.Context, info filter.PageInfo, filter *ProductFilter) (list []Product, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := filter.Scope(r.db.WithContext(ctx).Model(&Product{}))
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Limit(int(limit)).Offset(int(offset)).Find(&list).Error
+	return
+}
+
+func (r *productRepository) Transaction(ctx context.Context, fn func(repo ProductRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewProductRepository(tx))
+	})
+}
+
+// DB returns the database the repository runs on, which may be a
+// transaction.
+func (r *productRepository) DB() *gorm.DB {
+	return r.db
+}
+
+// productUpdateColumns maps the field mask paths of UpdateFields to the columns they
+// update.
+var productUpdateColumns = map[string][]string{
+	"name":   {"name"},
+	"remark": {"remark"},
+	"price":  {"price"},
+	"status": {"status"},
+	"active": {"active"},
+	"stock":  {"stock"},
+}
+
+func (r *productRepository) UpdateFields(ctx context.Context, a *Product, paths []string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("UpdateProductFields: empty field mask")
+	}
+	var columns []string
+	for _, path := range paths {
+		c, ok := productUpdateColumns[path]
+		if !ok {
+			return fmt.Errorf("UpdateProductFields: unknown or read-only field %q", path)
+		}
+		columns = append(columns, c...)
+	}
+	return r.db.WithContext(ctx).Model(a).Select(columns).Updates(a).Error
+}
+
+func (r *productRepository) BatchCreate(ctx context.Context, list []*Product, batchSize int) error {
+	return r.db.WithContext(ctx).CreateInBatches(list, batchSize).Error
+}
+
+func (r *productRepository) BatchUpdate(ctx context.Context, list []*Product) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, a := range list {
+			if err := NewProductRepository(tx).Update(ctx, a); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *productRepository) BatchDelete(ctx context.Context, ids []int64) error {
+	return r.db.WithContext(ctx).Where("id IN ?", ids).Delete(&Product{}).Error
+}
+
+func (r *productRepository) GetByIds(ctx context.Context, ids []int64) (list []Product, err error) {
+	err = r.db.WithContext(ctx).Where("id IN ?", ids).Find(&list).Error
+	return
+}
+
+func (r *productRepository) Restore(ctx context.Context, a Product) error {
+	return r.db.WithContext(ctx).Unscoped().Model(&a).Update("deleted_at", nil).Error
+}
+
+func (r *productRepository) HardDelete(ctx context.Context, a Product) error {
+	return r.db.WithContext(ctx).Unscoped().Delete(&a).Error
+}
+
+func (r *productRepository) GetListWithDeleted(ctx context.Context, info filter.PageInfo, filter *ProductFilter) (list []Product, total int64, err error) {
+	return (&productRepository{db: r.db.Unscoped()}).GetList(ctx, info, filter)
+}
+
+// CreateProduct Func 创建
+func CreateProduct(ctx context.Context, a Product) (err error) {
+	return NewProductRepository(global.DB_).Create(ctx, &a)
+}
+
+// DeleteProduct  删除
+func DeleteProduct(ctx context.Context, a Product) (err error) {
+	return NewProductRepository(global.DB_).Delete(ctx, a)
+}
+
+// UpdateProduct 修改
+func UpdateProduct(ctx context.Context, a *Product) (err error) {
+	return NewProductRepository(global.DB_).Update(ctx, a)
+}
+
+// UpdateProductFields 按 FieldMask 部分修改
+func UpdateProductFields(ctx context.Context, a *Product, paths []string) (err error) {
+	return NewProductRepository(global.DB_).UpdateFields(ctx, a, paths)
+}
+
+// GetProduct 查询
+func GetProduct(ctx context.Context, id int64) (result Product, err error) {
+	return NewProductRepository(global.DB_).Get(ctx, id)
+}
+
+// GetProductList 分页查询
+func GetProductList(ctx context.Context, info filter.PageInfo, filter *ProductFilter) (list []Product, total int64, err error) {
+	return NewProductRepository(global.DB_).GetList(ctx, info, filter)
+}
+
+// BatchCreateProduct 批量创建，每条 INSERT 语句最多 batchSize 行
+func BatchCreateProduct(ctx context.Context, list []*Product, batchSize int) error {
+	return NewProductRepository(global.DB_).BatchCreate(ctx, list, batchSize)
+}
+
+// BatchUpdateProduct 在一个事务中逐条 Update
+func BatchUpdateProduct(ctx context.Context, list []*Product) error {
+	return NewProductRepository(global.DB_).BatchUpdate(ctx, list)
+}
+
+// BatchDeleteProduct 按主键批量删除
+func BatchDeleteProduct(ctx context.Context, ids []int64) error {
+	return NewProductRepository(global.DB_).BatchDelete(ctx, ids)
+}
+
+// GetProductByIds 按主键批量查询
+func GetProductByIds(ctx context.Context, ids []int64) (list []Product, err error) {
+	return NewProductRepository(global.DB_).GetByIds(ctx, ids)
+}
+
+// RestoreProduct 恢复软删除的记录
+func RestoreProduct(ctx context.Context, a Product) error {
+	return NewProductRepository(global.DB_).Restore(ctx, a)
+}
+
+// HardDeleteProduct 永久删除，包括已软删除的记录
+func HardDeleteProduct(ctx context.Context, a Product) error {
+	return NewProductRepository(global.DB_).HardDelete(ctx, a)
+}
+
+// GetProductListWithDeleted 分页查询，包括已软删除的记录
+func GetProductListWithDeleted(ctx context.Context, info filter.PageInfo, filter *ProductFilter) (list []Product, total int64, err error) {
+	return NewProductRepository(global.DB_).GetListWithDeleted(ctx, info, filter)
+}
@@ -0,0 +1,22 @@
+-- Code generated by protoc-gen-simple. DO NOT EDIT.
+-- versions:
+-- - protoc-gen-simple v0.0.7
+-- - protoc          (unknown)
+-- source: filter.proto
+-- dialect: mysql
+
+CREATE TABLE `products` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `created_at` datetime(3),
+  `updated_at` datetime(3),
+  `deleted_at` datetime(3),
+  `name` varchar(255),
+  `remark` varchar(255),
+  `price` int,
+  `status` int,
+  `active` tinyint(1),
+  `stock` bigint,
+  PRIMARY KEY (`id`)
+);
+CREATE INDEX `idx_products_deleted_at` ON `products` (`deleted_at`);
+
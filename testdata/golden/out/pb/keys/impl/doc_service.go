@@ -0,0 +1,64 @@
+// Code generated by protoc-gen-simple. DO NOT EDIT.
+// versions:
+// - protoc-gen-simple v0.0.7
+// - protoc          (unknown)
+// source: keys.proto
+
+package impl
+
+import (
+	context "context"
+	keys "example.com/app/pb/keys"
+	model "example.com/app/pb/keys/model"
+	global "github.com/wwengg/simple/core/global"
+	gorm "gorm.io/gorm"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = context.TODO
+
+type Doc struct {
+	// Repository is the data access of the service, by default
+	// model.NewDocRepository(s.db()).
+	Repository model.DocRepository
+	// DB is the database of the service, by default global.DB_.
+	DB *gorm.DB
+}
+
+func (s *Doc) db() *gorm.DB {
+	if s.DB != nil {
+		return s.DB
+	}
+	return global.DB_
+}
+
+func (s *Doc) repository() model.DocRepository {
+	if s.Repository != nil {
+		return s.Repository
+	}
+	return model.NewDocRepository(s.db())
+}
+
+// DeleteDoc is server rpc method as defined
+func (s *Doc) DeleteDoc(ctx context.Context, args *keys.DocKey, reply *keys.CommonReply) (err error) {
+	*reply = keys.CommonReply{}
+	if err = s.repository().Delete(ctx, model.Doc{Id: args.Id}); err == nil {
+		reply.Code = keys.EnumCode_Success
+	} else {
+		reply.Code = keys.EnumCode_DeleteError
+	}
+
+	return nil
+}
+
+// FindDocById is server rpc method as defined
+func (s *Doc) FindDocById(ctx context.Context, args *keys.DocKey, reply *keys.DocReply) (err error) {
+	*reply = keys.DocReply{}
+	if result, err := s.repository().Get(ctx, args.Id); err == nil {
+		reply.Data = result.Proto()
+		reply.Code = keys.EnumCode_Success
+	} else {
+		reply.Code = keys.EnumCode_FindError
+	}
+	return nil
+}
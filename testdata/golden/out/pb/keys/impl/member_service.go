@@ -0,0 +1,76 @@
+// Code generated by protoc-gen-simple. DO NOT EDIT.
+// versions:
+// - protoc-gen-simple v0.0.7
+// - protoc          (unknown)
+// source: keys.proto
+
+package impl
+
+import (
+	context "context"
+	keys "example.com/app/pb/keys"
+	model "example.com/app/pb/keys/model"
+	global "github.com/wwengg/simple/core/global"
+	gorm "gorm.io/gorm"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = context.TODO
+
+type Member struct {
+	// Repository is the data access of the service, by default
+	// model.NewMemberRepository(s.db()).
+	Repository model.MemberRepository
+	// DB is the database of the service, by default global.DB_.
+	DB *gorm.DB
+}
+
+func (s *Member) db() *gorm.DB {
+	if s.DB != nil {
+		return s.DB
+	}
+	return global.DB_
+}
+
+func (s *Member) repository() model.MemberRepository {
+	if s.Repository != nil {
+		return s.Repository
+	}
+	return model.NewMemberRepository(s.db())
+}
+
+// CreateMember is server rpc method as defined
+func (s *Member) CreateMember(ctx context.Context, args *keys.MemberModel, reply *keys.CommonReply) (err error) {
+	*reply = keys.CommonReply{}
+	if err = s.repository().Create(ctx, model.MemberProtoToModel(args)); err == nil {
+		reply.Code = keys.EnumCode_Success
+	} else {
+		reply.Code = keys.EnumCode_CreateError
+	}
+
+	return nil
+}
+
+// DeleteMember is server rpc method as defined
+func (s *Member) DeleteMember(ctx context.Context, args *keys.MemberKey, reply *keys.CommonReply) (err error) {
+	*reply = keys.CommonReply{}
+	if err = s.repository().Delete(ctx, model.Member{TenantId: args.TenantId, Type: args.Type}); err == nil {
+		reply.Code = keys.EnumCode_Success
+	} else {
+		reply.Code = keys.EnumCode_DeleteError
+	}
+
+	return nil
+}
+
+// FindMemberById is server rpc method as defined
+func (s *Member) FindMemberById(ctx context.Context, args *keys.MemberKey, reply *keys.MemberReply) (err error) {
+	*reply = keys.MemberReply{}
+	if result, err := s.repository().Get(ctx, args.TenantId, args.Type); err == nil {
+		reply.Data = result.Proto()
+		reply.Code = keys.EnumCode_Success
+	} else {
+		reply.Code = keys.EnumCode_FindError
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+// Code generated by protoc-gen-simple. DO NOT EDIT.
+// versions:
+// - protoc-gen-simple v0.0.7
+// - protoc          (unknown)
+// source: keys.proto
+
+package model
+
+import (
+	context "context"
+	keys "example.com/app/pb/keys"
+	fmt "fmt"
+	global "github.com/wwengg/simple/core/global"
+	gorm "gorm.io/gorm"
+	clause "gorm.io/gorm/clause"
+	strings "strings"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = time.Now
+
+// Counter Model
+type Counter struct {
+	Id uint32 `json:"id" gorm:"column:id;type:int unsigned;primaryKey;autoIncrement;"`
+}
+
+func (model *Counter) Proto() *keys.CounterModel {
+	proto := &keys.CounterModel{
+		Id: model.Id,
+	}
+	return proto
+}
+
+func CounterProtoToModel(proto *keys.CounterModel) *Counter {
+	model := Counter{
+		Id: proto.Id,
+	}
+	return &model
+}
+
+// CounterFilter 分页查询条件，零值的条件不生效
+type CounterFilter struct {
+	// Sort 排序字段，"-" 前缀表示降序，可选 id
+	Sort []string
+}
+
+var counterSortColumns = map[string]string{
+	"id": "id",
+}
+
+// Scope adds the conditions and the order of f to db. An unknown sort
+// field is reported as an error of db.
+func (f *CounterFilter) Scope(db *gorm.DB) *gorm.DB {
+	if f == nil {
+		return db
+	}
+	for _, s := range f.Sort {
+		column, ok := counterSortColumns[strings.TrimPrefix(s, "-")]
+		if !ok {
+			db.AddError(fmt.Errorf("CounterFilter: cannot sort by %q", s))
+			return db
+		}
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: strings.HasPrefix(s, "-")})
+	}
+	return db
+}
+
+// CounterRepository 数据访问接口
+type CounterRepository interface {
+	// Create 创建
+	Create(ctx context.Context, a *Counter) error
+	// Delete 删除，记录不存在时返回 gorm.ErrRecordNotFound
+	Delete(ctx context.Context, a Counter) error
+	// Update 修改
+	Update(ctx context.Context, a *Counter) error
+	// UpdateFields 只修改 paths（FieldMask 的 proto 字段名）对应的列，
+	// 未知或只读的字段返回错误
+	UpdateFields(ctx context.Context, a *Counter, paths []string) error
+	// Get 查询
+	Get(ctx context.Context, id uint32) (Counter, error)
+	// GetList 分页查询，filter 为 nil 时不过滤
+	GetList(ctx context.Context, info keys.PageInfo, filter *CounterFilter) ([]Counter, int64, error)
+	// Transaction 在事务中运行 fn，fn 返回 nil 时提交；已在事务中时使用保存点
+	Transaction(ctx context.Context, fn func(repo CounterRepository) error) error
+	// BatchCreate 批量创建，每条 INSERT 语句最多 batchSize 行
+	BatchCreate(ctx context.Context, list []*Counter, batchSize int) error
+	// BatchUpdate 在一个事务中逐条 Update
+	BatchUpdate(ctx context.Context, list []*Counter) error
+	// BatchDelete 按主键批量删除
+	BatchDelete(ctx context.Context, ids []uint32) error
+	// GetByIds 按主键批量查询
+	GetByIds(ctx context.Context, ids []uint32) (list []Counter, err error)
+}
+
+// NewCounterRepository returns the CounterRepository running on db, which
+// may be a transaction.
+func NewCounterRepository(db *gorm.DB) CounterRepository {
+	return &counterRepository{db: db}
+}
+
+type counterRepository struct {
+	db *gorm.DB
+}
+
+func (r *counterRepository) Create(ctx context.Context, a *Counter) error {
+	return r.db.WithContext(ctx).Create(a).Error
+}
+
+func (r *counterRepository) Delete(ctx context.Context, a Counter) error {
+	result := r.db.WithContext(ctx).Delete(&a)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return result.Error
+}
+
+func (r *counterRepository) Update(ctx context.Context, a *Counter) error {
+	return r.db.WithContext(ctx).Save(a).Error
+}
+
+func (r *counterRepository) Get(ctx context.Context, id uint32) (result Counter, err error) {
+	err = r.db.WithContext(ctx).Where("id = ?", id).First(&result).Error
+	return
+}
+
+func (r *counterRepository) GetList(ctx context.Context, info keys.PageInfo, filter *CounterFilter) (list []Counter, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := filter.Scope(r.db.WithContext(ctx).Model(&Counter{}))
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Limit(int(limit)).Offset(int(offset)).Find(&list).Error
+	return
+}
+
+func (r *counterRepository) Transaction(ctx context.Context, fn func(repo CounterRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewCounterRepository(tx))
+	})
+}
+
+// DB returns the database the repository runs on, which may be a
+// transaction.
+func (r *counterRepository) DB() *gorm.DB {
+	return r.db
+}
+
+// counterUpdateColumns maps the field mask paths of UpdateFields to the columns they
+// update.
+var counterUpdateColumns = map[string][]string{}
+
+func (r *counterRepository) UpdateFields(ctx context.Context, a *Counter, paths []string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("UpdateCounterFields: empty field mask")
+	}
+	var columns []string
+	for _, path := range paths {
+		c, ok := counterUpdateColumns[path]
+		if !ok {
+			return fmt.Errorf("UpdateCounterFields: unknown or read-only field %q", path)
+		}
+		columns = append(columns, c...)
+	}
+	return r.db.WithContext(ctx).Model(a).Select(columns).Updates(a).Error
+}
+
+func (r *counterRepository) BatchCreate(ctx context.Context, list []*Counter, batchSize int) error {
+	return r.db.WithContext(ctx).CreateInBatches(list, batchSize).Error
+}
+
+func (r *counterRepository) BatchUpdate(ctx context.Context, list []*Counter) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, a := range list {
+			if err := NewCounterRepository(tx).Update(ctx, a); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *counterRepository) BatchDelete(ctx context.Context, ids []uint32) error {
+	return r.db.WithContext(ctx).Where("id IN ?", ids).Delete(&Counter{}).Error
+}
+
+func (r *counterRepository) GetByIds(ctx context.Context, ids []uint32) (list []Counter, err error) {
+	err = r.db.WithContext(ctx).Where("id IN ?", ids).Find(&list).Error
+	return
+}
+
+// CreateCounter Func 创建
+func CreateCounter(ctx context.Context, a Counter) (err error) {
+	return NewCounterRepository(global.DB_).Create(ctx, &a)
+}
+
+// DeleteCounter  删除
+func DeleteCounter(ctx context.Context, a Counter) (err error) {
+	return NewCounterRepository(global.DB_).Delete(ctx, a)
+}
+
+// UpdateCounter 修改
+func UpdateCounter(ctx context.Context, a *Counter) (err error) {
+	return NewCounterRepository(global.DB_).Update(ctx, a)
+}
+
+// UpdateCounterFields 按 FieldMask 部分修改
+func UpdateCounterFields(ctx context.Context, a *Counter, paths []string) (err error) {
+	return NewCounterRepository(global.DB_).UpdateFields(ctx, a, paths)
+}
+
+// GetCounter 查询
+func GetCounter(ctx context.Context, id uint32) (result Counter, err error) {
+	return NewCounterRepository(global.DB_).Get(ctx, id)
+}
+
+// GetCounterList 分页查询
+func GetCounterList(ctx context.Context, info keys.PageInfo, filter *CounterFilter) (list []Counter, total int64, err error) {
+	return NewCounterRepository(global.DB_).GetList(ctx, info, filter)
+}
+
+// BatchCreateCounter 批量创建，每条 INSERT 语句最多 batchSize 行
+func BatchCreateCounter(ctx context.Context, list []*Counter, batchSize int) error {
+	return NewCounterRepository(global.DB_).BatchCreate(ctx, list, batchSize)
+}
+
+// BatchUpdateCounter 在一个事务中逐条 Update
+func BatchUpdateCounter(ctx context.Context, list []*Counter) error {
+	return NewCounterRepository(global.DB_).BatchUpdate(ctx, list)
+}
+
+// BatchDeleteCounter 按主键批量删除
+func BatchDeleteCounter(ctx context.Context, ids []uint32) error {
+	return NewCounterRepository(global.DB_).BatchDelete(ctx, ids)
+}
+
+// GetCounterByIds 按主键批量查询
+func GetCounterByIds(ctx context.Context, ids []uint32) (list []Counter, err error) {
+	return NewCounterRepository(global.DB_).GetByIds(ctx, ids)
+}
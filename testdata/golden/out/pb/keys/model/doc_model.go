@@ -0,0 +1,267 @@
+// Code generated by protoc-gen-simple. DO NOT EDIT.
+// versions:
+// - protoc-gen-simple v0.0.7
+// - protoc          (unknown)
+// source: keys.proto
+
+package model
+
+import (
+	context "context"
+	keys "example.com/app/pb/keys"
+	fmt "fmt"
+	uuid "github.com/google/uuid"
+	global "github.com/wwengg/simple/core/global"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	gorm "gorm.io/gorm"
+	clause "gorm.io/gorm/clause"
+	strings "strings"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = time.Now
+
+// Doc Model
+type Doc struct {
+	Id        string    `json:"id" gorm:"column:id;type:varchar(255);primaryKey;"`
+	Title     string    `json:"title" gorm:"column:title;type:varchar(255);"`
+	CreatedAt time.Time `json:"createdAt" gorm:"column:created_at;type:datetime(3);"`
+}
+
+// BeforeCreate generates the primary key of a new Doc.
+func (model *Doc) BeforeCreate(tx *gorm.DB) error {
+	if model.Id == "" {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return err
+		}
+		model.Id = id.String()
+	}
+	return nil
+}
+
+func (model *Doc) Proto() *keys.DocModel {
+	proto := &keys.DocModel{
+		Id:    model.Id,
+		Title: model.Title,
+	}
+	if !model.CreatedAt.IsZero() {
+		proto.CreatedAt = timestamppb.New(model.CreatedAt)
+	}
+	return proto
+}
+
+func DocProtoToModel(proto *keys.DocModel) *Doc {
+	model := Doc{
+		Id:    proto.Id,
+		Title: proto.Title,
+	}
+	if proto.CreatedAt != nil {
+		model.CreatedAt = proto.CreatedAt.AsTime()
+	}
+	return &model
+}
+
+// DocFilter 分页查询条件，零值的条件不生效
+type DocFilter struct {
+	// Sort 排序字段，"-" 前缀表示降序，可选 id
+	Sort []string
+}
+
+var docSortColumns = map[string]string{
+	"id": "id",
+}
+
+// Scope adds the conditions and the order of f to db. An unknown sort
+// field is reported as an error of db.
+func (f *DocFilter) Scope(db *gorm.DB) *gorm.DB {
+	if f == nil {
+		return db
+	}
+	for _, s := range f.Sort {
+		column, ok := docSortColumns[strings.TrimPrefix(s, "-")]
+		if !ok {
+			db.AddError(fmt.Errorf("DocFilter: cannot sort by %q", s))
+			return db
+		}
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: strings.HasPrefix(s, "-")})
+	}
+	return db
+}
+
+// DocRepository 数据访问接口
+type DocRepository interface {
+	// Create 创建
+	Create(ctx context.Context, a *Doc) error
+	// Delete 删除，记录不存在时返回 gorm.ErrRecordNotFound
+	Delete(ctx context.Context, a Doc) error
+	// Update 修改
+	Update(ctx context.Context, a *Doc) error
+	// UpdateFields 只修改 paths（FieldMask 的 proto 字段名）对应的列，
+	// 未知或只读的字段返回错误
+	UpdateFields(ctx context.Context, a *Doc, paths []string) error
+	// Get 查询
+	Get(ctx context.Context, id string) (Doc, error)
+	// GetList 分页查询，filter 为 nil 时不过滤
+	GetList(ctx context.Context, info keys.PageInfo, filter *DocFilter) ([]Doc, int64, error)
+	// Transaction 在事务中运行 fn，fn 返回 nil 时提交；已在事务中时使用保存点
+	Transaction(ctx context.Context, fn func(repo DocRepository) error) error
+	// BatchCreate 批量创建，每条 INSERT 语句最多 batchSize 行
+	BatchCreate(ctx context.Context, list []*Doc, batchSize int) error
+	// BatchUpdate 在一个事务中逐条 Update
+	BatchUpdate(ctx context.Context, list []*Doc) error
+	// BatchDelete 按主键批量删除
+	BatchDelete(ctx context.Context, ids []string) error
+	// GetByIds 按主键批量查询
+	GetByIds(ctx context.Context, ids []string) (list []Doc, err error)
+}
+
+// NewDocRepository returns the DocRepository running on db, which
+// may be a transaction.
+func NewDocRepository(db *gorm.DB) DocRepository {
+	return &docRepository{db: db}
+}
+
+type docRepository struct {
+	db *gorm.DB
+}
+
+func (r *docRepository) Create(ctx context.Context, a *Doc) error {
+	return r.db.WithContext(ctx).Create(a).Error
+}
+
+func (r *docRepository) Delete(ctx context.Context, a Doc) error {
+	result := r.db.WithContext(ctx).Delete(&a)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return result.Error
+}
+
+func (r *docRepository) Update(ctx context.Context, a *Doc) error {
+	return r.db.WithContext(ctx).Save(a).Error
+}
+
+func (r *docRepository) Get(ctx context.Context, id string) (result Doc, err error) {
+	err = r.db.WithContext(ctx).Where("id = ?", id).First(&result).Error
+	return
+}
+
+func (r *docRepository) GetList(ctx context.Context, info keys.PageInfo, filter *DocFilter) (list []Doc, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := filter.Scope(r.db.WithContext(ctx).Model(&Doc{}))
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Limit(int(limit)).Offset(int(offset)).Find(&list).Error
+	return
+}
+
+func (r *docRepository) Transaction(ctx context.Context, fn func(repo DocRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewDocRepository(tx))
+	})
+}
+
+// DB returns the database the repository runs on, which may be a
+// transaction.
+func (r *docRepository) DB() *gorm.DB {
+	return r.db
+}
+
+// docUpdateColumns maps the field mask paths of UpdateFields to the columns they
+// update.
+var docUpdateColumns = map[string][]string{
+	"title": {"title"},
+}
+
+func (r *docRepository) UpdateFields(ctx context.Context, a *Doc, paths []string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("UpdateDocFields: empty field mask")
+	}
+	var columns []string
+	for _, path := range paths {
+		c, ok := docUpdateColumns[path]
+		if !ok {
+			return fmt.Errorf("UpdateDocFields: unknown or read-only field %q", path)
+		}
+		columns = append(columns, c...)
+	}
+	return r.db.WithContext(ctx).Model(a).Select(columns).Updates(a).Error
+}
+
+func (r *docRepository) BatchCreate(ctx context.Context, list []*Doc, batchSize int) error {
+	return r.db.WithContext(ctx).CreateInBatches(list, batchSize).Error
+}
+
+func (r *docRepository) BatchUpdate(ctx context.Context, list []*Doc) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, a := range list {
+			if err := NewDocRepository(tx).Update(ctx, a); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *docRepository) BatchDelete(ctx context.Context, ids []string) error {
+	return r.db.WithContext(ctx).Where("id IN ?", ids).Delete(&Doc{}).Error
+}
+
+func (r *docRepository) GetByIds(ctx context.Context, ids []string) (list []Doc, err error) {
+	err = r.db.WithContext(ctx).Where("id IN ?", ids).Find(&list).Error
+	return
+}
+
+// CreateDoc Func 创建
+func CreateDoc(ctx context.Context, a Doc) (err error) {
+	return NewDocRepository(global.DB_).Create(ctx, &a)
+}
+
+// DeleteDoc  删除
+func DeleteDoc(ctx context.Context, a Doc) (err error) {
+	return NewDocRepository(global.DB_).Delete(ctx, a)
+}
+
+// UpdateDoc 修改
+func UpdateDoc(ctx context.Context, a *Doc) (err error) {
+	return NewDocRepository(global.DB_).Update(ctx, a)
+}
+
+// UpdateDocFields 按 FieldMask 部分修改
+func UpdateDocFields(ctx context.Context, a *Doc, paths []string) (err error) {
+	return NewDocRepository(global.DB_).UpdateFields(ctx, a, paths)
+}
+
+// GetDoc 查询
+func GetDoc(ctx context.Context, id string) (result Doc, err error) {
+	return NewDocRepository(global.DB_).Get(ctx, id)
+}
+
+// GetDocList 分页查询
+func GetDocList(ctx context.Context, info keys.PageInfo, filter *DocFilter) (list []Doc, total int64, err error) {
+	return NewDocRepository(global.DB_).GetList(ctx, info, filter)
+}
+
+// BatchCreateDoc 批量创建，每条 INSERT 语句最多 batchSize 行
+func BatchCreateDoc(ctx context.Context, list []*Doc, batchSize int) error {
+	return NewDocRepository(global.DB_).BatchCreate(ctx, list, batchSize)
+}
+
+// BatchUpdateDoc 在一个事务中逐条 Update
+func BatchUpdateDoc(ctx context.Context, list []*Doc) error {
+	return NewDocRepository(global.DB_).BatchUpdate(ctx, list)
+}
+
+// BatchDeleteDoc 按主键批量删除
+func BatchDeleteDoc(ctx context.Context, ids []string) error {
+	return NewDocRepository(global.DB_).BatchDelete(ctx, ids)
+}
+
+// GetDocByIds 按主键批量查询
+func GetDocByIds(ctx context.Context, ids []string) (list []Doc, err error) {
+	return NewDocRepository(global.DB_).GetByIds(ctx, ids)
+}
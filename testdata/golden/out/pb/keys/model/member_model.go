@@ -0,0 +1,227 @@
+// Code generated by protoc-gen-simple. DO NOT EDIT.
+// versions:
+// - protoc-gen-simple v0.0.7
+// - protoc          (unknown)
+// source: keys.proto
+
+package model
+
+import (
+	context "context"
+	keys "example.com/app/pb/keys"
+	fmt "fmt"
+	global "github.com/wwengg/simple/core/global"
+	gorm "gorm.io/gorm"
+	clause "gorm.io/gorm/clause"
+	strings "strings"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = time.Now
+
+// Member Model
+type Member struct {
+	TenantId int64  `json:"tenantId" gorm:"column:tenant_id;type:bigint;primaryKey;autoIncrement:false;"`
+	Type     string `json:"type" gorm:"column:type;type:varchar(255);primaryKey;"`
+	Note     string `json:"note" gorm:"column:note;type:varchar(255);"`
+}
+
+func (model *Member) Proto() *keys.MemberModel {
+	proto := &keys.MemberModel{
+		TenantId: model.TenantId,
+		Type:     model.Type,
+		Note:     model.Note,
+	}
+	return proto
+}
+
+func MemberProtoToModel(proto *keys.MemberModel) *Member {
+	model := Member{
+		TenantId: proto.TenantId,
+		Type:     proto.Type,
+		Note:     proto.Note,
+	}
+	return &model
+}
+
+// MemberFilter 分页查询条件，零值的条件不生效
+type MemberFilter struct {
+	// Sort 排序字段，"-" 前缀表示降序，可选 tenant_id, type
+	Sort []string
+}
+
+var memberSortColumns = map[string]string{
+	"tenant_id": "tenant_id",
+	"type":      "type",
+}
+
+// Scope adds the conditions and the order of f to db. An unknown sort
+// field is reported as an error of db.
+func (f *MemberFilter) Scope(db *gorm.DB) *gorm.DB {
+	if f == nil {
+		return db
+	}
+	for _, s := range f.Sort {
+		column, ok := memberSortColumns[strings.TrimPrefix(s, "-")]
+		if !ok {
+			db.AddError(fmt.Errorf("MemberFilter: cannot sort by %q", s))
+			return db
+		}
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: strings.HasPrefix(s, "-")})
+	}
+	return db
+}
+
+// MemberRepository 数据访问接口
+type MemberRepository interface {
+	// Create 创建
+	Create(ctx context.Context, a *Member) error
+	// Delete 删除，记录不存在时返回 gorm.ErrRecordNotFound
+	Delete(ctx context.Context, a Member) error
+	// Update 修改
+	Update(ctx context.Context, a *Member) error
+	// UpdateFields 只修改 paths（FieldMask 的 proto 字段名）对应的列，
+	// 未知或只读的字段返回错误
+	UpdateFields(ctx context.Context, a *Member, paths []string) error
+	// Get 查询
+	Get(ctx context.Context, tenantId int64, type_ string) (Member, error)
+	// GetList 分页查询，filter 为 nil 时不过滤
+	GetList(ctx context.Context, info keys.PageInfo, filter *MemberFilter) ([]Member, int64, error)
+	// Transaction 在事务中运行 fn，fn 返回 nil 时提交；已在事务中时使用保存点
+	Transaction(ctx context.Context, fn func(repo MemberRepository) error) error
+	// BatchCreate 批量创建，每条 INSERT 语句最多 batchSize 行
+	BatchCreate(ctx context.Context, list []*Member, batchSize int) error
+	// BatchUpdate 在一个事务中逐条 Update
+	BatchUpdate(ctx context.Context, list []*Member) error
+}
+
+// NewMemberRepository returns the MemberRepository running on db, which
+// may be a transaction.
+func NewMemberRepository(db *gorm.DB) MemberRepository {
+	return &memberRepository{db: db}
+}
+
+type memberRepository struct {
+	db *gorm.DB
+}
+
+func (r *memberRepository) Create(ctx context.Context, a *Member) error {
+	return r.db.WithContext(ctx).Create(a).Error
+}
+
+func (r *memberRepository) Delete(ctx context.Context, a Member) error {
+	result := r.db.WithContext(ctx).Delete(&a)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return result.Error
+}
+
+func (r *memberRepository) Update(ctx context.Context, a *Member) error {
+	return r.db.WithContext(ctx).Save(a).Error
+}
+
+func (r *memberRepository) Get(ctx context.Context, tenantId int64, type_ string) (result Member, err error) {
+	err = r.db.WithContext(ctx).Where("tenant_id = ? AND type = ?", tenantId, type_).First(&result).Error
+	return
+}
+
+func (r *memberRepository) GetList(ctx context.Context, info keys.PageInfo, filter *MemberFilter) (list []Member, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := filter.Scope(r.db.WithContext(ctx).Model(&Member{}))
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Limit(int(limit)).Offset(int(offset)).Find(&list).Error
+	return
+}
+
+func (r *memberRepository) Transaction(ctx context.Context, fn func(repo MemberRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewMemberRepository(tx))
+	})
+}
+
+// DB returns the database the repository runs on, which may be a
+// transaction.
+func (r *memberRepository) DB() *gorm.DB {
+	return r.db
+}
+
+// memberUpdateColumns maps the field mask paths of UpdateFields to the columns they
+// update.
+var memberUpdateColumns = map[string][]string{
+	"note": {"note"},
+}
+
+func (r *memberRepository) UpdateFields(ctx context.Context, a *Member, paths []string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("UpdateMemberFields: empty field mask")
+	}
+	var columns []string
+	for _, path := range paths {
+		c, ok := memberUpdateColumns[path]
+		if !ok {
+			return fmt.Errorf("UpdateMemberFields: unknown or read-only field %q", path)
+		}
+		columns = append(columns, c...)
+	}
+	return r.db.WithContext(ctx).Model(a).Select(columns).Updates(a).Error
+}
+
+func (r *memberRepository) BatchCreate(ctx context.Context, list []*Member, batchSize int) error {
+	return r.db.WithContext(ctx).CreateInBatches(list, batchSize).Error
+}
+
+func (r *memberRepository) BatchUpdate(ctx context.Context, list []*Member) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, a := range list {
+			if err := NewMemberRepository(tx).Update(ctx, a); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// CreateMember Func 创建
+func CreateMember(ctx context.Context, a Member) (err error) {
+	return NewMemberRepository(global.DB_).Create(ctx, &a)
+}
+
+// DeleteMember  删除
+func DeleteMember(ctx context.Context, a Member) (err error) {
+	return NewMemberRepository(global.DB_).Delete(ctx, a)
+}
+
+// UpdateMember 修改
+func UpdateMember(ctx context.Context, a *Member) (err error) {
+	return NewMemberRepository(global.DB_).Update(ctx, a)
+}
+
+// UpdateMemberFields 按 FieldMask 部分修改
+func UpdateMemberFields(ctx context.Context, a *Member, paths []string) (err error) {
+	return NewMemberRepository(global.DB_).UpdateFields(ctx, a, paths)
+}
+
+// GetMember 查询
+func GetMember(ctx context.Context, tenantId int64, type_ string) (result Member, err error) {
+	return NewMemberRepository(global.DB_).Get(ctx, tenantId, type_)
+}
+
+// GetMemberList 分页查询
+func GetMemberList(ctx context.Context, info keys.PageInfo, filter *MemberFilter) (list []Member, total int64, err error) {
+	return NewMemberRepository(global.DB_).GetList(ctx, info, filter)
+}
+
+// BatchCreateMember 批量创建，每条 INSERT 语句最多 batchSize 行
+func BatchCreateMember(ctx context.Context, list []*Member, batchSize int) error {
+	return NewMemberRepository(global.DB_).BatchCreate(ctx, list, batchSize)
+}
+
+// BatchUpdateMember 在一个事务中逐条 Update
+func BatchUpdateMember(ctx context.Context, list []*Member) error {
+	return NewMemberRepository(global.DB_).BatchUpdate(ctx, list)
+}
@@ -0,0 +1,37 @@
+-- Code generated by protoc-gen-simple. DO NOT EDIT.
+-- versions:
+-- - protoc-gen-simple v0.0.7
+-- - protoc          (unknown)
+-- source: keys.proto
+-- dialect: mysql
+
+CREATE TABLE `docs` (
+  `id` varchar(255) NOT NULL,
+  `title` varchar(255),
+  `created_at` datetime(3),
+  PRIMARY KEY (`id`)
+);
+
+CREATE TABLE `events` (
+  `id` bigint NOT NULL,
+  `name` varchar(255),
+  PRIMARY KEY (`id`)
+);
+
+CREATE TABLE `tags` (
+  `code` varchar(255) NOT NULL,
+  PRIMARY KEY (`code`)
+);
+
+CREATE TABLE `members` (
+  `tenant_id` bigint NOT NULL,
+  `type` varchar(255) NOT NULL,
+  `note` varchar(255),
+  PRIMARY KEY (`tenant_id`, `type`)
+);
+
+CREATE TABLE `counters` (
+  `id` int unsigned NOT NULL AUTO_INCREMENT,
+  PRIMARY KEY (`id`)
+);
+
@@ -0,0 +1,130 @@
+// Code generated by protoc-gen-simple. DO NOT EDIT.
+// versions:
+// - protoc-gen-simple v0.0.7
+// - protoc          (unknown)
+// source: lock.proto
+
+package impl
+
+import (
+	context "context"
+	errors "errors"
+	lock "example.com/app/pb/lock"
+	model "example.com/app/pb/lock/model"
+	global "github.com/wwengg/simple/core/global"
+	gorm "gorm.io/gorm"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = context.TODO
+
+type Doc struct {
+	// Repository is the data access of the service, by default
+	// model.NewDocRepository(s.db()).
+	Repository model.DocRepository
+	// DB is the database of the service, by default global.DB_.
+	DB *gorm.DB
+}
+
+func (s *Doc) db() *gorm.DB {
+	if s.DB != nil {
+		return s.DB
+	}
+	return global.DB_
+}
+
+func (s *Doc) repository() model.DocRepository {
+	if s.Repository != nil {
+		return s.Repository
+	}
+	return model.NewDocRepository(s.db())
+}
+
+// UpdateDoc is server rpc method as defined
+func (s *Doc) UpdateDoc(ctx context.Context, args *lock.DocModel, reply *lock.CommonReply) (err error) {
+	*reply = lock.CommonReply{}
+	if err = s.repository().Update(ctx, model.DocProtoToModel(args)); err == nil {
+		reply.Code = lock.EnumCode_Success
+	} else if errors.Is(err, model.ErrDocVersionConflict) {
+		reply.Code = lock.EnumCode_VersionConflict
+	} else {
+		reply.Code = lock.EnumCode_UpdateError
+	}
+
+	return nil
+}
+
+// UpdateDocFields is server rpc method as defined
+func (s *Doc) UpdateDocFields(ctx context.Context, args *lock.UpdateDocArgs, reply *lock.CommonReply) (err error) {
+	*reply = lock.CommonReply{}
+	if args.Doc == nil {
+		reply.Code = lock.EnumCode_UpdateError
+	} else if err = s.repository().UpdateFields(ctx, model.DocProtoToModel(args.Doc), args.Mask.GetPaths()); err == nil {
+		reply.Code = lock.EnumCode_Success
+	} else if errors.Is(err, model.ErrDocVersionConflict) {
+		reply.Code = lock.EnumCode_VersionConflict
+	} else {
+		reply.Code = lock.EnumCode_UpdateError
+	}
+
+	return nil
+}
+
+// BatchUpdateDoc is server rpc method as defined
+func (s *Doc) BatchUpdateDoc(ctx context.Context, args *lock.DocsArgs, reply *lock.ResultsReply) (err error) {
+	*reply = lock.ResultsReply{}
+	reply.Code = lock.EnumCode_Success
+	batchFailed := errors.New("batch failed")
+	err = s.repository().Transaction(ctx, func(repo model.DocRepository) error {
+		list := make([]*model.Doc, 0, len(args.Docs))
+		for _, v := range args.Docs {
+			list = append(list, model.DocProtoToModel(v))
+		}
+		for _, a := range list {
+			if err := repo.Transaction(ctx, func(repo model.DocRepository) error {
+				return repo.Update(ctx, a)
+			}); err == nil {
+				reply.Results = append(reply.Results, lock.EnumCode_Success)
+			} else if errors.Is(err, model.ErrDocVersionConflict) {
+				reply.Results = append(reply.Results, lock.EnumCode_VersionConflict)
+				reply.Code = lock.EnumCode_VersionConflict
+			} else {
+				reply.Results = append(reply.Results, lock.EnumCode_UpdateError)
+				reply.Code = lock.EnumCode_UpdateError
+			}
+		}
+		if reply.Code != lock.EnumCode_Success {
+			return batchFailed
+		}
+		return nil
+	})
+	if err != nil {
+		if err != batchFailed {
+			reply.Code = lock.EnumCode_UpdateError
+		}
+		// 整批已回滚，成功的记录也没有保存
+		for i, code := range reply.Results {
+			if code == lock.EnumCode_Success {
+				reply.Results[i] = lock.EnumCode_UpdateError
+			}
+		}
+	}
+	return nil
+}
+
+// BatchUpdateDocAll is server rpc method as defined
+func (s *Doc) BatchUpdateDocAll(ctx context.Context, args *lock.DocsArgs, reply *lock.CommonReply) (err error) {
+	*reply = lock.CommonReply{}
+	list := make([]*model.Doc, 0, len(args.Docs))
+	for _, v := range args.Docs {
+		list = append(list, model.DocProtoToModel(v))
+	}
+	if err = s.repository().BatchUpdate(ctx, list); err == nil {
+		reply.Code = lock.EnumCode_Success
+	} else if errors.Is(err, model.ErrDocVersionConflict) {
+		reply.Code = lock.EnumCode_VersionConflict
+	} else {
+		reply.Code = lock.EnumCode_UpdateError
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+-- Code generated by protoc-gen-simple. DO NOT EDIT.
+-- versions:
+-- - protoc-gen-simple v0.0.7
+-- - protoc          (unknown)
+-- source: lock.proto
+-- dialect: mysql
+
+CREATE TABLE `docs` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `created_at` datetime(3),
+  `updated_at` datetime(3),
+  `deleted_at` datetime(3),
+  `title` varchar(255),
+  `version` bigint,
+  PRIMARY KEY (`id`)
+);
+CREATE INDEX `idx_docs_deleted_at` ON `docs` (`deleted_at`);
+
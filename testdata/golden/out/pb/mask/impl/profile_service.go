@@ -0,0 +1,66 @@
+// Code generated by protoc-gen-simple. DO NOT EDIT.
+// versions:
+// - protoc-gen-simple v0.0.7
+// - protoc          (unknown)
+// source: mask.proto
+
+package impl
+
+import (
+	context "context"
+	mask "example.com/app/pb/mask"
+	model "example.com/app/pb/mask/model"
+	global "github.com/wwengg/simple/core/global"
+	gorm "gorm.io/gorm"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = context.TODO
+
+type Profile struct {
+	// Repository is the data access of the service, by default
+	// model.NewProfileRepository(s.db()).
+	Repository model.ProfileRepository
+	// DB is the database of the service, by default global.DB_.
+	DB *gorm.DB
+}
+
+func (s *Profile) db() *gorm.DB {
+	if s.DB != nil {
+		return s.DB
+	}
+	return global.DB_
+}
+
+func (s *Profile) repository() model.ProfileRepository {
+	if s.Repository != nil {
+		return s.Repository
+	}
+	return model.NewProfileRepository(s.db())
+}
+
+// UpdateProfile is server rpc method as defined
+func (s *Profile) UpdateProfile(ctx context.Context, args *mask.UpdateProfileArgs, reply *mask.CommonReply) (err error) {
+	*reply = mask.CommonReply{}
+	if args.Profile == nil {
+		reply.Code = mask.EnumCode_UpdateError
+	} else if err = s.repository().UpdateFields(ctx, model.ProfileProtoToModel(args.Profile), args.UpdateMask.GetPaths()); err == nil {
+		reply.Code = mask.EnumCode_Success
+	} else {
+		reply.Code = mask.EnumCode_UpdateError
+	}
+
+	return nil
+}
+
+// UpdateProfileAll is server rpc method as defined
+func (s *Profile) UpdateProfileAll(ctx context.Context, args *mask.ProfileModel, reply *mask.CommonReply) (err error) {
+	*reply = mask.CommonReply{}
+	if err = s.repository().Update(ctx, model.ProfileProtoToModel(args)); err == nil {
+		reply.Code = mask.EnumCode_Success
+	} else {
+		reply.Code = mask.EnumCode_UpdateError
+	}
+
+	return nil
+}
@@ -0,0 +1,249 @@
+// Code generated by protoc-gen-simple. DO NOT EDIT.
+// versions:
+// - protoc-gen-simple v0.0.7
+// - protoc          (unknown)
+// source: mask.proto
+
+package model
+
+import (
+	context "context"
+	mask "example.com/app/pb/mask"
+	fmt "fmt"
+	global "github.com/wwengg/simple/core/global"
+	gorm "gorm.io/gorm"
+	clause "gorm.io/gorm/clause"
+	strings "strings"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = time.Now
+
+// Note Model
+type Note struct {
+	Code      string `json:"code" gorm:"column:code;type:varchar(255);primaryKey;"`
+	Body      string `json:"body" gorm:"column:body;type:varchar(255);"`
+	CreatedAt string `json:"createdAt" gorm:"column:created_at;type:varchar(255);"`
+}
+
+func (model *Note) Proto() *mask.NoteModel {
+	proto := &mask.NoteModel{
+		Code:      model.Code,
+		Body:      model.Body,
+		CreatedAt: model.CreatedAt,
+	}
+	return proto
+}
+
+func NoteProtoToModel(proto *mask.NoteModel) *Note {
+	model := Note{
+		Code:      proto.Code,
+		Body:      proto.Body,
+		CreatedAt: proto.CreatedAt,
+	}
+	return &model
+}
+
+// NoteFilter 分页查询条件，零值的条件不生效
+type NoteFilter struct {
+	// Sort 排序字段，"-" 前缀表示降序，可选 code
+	Sort []string
+}
+
+var noteSortColumns = map[string]string{
+	"code": "code",
+}
+
+// Scope adds the conditions and the order of f to db. An unknown sort
+// field is reported as an error of db.
+func (f *NoteFilter) Scope(db *gorm.DB) *gorm.DB {
+	if f == nil {
+		return db
+	}
+	for _, s := range f.Sort {
+		column, ok := noteSortColumns[strings.TrimPrefix(s, "-")]
+		if !ok {
+			db.AddError(fmt.Errorf("NoteFilter: cannot sort by %q", s))
+			return db
+		}
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: strings.HasPrefix(s, "-")})
+	}
+	return db
+}
+
+// NoteRepository 数据访问接口
+type NoteRepository interface {
+	// Create 创建
+	Create(ctx context.Context, a *Note) error
+	// Delete 删除，记录不存在时返回 gorm.ErrRecordNotFound
+	Delete(ctx context.Context, a Note) error
+	// Update 修改
+	Update(ctx context.Context, a *Note) error
+	// UpdateFields 只修改 paths（FieldMask 的 proto 字段名）对应的列，
+	// 未知或只读的字段返回错误
+	UpdateFields(ctx context.Context, a *Note, paths []string) error
+	// Get 查询
+	Get(ctx context.Context, code string) (Note, error)
+	// GetList 分页查询，filter 为 nil 时不过滤
+	GetList(ctx context.Context, info mask.PageInfo, filter *NoteFilter) ([]Note, int64, error)
+	// Transaction 在事务中运行 fn，fn 返回 nil 时提交；已在事务中时使用保存点
+	Transaction(ctx context.Context, fn func(repo NoteRepository) error) error
+	// BatchCreate 批量创建，每条 INSERT 语句最多 batchSize 行
+	BatchCreate(ctx context.Context, list []*Note, batchSize int) error
+	// BatchUpdate 在一个事务中逐条 Update
+	BatchUpdate(ctx context.Context, list []*Note) error
+	// BatchDelete 按主键批量删除
+	BatchDelete(ctx context.Context, ids []string) error
+	// GetByIds 按主键批量查询
+	GetByIds(ctx context.Context, ids []string) (list []Note, err error)
+}
+
+// NewNoteRepository returns the NoteRepository running on db, which
+// may be a transaction.
+func NewNoteRepository(db *gorm.DB) NoteRepository {
+	return &noteRepository{db: db}
+}
+
+type noteRepository struct {
+	db *gorm.DB
+}
+
+func (r *noteRepository) Create(ctx context.Context, a *Note) error {
+	return r.db.WithContext(ctx).Create(a).Error
+}
+
+func (r *noteRepository) Delete(ctx context.Context, a Note) error {
+	result := r.db.WithContext(ctx).Delete(&a)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return result.Error
+}
+
+func (r *noteRepository) Update(ctx context.Context, a *Note) error {
+	return r.db.WithContext(ctx).Save(a).Error
+}
+
+func (r *noteRepository) Get(ctx context.Context, code string) (result Note, err error) {
+	err = r.db.WithContext(ctx).Where("code = ?", code).First(&result).Error
+	return
+}
+
+func (r *noteRepository) GetList(ctx context.Context, info mask.PageInfo, filter *NoteFilter) (list []Note, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := filter.Scope(r.db.WithContext(ctx).Model(&Note{}))
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Limit(int(limit)).Offset(int(offset)).Find(&list).Error
+	return
+}
+
+func (r *noteRepository) Transaction(ctx context.Context, fn func(repo NoteRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewNoteRepository(tx))
+	})
+}
+
+// DB returns the database the repository runs on, which may be a
+// transaction.
+func (r *noteRepository) DB() *gorm.DB {
+	return r.db
+}
+
+// noteUpdateColumns maps the field mask paths of UpdateFields to the columns they
+// update.
+var noteUpdateColumns = map[string][]string{
+	"body": {"body"},
+}
+
+func (r *noteRepository) UpdateFields(ctx context.Context, a *Note, paths []string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("UpdateNoteFields: empty field mask")
+	}
+	var columns []string
+	for _, path := range paths {
+		c, ok := noteUpdateColumns[path]
+		if !ok {
+			return fmt.Errorf("UpdateNoteFields: unknown or read-only field %q", path)
+		}
+		columns = append(columns, c...)
+	}
+	return r.db.WithContext(ctx).Model(a).Select(columns).Updates(a).Error
+}
+
+func (r *noteRepository) BatchCreate(ctx context.Context, list []*Note, batchSize int) error {
+	return r.db.WithContext(ctx).CreateInBatches(list, batchSize).Error
+}
+
+func (r *noteRepository) BatchUpdate(ctx context.Context, list []*Note) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, a := range list {
+			if err := NewNoteRepository(tx).Update(ctx, a); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *noteRepository) BatchDelete(ctx context.Context, ids []string) error {
+	return r.db.WithContext(ctx).Where("code IN ?", ids).Delete(&Note{}).Error
+}
+
+func (r *noteRepository) GetByIds(ctx context.Context, ids []string) (list []Note, err error) {
+	err = r.db.WithContext(ctx).Where("code IN ?", ids).Find(&list).Error
+	return
+}
+
+// CreateNote Func 创建
+func CreateNote(ctx context.Context, a Note) (err error) {
+	return NewNoteRepository(global.DB_).Create(ctx, &a)
+}
+
+// DeleteNote  删除
+func DeleteNote(ctx context.Context, a Note) (err error) {
+	return NewNoteRepository(global.DB_).Delete(ctx, a)
+}
+
+// UpdateNote 修改
+func UpdateNote(ctx context.Context, a *Note) (err error) {
+	return NewNoteRepository(global.DB_).Update(ctx, a)
+}
+
+// UpdateNoteFields 按 FieldMask 部分修改
+func UpdateNoteFields(ctx context.Context, a *Note, paths []string) (err error) {
+	return NewNoteRepository(global.DB_).UpdateFields(ctx, a, paths)
+}
+
+// GetNote 查询
+func GetNote(ctx context.Context, code string) (result Note, err error) {
+	return NewNoteRepository(global.DB_).Get(ctx, code)
+}
+
+// GetNoteList 分页查询
+func GetNoteList(ctx context.Context, info mask.PageInfo, filter *NoteFilter) (list []Note, total int64, err error) {
+	return NewNoteRepository(global.DB_).GetList(ctx, info, filter)
+}
+
+// BatchCreateNote 批量创建，每条 INSERT 语句最多 batchSize 行
+func BatchCreateNote(ctx context.Context, list []*Note, batchSize int) error {
+	return NewNoteRepository(global.DB_).BatchCreate(ctx, list, batchSize)
+}
+
+// BatchUpdateNote 在一个事务中逐条 Update
+func BatchUpdateNote(ctx context.Context, list []*Note) error {
+	return NewNoteRepository(global.DB_).BatchUpdate(ctx, list)
+}
+
+// BatchDeleteNote 按主键批量删除
+func BatchDeleteNote(ctx context.Context, ids []string) error {
+	return NewNoteRepository(global.DB_).BatchDelete(ctx, ids)
+}
+
+// GetNoteByIds 按主键批量查询
+func GetNoteByIds(ctx context.Context, ids []string) (list []Note, err error) {
+	return NewNoteRepository(global.DB_).GetByIds(ctx, ids)
+}
@@ -0,0 +1,341 @@
+// Code generated by protoc-gen-simple. DO NOT EDIT.
+// versions:
+// - protoc-gen-simple v0.0.7
+// - protoc          (unknown)
+// source: mask.proto
+
+package model
+
+import (
+	context "context"
+	mask "example.com/app/pb/mask"
+	fmt "fmt"
+	global "github.com/wwengg/simple/core/global"
+	store "github.com/wwengg/simple/core/store"
+	gorm "gorm.io/gorm"
+	clause "gorm.io/gorm/clause"
+	strings "strings"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = time.Now
+
+// Profile Model
+type Profile struct {
+	store.BASE_MODEL
+
+	Name        string        `json:"name" gorm:"column:name;type:varchar(255);"`
+	Age         *int32        `json:"age" gorm:"column:age;type:int;"`
+	ContactCase string        `json:"contactCase" gorm:"column:contact_case;type:varchar(64);"`
+	Email       *string       `json:"email" gorm:"column:email;type:varchar(255);"`
+	Phone       *string       `json:"phone" gorm:"column:phone;type:varchar(255);"`
+	Extra       *ProfileExtra `json:"extra" gorm:"column:extra;serializer:json;type:json;"`
+}
+
+// ProfileExtra holds the extra oneof of Profile, stored as JSON.
+type ProfileExtra struct {
+	A *int64  `json:"a,omitempty"`
+	B *string `json:"b,omitempty"`
+}
+
+func (model *Profile) Proto() *mask.ProfileModel {
+	proto := &mask.ProfileModel{
+		Id:        model.ID,
+		CreatedAt: model.CreatedAt.Format(time.DateTime),
+		Name:      model.Name,
+		Age:       model.Age,
+	}
+	switch model.ContactCase {
+	case "email":
+		if model.Email != nil {
+			proto.Contact = &mask.ProfileModel_Email{Email: *model.Email}
+		}
+	case "phone":
+		if model.Phone != nil {
+			proto.Contact = &mask.ProfileModel_Phone{Phone: *model.Phone}
+		}
+	}
+	if model.Extra != nil {
+		switch {
+		case model.Extra.A != nil:
+			proto.Extra = &mask.ProfileModel_A{A: *model.Extra.A}
+		case model.Extra.B != nil:
+			proto.Extra = &mask.ProfileModel_B{B: *model.Extra.B}
+		}
+	}
+	return proto
+}
+
+func ProfileProtoToModel(proto *mask.ProfileModel) *Profile {
+	model := Profile{
+		Name: proto.Name,
+		Age:  proto.Age,
+	}
+	model.ID = proto.Id
+	if t, err := time.Parse(time.DateTime, proto.CreatedAt); err == nil {
+		model.CreatedAt = t
+	}
+	switch v := proto.Contact.(type) {
+	case *mask.ProfileModel_Email:
+		model.ContactCase = "email"
+		model.Email = &v.Email
+	case *mask.ProfileModel_Phone:
+		model.ContactCase = "phone"
+		model.Phone = &v.Phone
+	}
+	switch v := proto.Extra.(type) {
+	case *mask.ProfileModel_A:
+		model.Extra = &ProfileExtra{}
+		model.Extra.A = &v.A
+	case *mask.ProfileModel_B:
+		model.Extra = &ProfileExtra{}
+		model.Extra.B = &v.B
+	}
+	return &model
+}
+
+// ProfileFilter 分页查询条件，零值的条件不生效
+type ProfileFilter struct {
+	// Sort 排序字段，"-" 前缀表示降序，可选 id, created_at, updated_at
+	Sort []string
+}
+
+var profileSortColumns = map[string]string{
+	"id":         "id",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// Scope adds the conditions and the order of f to db. An unknown sort
+// field is reported as an error of db.
+func (f *ProfileFilter) Scope(db *gorm.DB) *gorm.DB {
+	if f == nil {
+		return db
+	}
+	for _, s := range f.Sort {
+		column, ok := profileSortColumns[strings.TrimPrefix(s, "-")]
+		if !ok {
+			db.AddError(fmt.Errorf("ProfileFilter: cannot sort by %q", s))
+			return db
+		}
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: strings.HasPrefix(s, "-")})
+	}
+	return db
+}
+
+// ProfileRepository 数据访问接口
+type ProfileRepository interface {
+	// Create 创建
+	Create(ctx context.Context, a *Profile) error
+	// Delete 删除，记录不存在时返回 gorm.ErrRecordNotFound
+	Delete(ctx context.Context, a Profile) error
+	// Update 修改
+	Update(ctx context.Context, a *Profile) error
+	// UpdateFields 只修改 paths（FieldMask 的 proto 字段名）对应的列，
+	// 未知或只读的字段返回错误
+	UpdateFields(ctx context.Context, a *Profile, paths []string) error
+	// Get 查询
+	Get(ctx context.Context, id int64) (Profile, error)
+	// GetList 分页查询，filter 为 nil 时不过滤
+	GetList(ctx context.Context, info mask.PageInfo, filter *ProfileFilter) ([]Profile, int64, error)
+	// Transaction 在事务中运行 fn，fn 返回 nil 时提交；已在事务中时使用保存点
+	Transaction(ctx context.Context, fn func(repo ProfileRepository) error) error
+	// BatchCreate 批量创建，每条 INSERT 语句最多 batchSize 行
+	BatchCreate(ctx context.Context, list []*Profile, batchSize int) error
+	// BatchUpdate 在一个事务中逐条 Update
+	BatchUpdate(ctx context.Context, list []*Profile) error
+	// BatchDelete 按主键批量删除
+	BatchDelete(ctx context.Context, ids []int64) error
+	// GetByIds 按主键批量查询
+	GetByIds(ctx context.Context, ids []int64) (list []Profile, err error)
+	// Restore 恢复软删除的记录
+	Restore(ctx context.Context, a Profile) error
+	// HardDelete 永久删除，包括已软删除的记录
+	HardDelete(ctx context.Context, a Profile) error
+	// GetListWithDeleted 分页查询，包括已软删除的记录
+	GetListWithDeleted(ctx context.Context, info mask.PageInfo, filter *ProfileFilter) (list []Profile, total int64, err error)
+}
+
+// NewProfileRepository returns the ProfileRepository running on db, which
+// may be a transaction.
+func NewProfileRepository(db *gorm.DB) ProfileRepository {
+	return &profileRepository{db: db}
+}
+
+type profileRepository struct {
+	db *gorm.DB
+}
+
+func (r *profileRepository) Create(ctx context.Context, a *Profile) error {
+	return r.db.WithContext(ctx).Create(a).Error
+}
+
+func (r *profileRepository) Delete(ctx context.Context, a Profile) error {
+	result := r.db.WithContext(ctx).Delete(&a)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return result.Error
+}
+
+func (r *profileRepository) Update(ctx context.Context, a *Profile) error {
+	return r.db.WithContext(ctx).Save(a).Error
+}
+
+func (r *profileRepository) Get(ctx context.Context, id int64) (result Profile, err error) {
+	err = r.db.WithContext(ctx).Where("id = ?", id).First(&result).Error
+	return
+}
+
+func (r *profileRepository) GetList(ctx context.Context, info mask.PageInfo, filter *ProfileFilter) (list []Profile, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := filter.Scope(r.db.WithContext(ctx).Model(&Profile{}))
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Limit(int(limit)).Offset(int(offset)).Find(&list).Error
+	return
+}
+
+func (r *profileRepository) Transaction(ctx context.Context, fn func(repo ProfileRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewProfileRepository(tx))
+	})
+}
+
+// DB returns the database the repository runs on, which may be a
+// transaction.
+func (r *profileRepository) DB() *gorm.DB {
+	return r.db
+}
+
+// profileUpdateColumns maps the field mask paths of UpdateFields to the columns they
+// update.
+var profileUpdateColumns = map[string][]string{
+	"name":    {"name"},
+	"age":     {"age"},
+	"contact": {"contact_case", "email", "phone"},
+	"email":   {"contact_case", "email", "phone"},
+	"phone":   {"contact_case", "email", "phone"},
+	"extra":   {"extra"},
+	"a":       {"extra"},
+	"b":       {"extra"},
+}
+
+func (r *profileRepository) UpdateFields(ctx context.Context, a *Profile, paths []string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("UpdateProfileFields: empty field mask")
+	}
+	var columns []string
+	for _, path := range paths {
+		c, ok := profileUpdateColumns[path]
+		if !ok {
+			return fmt.Errorf("UpdateProfileFields: unknown or read-only field %q", path)
+		}
+		columns = append(columns, c...)
+	}
+	return r.db.WithContext(ctx).Model(a).Select(columns).Updates(a).Error
+}
+
+func (r *profileRepository) BatchCreate(ctx context.Context, list []*Profile, batchSize int) error {
+	return r.db.WithContext(ctx).CreateInBatches(list, batchSize).Error
+}
+
+func (r *profileRepository) BatchUpdate(ctx context.Context, list []*Profile) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, a := range list {
+			if err := NewProfileRepository(tx).Update(ctx, a); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *profileRepository) BatchDelete(ctx context.Context, ids []int64) error {
+	return r.db.WithContext(ctx).Where("id IN ?", ids).Delete(&Profile{}).Error
+}
+
+func (r *profileRepository) GetByIds(ctx context.Context, ids []int64) (list []Profile, err error) {
+	err = r.db.WithContext(ctx).Where("id IN ?", ids).Find(&list).Error
+	return
+}
+
+func (r *profileRepository) Restore(ctx context.Context, a Profile) error {
+	return r.db.WithContext(ctx).Unscoped().Model(&a).Update("deleted_at", nil).Error
+}
+
+func (r *profileRepository) HardDelete(ctx context.Context, a Profile) error {
+	return r.db.WithContext(ctx).Unscoped().Delete(&a).Error
+}
+
+func (r *profileRepository) GetListWithDeleted(ctx context.Context, info mask.PageInfo, filter *ProfileFilter) (list []Profile, total int64, err error) {
+	return (&profileRepository{db: r.db.Unscoped()}).GetList(ctx, info, filter)
+}
+
+// CreateProfile Func 创建
+func CreateProfile(ctx context.Context, a Profile) (err error) {
+	return NewProfileRepository(global.DB_).Create(ctx, &a)
+}
+
+// DeleteProfile  删除
+func DeleteProfile(ctx context.Context, a Profile) (err error) {
+	return NewProfileRepository(global.DB_).Delete(ctx, a)
+}
+
+// UpdateProfile 修改
+func UpdateProfile(ctx context.Context, a *Profile) (err error) {
+	return NewProfileRepository(global.DB_).Update(ctx, a)
+}
+
+// UpdateProfileFields 按 FieldMask 部分修改
+func UpdateProfileFields(ctx context.Context, a *Profile, paths []string) (err error) {
+	return NewProfileRepository(global.DB_).UpdateFields(ctx, a, paths)
+}
+
+// GetProfile 查询
+func GetProfile(ctx context.Context, id int64) (result Profile, err error) {
+	return NewProfileRepository(global.DB_).Get(ctx, id)
+}
+
+// GetProfileList 分页查询
+func GetProfileList(ctx context.Context, info mask.PageInfo, filter *ProfileFilter) (list []Profile, total int64, err error) {
+	return NewProfileRepository(global.DB_).GetList(ctx, info, filter)
+}
+
+// BatchCreateProfile 批量创建，每条 INSERT 语句最多 batchSize 行
+func BatchCreateProfile(ctx context.Context, list []*Profile, batchSize int) error {
+	return NewProfileRepository(global.DB_).BatchCreate(ctx, list, batchSize)
+}
+
+// BatchUpdateProfile 在一个事务中逐条 Update
+func BatchUpdateProfile(ctx context.Context, list []*Profile) error {
+	return NewProfileRepository(global.DB_).BatchUpdate(ctx, list)
+}
+
+// BatchDeleteProfile 按主键批量删除
+func BatchDeleteProfile(ctx context.Context, ids []int64) error {
+	return NewProfileRepository(global.DB_).BatchDelete(ctx, ids)
+}
+
+// GetProfileByIds 按主键批量查询
+func GetProfileByIds(ctx context.Context, ids []int64) (list []Profile, err error) {
+	return NewProfileRepository(global.DB_).GetByIds(ctx, ids)
+}
+
+// RestoreProfile 恢复软删除的记录
+func RestoreProfile(ctx context.Context, a Profile) error {
+	return NewProfileRepository(global.DB_).Restore(ctx, a)
+}
+
+// HardDeleteProfile 永久删除，包括已软删除的记录
+func HardDeleteProfile(ctx context.Context, a Profile) error {
+	return NewProfileRepository(global.DB_).HardDelete(ctx, a)
+}
+
+// GetProfileListWithDeleted 分页查询，包括已软删除的记录
+func GetProfileListWithDeleted(ctx context.Context, info mask.PageInfo, filter *ProfileFilter) (list []Profile, total int64, err error) {
+	return NewProfileRepository(global.DB_).GetListWithDeleted(ctx, info, filter)
+}
@@ -0,0 +1,29 @@
+-- Code generated by protoc-gen-simple. DO NOT EDIT.
+-- versions:
+-- - protoc-gen-simple v0.0.7
+-- - protoc          (unknown)
+-- source: mask.proto
+-- dialect: mysql
+
+CREATE TABLE `profiles` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `created_at` datetime(3),
+  `updated_at` datetime(3),
+  `deleted_at` datetime(3),
+  `name` varchar(255),
+  `age` int,
+  `contact_case` varchar(64),
+  `email` varchar(255),
+  `phone` varchar(255),
+  `extra` json,
+  PRIMARY KEY (`id`)
+);
+CREATE INDEX `idx_profiles_deleted_at` ON `profiles` (`deleted_at`);
+
+CREATE TABLE `notes` (
+  `code` varchar(255) NOT NULL,
+  `body` varchar(255),
+  `created_at` varchar(255),
+  PRIMARY KEY (`code`)
+);
+
@@ -0,0 +1,65 @@
+// Code generated by protoc-gen-simple. DO NOT EDIT.
+// versions:
+// - protoc-gen-simple v0.0.7
+// - protoc          (unknown)
+// source: page.proto
+
+package impl
+
+import (
+	context "context"
+	page "example.com/app/pb/page"
+	model "example.com/app/pb/page/model"
+	global "github.com/wwengg/simple/core/global"
+	gorm "gorm.io/gorm"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = context.TODO
+
+type Event struct {
+	// Repository is the data access of the service, by default
+	// model.NewEventRepository(s.db()).
+	Repository model.EventRepository
+	// DB is the database of the service, by default global.DB_.
+	DB *gorm.DB
+}
+
+func (s *Event) db() *gorm.DB {
+	if s.DB != nil {
+		return s.DB
+	}
+	return global.DB_
+}
+
+func (s *Event) repository() model.EventRepository {
+	if s.Repository != nil {
+		return s.Repository
+	}
+	return model.NewEventRepository(s.db())
+}
+
+// FindEventList is server rpc method as defined
+func (s *Event) FindEventList(ctx context.Context, args *page.EventListArgs, reply *page.EventListReply) (err error) {
+	*reply = page.EventListReply{}
+	listFilter := &model.EventFilter{}
+	if args.Kind != "" {
+		v := args.Kind
+		listFilter.Kind = &v
+	}
+	limit := int(args.GetPageInfo().GetPageSize())
+	if limit == 0 {
+		// 未传 page_info 时每页 10 条
+		limit = 10
+	}
+	if list, next, err := s.repository().GetPage(ctx, args.GetCursor(), limit, listFilter); err == nil {
+		for _, v := range list {
+			reply.List = append(reply.List, v.Proto())
+		}
+		reply.NextCursor = next
+		reply.Code = page.EnumCode_Success
+	} else {
+		reply.Code = page.EnumCode_FindError
+	}
+	return nil
+}
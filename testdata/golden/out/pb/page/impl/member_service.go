@@ -0,0 +1,60 @@
+// Code generated by protoc-gen-simple. DO NOT EDIT.
+// versions:
+// - protoc-gen-simple v0.0.7
+// - protoc          (unknown)
+// source: page.proto
+
+package impl
+
+import (
+	context "context"
+	page "example.com/app/pb/page"
+	model "example.com/app/pb/page/model"
+	global "github.com/wwengg/simple/core/global"
+	gorm "gorm.io/gorm"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = context.TODO
+
+type Member struct {
+	// Repository is the data access of the service, by default
+	// model.NewMemberRepository(s.db()).
+	Repository model.MemberRepository
+	// DB is the database of the service, by default global.DB_.
+	DB *gorm.DB
+}
+
+func (s *Member) db() *gorm.DB {
+	if s.DB != nil {
+		return s.DB
+	}
+	return global.DB_
+}
+
+func (s *Member) repository() model.MemberRepository {
+	if s.Repository != nil {
+		return s.Repository
+	}
+	return model.NewMemberRepository(s.db())
+}
+
+// FindMemberList is server rpc method as defined
+func (s *Member) FindMemberList(ctx context.Context, args *page.MemberListArgs, reply *page.MemberListReply) (err error) {
+	*reply = page.MemberListReply{}
+	limit := int(args.GetLimit())
+	if limit == 0 {
+		// 未传 limit 时每页 10 条
+		limit = 10
+	}
+	if list, next, err := s.repository().GetPage(ctx, args.GetCursor(), limit, nil); err == nil {
+		for _, v := range list {
+			reply.List = append(reply.List, v.Proto())
+		}
+		reply.NextCursor = next
+		reply.Code = page.EnumCode_Success
+	} else {
+		reply.Code = page.EnumCode_FindError
+	}
+	return nil
+}
@@ -0,0 +1,336 @@
+// Code generated by protoc-gen-simple. DO NOT EDIT.
+// versions:
+// - protoc-gen-simple v0.0.7
+// - protoc          (unknown)
+// source: page.proto
+
+package model
+
+import (
+	context "context"
+	base64 "encoding/base64"
+	json "encoding/json"
+	page "example.com/app/pb/page"
+	fmt "fmt"
+	global "github.com/wwengg/simple/core/global"
+	store "github.com/wwengg/simple/core/store"
+	gorm "gorm.io/gorm"
+	clause "gorm.io/gorm/clause"
+	strings "strings"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = time.Now
+
+// Event Model
+type Event struct {
+	store.BASE_MODEL
+
+	Kind string `json:"kind" gorm:"column:kind;type:varchar(255);"`
+}
+
+func (model *Event) Proto() *page.EventModel {
+	proto := &page.EventModel{
+		Id:   model.ID,
+		Kind: model.Kind,
+	}
+	return proto
+}
+
+func EventProtoToModel(proto *page.EventModel) *Event {
+	model := Event{
+		Kind: proto.Kind,
+	}
+	model.ID = proto.Id
+	return &model
+}
+
+// EventFilter 分页查询条件，零值的条件不生效
+type EventFilter struct {
+	Kind *string // kind = ?
+	// Sort 排序字段，"-" 前缀表示降序，可选 id, created_at, updated_at
+	Sort []string
+}
+
+var eventSortColumns = map[string]string{
+	"id":         "id",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// Scope adds the conditions and the order of f to db. An unknown sort
+// field is reported as an error of db.
+func (f *EventFilter) Scope(db *gorm.DB) *gorm.DB {
+	if f == nil {
+		return db
+	}
+	if f.Kind != nil {
+		db = db.Where("kind = ?", *f.Kind)
+	}
+	for _, s := range f.Sort {
+		column, ok := eventSortColumns[strings.TrimPrefix(s, "-")]
+		if !ok {
+			db.AddError(fmt.Errorf("EventFilter: cannot sort by %q", s))
+			return db
+		}
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: strings.HasPrefix(s, "-")})
+	}
+	return db
+}
+
+// EventRepository 数据访问接口
+type EventRepository interface {
+	// Create 创建
+	Create(ctx context.Context, a *Event) error
+	// Delete 删除，记录不存在时返回 gorm.ErrRecordNotFound
+	Delete(ctx context.Context, a Event) error
+	// Update 修改
+	Update(ctx context.Context, a *Event) error
+	// UpdateFields 只修改 paths（FieldMask 的 proto 字段名）对应的列，
+	// 未知或只读的字段返回错误
+	UpdateFields(ctx context.Context, a *Event, paths []string) error
+	// Get 查询
+	Get(ctx context.Context, id int64) (Event, error)
+	// GetList 分页查询，filter 为 nil 时不过滤
+	GetList(ctx context.Context, info page.PageInfo, filter *EventFilter) ([]Event, int64, error)
+	// Transaction 在事务中运行 fn，fn 返回 nil 时提交；已在事务中时使用保存点
+	Transaction(ctx context.Context, fn func(repo EventRepository) error) error
+	// BatchCreate 批量创建，每条 INSERT 语句最多 batchSize 行
+	BatchCreate(ctx context.Context, list []*Event, batchSize int) error
+	// BatchUpdate 在一个事务中逐条 Update
+	BatchUpdate(ctx context.Context, list []*Event) error
+	// BatchDelete 按主键批量删除
+	BatchDelete(ctx context.Context, ids []int64) error
+	// GetByIds 按主键批量查询
+	GetByIds(ctx context.Context, ids []int64) (list []Event, err error)
+	// Restore 恢复软删除的记录
+	Restore(ctx context.Context, a Event) error
+	// HardDelete 永久删除，包括已软删除的记录
+	HardDelete(ctx context.Context, a Event) error
+	// GetListWithDeleted 分页查询，包括已软删除的记录
+	GetListWithDeleted(ctx context.Context, info page.PageInfo, filter *EventFilter) (list []Event, total int64, err error)
+	// GetPage 游标分页查询，按主键排序，不统计总数。cursor 为上一页返回的
+	// next，第一页为空；没有下一页时 next 为空
+	GetPage(ctx context.Context, cursor string, limit int, filter *EventFilter) (list []Event, next string, err error)
+}
+
+// NewEventRepository returns the EventRepository running on db, which
+// may be a transaction.
+func NewEventRepository(db *gorm.DB) EventRepository {
+	return &eventRepository{db: db}
+}
+
+type eventRepository struct {
+	db *gorm.DB
+}
+
+func (r *eventRepository) Create(ctx context.Context, a *Event) error {
+	return r.db.WithContext(ctx).Create(a).Error
+}
+
+func (r *eventRepository) Delete(ctx context.Context, a Event) error {
+	result := r.db.WithContext(ctx).Delete(&a)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return result.Error
+}
+
+func (r *eventRepository) Update(ctx context.Context, a *Event) error {
+	return r.db.WithContext(ctx).Save(a).Error
+}
+
+func (r *eventRepository) Get(ctx context.Context, id int64) (result Event, err error) {
+	err = r.db.WithContext(ctx).Where("id = ?", id).First(&result).Error
+	return
+}
+
+func (r *eventRepository) GetList(ctx context.Context, info page.PageInfo, filter *EventFilter) (list []Event, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := filter.Scope(r.db.WithContext(ctx).Model(&Event{}))
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Limit(int(limit)).Offset(int(offset)).Find(&list).Error
+	return
+}
+
+func (r *eventRepository) Transaction(ctx context.Context, fn func(repo EventRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewEventRepository(tx))
+	})
+}
+
+// DB returns the database the repository runs on, which may be a
+// transaction.
+func (r *eventRepository) DB() *gorm.DB {
+	return r.db
+}
+
+// eventUpdateColumns maps the field mask paths of UpdateFields to the columns they
+// update.
+var eventUpdateColumns = map[string][]string{
+	"kind": {"kind"},
+}
+
+func (r *eventRepository) UpdateFields(ctx context.Context, a *Event, paths []string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("UpdateEventFields: empty field mask")
+	}
+	var columns []string
+	for _, path := range paths {
+		c, ok := eventUpdateColumns[path]
+		if !ok {
+			return fmt.Errorf("UpdateEventFields: unknown or read-only field %q", path)
+		}
+		columns = append(columns, c...)
+	}
+	return r.db.WithContext(ctx).Model(a).Select(columns).Updates(a).Error
+}
+
+func (r *eventRepository) BatchCreate(ctx context.Context, list []*Event, batchSize int) error {
+	return r.db.WithContext(ctx).CreateInBatches(list, batchSize).Error
+}
+
+func (r *eventRepository) BatchUpdate(ctx context.Context, list []*Event) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, a := range list {
+			if err := NewEventRepository(tx).Update(ctx, a); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *eventRepository) BatchDelete(ctx context.Context, ids []int64) error {
+	return r.db.WithContext(ctx).Where("id IN ?", ids).Delete(&Event{}).Error
+}
+
+func (r *eventRepository) GetByIds(ctx context.Context, ids []int64) (list []Event, err error) {
+	err = r.db.WithContext(ctx).Where("id IN ?", ids).Find(&list).Error
+	return
+}
+
+func (r *eventRepository) Restore(ctx context.Context, a Event) error {
+	return r.db.WithContext(ctx).Unscoped().Model(&a).Update("deleted_at", nil).Error
+}
+
+func (r *eventRepository) HardDelete(ctx context.Context, a Event) error {
+	return r.db.WithContext(ctx).Unscoped().Delete(&a).Error
+}
+
+func (r *eventRepository) GetListWithDeleted(ctx context.Context, info page.PageInfo, filter *EventFilter) (list []Event, total int64, err error) {
+	return (&eventRepository{db: r.db.Unscoped()}).GetList(ctx, info, filter)
+}
+
+// eventCursor is the position of the last row of a page of GetPage.
+type eventCursor struct {
+	ID int64 `json:"id"`
+}
+
+func (r *eventRepository) GetPage(ctx context.Context, cursor string, limit int, filter *EventFilter) (list []Event, next string, err error) {
+	if limit <= 0 {
+		return nil, "", fmt.Errorf("GetEventPage: limit must be positive, got %d", limit)
+	}
+	if filter != nil && len(filter.Sort) > 0 {
+		return nil, "", fmt.Errorf("GetEventPage: pages are ordered by the primary key, sort is not supported")
+	}
+	db := filter.Scope(r.db.WithContext(ctx).Model(&Event{}))
+	if cursor != "" {
+		var c eventCursor
+		b, err := base64.RawURLEncoding.DecodeString(cursor)
+		if err == nil {
+			err = json.Unmarshal(b, &c)
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("GetEventPage: invalid cursor %q", cursor)
+		}
+		db = db.Where("id > ?", c.ID)
+	}
+	if err = db.Order("id").Limit(limit + 1).Find(&list).Error; err != nil {
+		return nil, "", err
+	}
+	if len(list) > limit {
+		list = list[:limit]
+		last := list[limit-1]
+		b, err := json.Marshal(eventCursor{ID: last.ID})
+		if err != nil {
+			return nil, "", err
+		}
+		next = base64.RawURLEncoding.EncodeToString(b)
+	}
+	return list, next, nil
+}
+
+// CreateEvent Func 创建
+func CreateEvent(ctx context.Context, a Event) (err error) {
+	return NewEventRepository(global.DB_).Create(ctx, &a)
+}
+
+// DeleteEvent  删除
+func DeleteEvent(ctx context.Context, a Event) (err error) {
+	return NewEventRepository(global.DB_).Delete(ctx, a)
+}
+
+// UpdateEvent 修改
+func UpdateEvent(ctx context.Context, a *Event) (err error) {
+	return NewEventRepository(global.DB_).Update(ctx, a)
+}
+
+// UpdateEventFields 按 FieldMask 部分修改
+func UpdateEventFields(ctx context.Context, a *Event, paths []string) (err error) {
+	return NewEventRepository(global.DB_).UpdateFields(ctx, a, paths)
+}
+
+// GetEvent 查询
+func GetEvent(ctx context.Context, id int64) (result Event, err error) {
+	return NewEventRepository(global.DB_).Get(ctx, id)
+}
+
+// GetEventList 分页查询
+func GetEventList(ctx context.Context, info page.PageInfo, filter *EventFilter) (list []Event, total int64, err error) {
+	return NewEventRepository(global.DB_).GetList(ctx, info, filter)
+}
+
+// BatchCreateEvent 批量创建，每条 INSERT 语句最多 batchSize 行
+func BatchCreateEvent(ctx context.Context, list []*Event, batchSize int) error {
+	return NewEventRepository(global.DB_).BatchCreate(ctx, list, batchSize)
+}
+
+// BatchUpdateEvent 在一个事务中逐条 Update
+func BatchUpdateEvent(ctx context.Context, list []*Event) error {
+	return NewEventRepository(global.DB_).BatchUpdate(ctx, list)
+}
+
+// BatchDeleteEvent 按主键批量删除
+func BatchDeleteEvent(ctx context.Context, ids []int64) error {
+	return NewEventRepository(global.DB_).BatchDelete(ctx, ids)
+}
+
+// GetEventByIds 按主键批量查询
+func GetEventByIds(ctx context.Context, ids []int64) (list []Event, err error) {
+	return NewEventRepository(global.DB_).GetByIds(ctx, ids)
+}
+
+// RestoreEvent 恢复软删除的记录
+func RestoreEvent(ctx context.Context, a Event) error {
+	return NewEventRepository(global.DB_).Restore(ctx, a)
+}
+
+// HardDeleteEvent 永久删除，包括已软删除的记录
+func HardDeleteEvent(ctx context.Context, a Event) error {
+	return NewEventRepository(global.DB_).HardDelete(ctx, a)
+}
+
+// GetEventListWithDeleted 分页查询，包括已软删除的记录
+func GetEventListWithDeleted(ctx context.Context, info page.PageInfo, filter *EventFilter) (list []Event, total int64, err error) {
+	return NewEventRepository(global.DB_).GetListWithDeleted(ctx, info, filter)
+}
+
+// GetEventPage 游标分页查询
+func GetEventPage(ctx context.Context, cursor string, limit int, filter *EventFilter) (list []Event, next string, err error) {
+	return NewEventRepository(global.DB_).GetPage(ctx, cursor, limit, filter)
+}
@@ -0,0 +1,23 @@
+-- Code generated by protoc-gen-simple. DO NOT EDIT.
+-- versions:
+-- - protoc-gen-simple v0.0.7
+-- - protoc          (unknown)
+-- source: page.proto
+-- dialect: mysql
+
+CREATE TABLE `events` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `created_at` datetime(3),
+  `updated_at` datetime(3),
+  `deleted_at` datetime(3),
+  `kind` varchar(255),
+  PRIMARY KEY (`id`)
+);
+CREATE INDEX `idx_events_deleted_at` ON `events` (`deleted_at`);
+
+CREATE TABLE `members` (
+  `tenant_id` bigint NOT NULL,
+  `name` varchar(255) NOT NULL,
+  PRIMARY KEY (`tenant_id`, `name`)
+);
+
@@ -0,0 +1,291 @@
+// Code generated by protoc-gen-simple. DO NOT EDIT.
+// versions:
+// - protoc-gen-simple v0.0.7
+// - protoc          (unknown)
+// source: rel.proto
+
+package model
+
+import (
+	context "context"
+	rel "example.com/app/pb/rel"
+	fmt "fmt"
+	global "github.com/wwengg/simple/core/global"
+	store "github.com/wwengg/simple/core/store"
+	gorm "gorm.io/gorm"
+	clause "gorm.io/gorm/clause"
+	strings "strings"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = time.Now
+
+// Customer Model
+type Customer struct {
+	store.BASE_MODEL
+
+	Name string `json:"name" gorm:"column:name;type:varchar(255);"`
+}
+
+func (model *Customer) Proto() *rel.CustomerModel {
+	proto := &rel.CustomerModel{
+		Id:        model.ID,
+		CreatedAt: model.CreatedAt.Format(time.DateTime),
+		UpdatedAt: model.UpdatedAt.Format(time.DateTime),
+		Name:      model.Name,
+	}
+	return proto
+}
+
+func CustomerProtoToModel(proto *rel.CustomerModel) *Customer {
+	model := Customer{
+		Name: proto.Name,
+	}
+	model.ID = proto.Id
+	if t, err := time.Parse(time.DateTime, proto.CreatedAt); err == nil {
+		model.CreatedAt = t
+	}
+	if t, err := time.Parse(time.DateTime, proto.UpdatedAt); err == nil {
+		model.UpdatedAt = t
+	}
+	return &model
+}
+
+// CustomerFilter 分页查询条件，零值的条件不生效
+type CustomerFilter struct {
+	// Sort 排序字段，"-" 前缀表示降序，可选 id, created_at, updated_at
+	Sort []string
+}
+
+var customerSortColumns = map[string]string{
+	"id":         "id",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// Scope adds the conditions and the order of f to db. An unknown sort
+// field is reported as an error of db.
+func (f *CustomerFilter) Scope(db *gorm.DB) *gorm.DB {
+	if f == nil {
+		return db
+	}
+	for _, s := range f.Sort {
+		column, ok := customerSortColumns[strings.TrimPrefix(s, "-")]
+		if !ok {
+			db.AddError(fmt.Errorf("CustomerFilter: cannot sort by %q", s))
+			return db
+		}
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: strings.HasPrefix(s, "-")})
+	}
+	return db
+}
+
+// CustomerRepository 数据访问接口
+type CustomerRepository interface {
+	// Create 创建
+	Create(ctx context.Context, a *Customer) error
+	// Delete 删除，记录不存在时返回 gorm.ErrRecordNotFound
+	Delete(ctx context.Context, a Customer) error
+	// Update 修改
+	Update(ctx context.Context, a *Customer) error
+	// UpdateFields 只修改 paths（FieldMask 的 proto 字段名）对应的列，
+	// 未知或只读的字段返回错误
+	UpdateFields(ctx context.Context, a *Customer, paths []string) error
+	// Get 查询
+	Get(ctx context.Context, id int64) (Customer, error)
+	// GetList 分页查询，filter 为 nil 时不过滤
+	GetList(ctx context.Context, info rel.PageInfo, filter *CustomerFilter) ([]Customer, int64, error)
+	// Transaction 在事务中运行 fn，fn 返回 nil 时提交；已在事务中时使用保存点
+	Transaction(ctx context.Context, fn func(repo CustomerRepository) error) error
+	// BatchCreate 批量创建，每条 INSERT 语句最多 batchSize 行
+	BatchCreate(ctx context.Context, list []*Customer, batchSize int) error
+	// BatchUpdate 在一个事务中逐条 Update
+	BatchUpdate(ctx context.Context, list []*Customer) error
+	// BatchDelete 按主键批量删除
+	BatchDelete(ctx context.Context, ids []int64) error
+	// GetByIds 按主键批量查询
+	GetByIds(ctx context.Context, ids []int64) (list []Customer, err error)
+	// Restore 恢复软删除的记录
+	Restore(ctx context.Context, a Customer) error
+	// HardDelete 永久删除，包括已软删除的记录
+	HardDelete(ctx context.Context, a Customer) error
+	// GetListWithDeleted 分页查询，包括已软删除的记录
+	GetListWithDeleted(ctx context.Context, info rel.PageInfo, filter *CustomerFilter) (list []Customer, total int64, err error)
+}
+
+// NewCustomerRepository returns the CustomerRepository running on db, which
+// may be a transaction.
+func NewCustomerRepository(db *gorm.DB) CustomerRepository {
+	return &customerRepository{db: db}
+}
+
+type customerRepository struct {
+	db *gorm.DB
+}
+
+func (r *customerRepository) Create(ctx context.Context, a *Customer) error {
+	return r.db.WithContext(ctx).Create(a).Error
+}
+
+func (r *customerRepository) Delete(ctx context.Context, a Customer) error {
+	result := r.db.WithContext(ctx).Delete(&a)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return result.Error
+}
+
+func (r *customerRepository) Update(ctx context.Context, a *Customer) error {
+	return r.db.WithContext(ctx).Save(a).Error
+}
+
+func (r *customerRepository) Get(ctx context.Context, id int64) (result Customer, err error) {
+	err = r.db.WithContext(ctx).Where("id = ?", id).First(&result).Error
+	return
+}
+
+func (r *customerRepository) GetList(ctx context.Context, info rel.PageInfo, filter *CustomerFilter) (list []Customer, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := filter.Scope(r.db.WithContext(ctx).Model(&Customer{}))
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Limit(int(limit)).Offset(int(offset)).Find(&list).Error
+	return
+}
+
+func (r *customerRepository) Transaction(ctx context.Context, fn func(repo CustomerRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewCustomerRepository(tx))
+	})
+}
+
+// DB returns the database the repository runs on, which may be a
+// transaction.
+func (r *customerRepository) DB() *gorm.DB {
+	return r.db
+}
+
+// customerUpdateColumns maps the field mask paths of UpdateFields to the columns they
+// update.
+var customerUpdateColumns = map[string][]string{
+	"name": {"name"},
+}
+
+func (r *customerRepository) UpdateFields(ctx context.Context, a *Customer, paths []string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("UpdateCustomerFields: empty field mask")
+	}
+	var columns []string
+	for _, path := range paths {
+		c, ok := customerUpdateColumns[path]
+		if !ok {
+			return fmt.Errorf("UpdateCustomerFields: unknown or read-only field %q", path)
+		}
+		columns = append(columns, c...)
+	}
+	return r.db.WithContext(ctx).Model(a).Select(columns).Updates(a).Error
+}
+
+func (r *customerRepository) BatchCreate(ctx context.Context, list []*Customer, batchSize int) error {
+	return r.db.WithContext(ctx).CreateInBatches(list, batchSize).Error
+}
+
+func (r *customerRepository) BatchUpdate(ctx context.Context, list []*Customer) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, a := range list {
+			if err := NewCustomerRepository(tx).Update(ctx, a); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *customerRepository) BatchDelete(ctx context.Context, ids []int64) error {
+	return r.db.WithContext(ctx).Where("id IN ?", ids).Delete(&Customer{}).Error
+}
+
+func (r *customerRepository) GetByIds(ctx context.Context, ids []int64) (list []Customer, err error) {
+	err = r.db.WithContext(ctx).Where("id IN ?", ids).Find(&list).Error
+	return
+}
+
+func (r *customerRepository) Restore(ctx context.Context, a Customer) error {
+	return r.db.WithContext(ctx).Unscoped().Model(&a).Update("deleted_at", nil).Error
+}
+
+func (r *customerRepository) HardDelete(ctx context.Context, a Customer) error {
+	return r.db.WithContext(ctx).Unscoped().Delete(&a).Error
+}
+
+func (r *customerRepository) GetListWithDeleted(ctx context.Context, info rel.PageInfo, filter *CustomerFilter) (list []Customer, total int64, err error) {
+	return (&customerRepository{db: r.db.Unscoped()}).GetList(ctx, info, filter)
+}
+
+// CreateCustomer Func 创建
+func CreateCustomer(ctx context.Context, a Customer) (err error) {
+	return NewCustomerRepository(global.DB_).Create(ctx, &a)
+}
+
+// DeleteCustomer  删除
+func DeleteCustomer(ctx context.Context, a Customer) (err error) {
+	return NewCustomerRepository(global.DB_).Delete(ctx, a)
+}
+
+// UpdateCustomer 修改
+func UpdateCustomer(ctx context.Context, a *Customer) (err error) {
+	return NewCustomerRepository(global.DB_).Update(ctx, a)
+}
+
+// UpdateCustomerFields 按 FieldMask 部分修改
+func UpdateCustomerFields(ctx context.Context, a *Customer, paths []string) (err error) {
+	return NewCustomerRepository(global.DB_).UpdateFields(ctx, a, paths)
+}
+
+// GetCustomer 查询
+func GetCustomer(ctx context.Context, id int64) (result Customer, err error) {
+	return NewCustomerRepository(global.DB_).Get(ctx, id)
+}
+
+// GetCustomerList 分页查询
+func GetCustomerList(ctx context.Context, info rel.PageInfo, filter *CustomerFilter) (list []Customer, total int64, err error) {
+	return NewCustomerRepository(global.DB_).GetList(ctx, info, filter)
+}
+
+// BatchCreateCustomer 批量创建，每条 INSERT 语句最多 batchSize 行
+func BatchCreateCustomer(ctx context.Context, list []*Customer, batchSize int) error {
+	return NewCustomerRepository(global.DB_).BatchCreate(ctx, list, batchSize)
+}
+
+// BatchUpdateCustomer 在一个事务中逐条 Update
+func BatchUpdateCustomer(ctx context.Context, list []*Customer) error {
+	return NewCustomerRepository(global.DB_).BatchUpdate(ctx, list)
+}
+
+// BatchDeleteCustomer 按主键批量删除
+func BatchDeleteCustomer(ctx context.Context, ids []int64) error {
+	return NewCustomerRepository(global.DB_).BatchDelete(ctx, ids)
+}
+
+// GetCustomerByIds 按主键批量查询
+func GetCustomerByIds(ctx context.Context, ids []int64) (list []Customer, err error) {
+	return NewCustomerRepository(global.DB_).GetByIds(ctx, ids)
+}
+
+// RestoreCustomer 恢复软删除的记录
+func RestoreCustomer(ctx context.Context, a Customer) error {
+	return NewCustomerRepository(global.DB_).Restore(ctx, a)
+}
+
+// HardDeleteCustomer 永久删除，包括已软删除的记录
+func HardDeleteCustomer(ctx context.Context, a Customer) error {
+	return NewCustomerRepository(global.DB_).HardDelete(ctx, a)
+}
+
+// GetCustomerListWithDeleted 分页查询，包括已软删除的记录
+func GetCustomerListWithDeleted(ctx context.Context, info rel.PageInfo, filter *CustomerFilter) (list []Customer, total int64, err error) {
+	return NewCustomerRepository(global.DB_).GetListWithDeleted(ctx, info, filter)
+}
@@ -0,0 +1,295 @@
+// Code generated by protoc-gen-simple. DO NOT EDIT.
+// versions:
+// - protoc-gen-simple v0.0.7
+// - protoc          (unknown)
+// source: rel.proto
+
+package model
+
+import (
+	context "context"
+	rel "example.com/app/pb/rel"
+	fmt "fmt"
+	global "github.com/wwengg/simple/core/global"
+	store "github.com/wwengg/simple/core/store"
+	gorm "gorm.io/gorm"
+	clause "gorm.io/gorm/clause"
+	strings "strings"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = time.Now
+
+// Item Model
+type Item struct {
+	store.BASE_MODEL
+
+	OrderId int64  `json:"orderId" gorm:"column:order_id;type:bigint;"`
+	Sku     string `json:"sku" gorm:"column:sku;type:varchar(255);"`
+}
+
+func (model *Item) Proto() *rel.ItemModel {
+	proto := &rel.ItemModel{
+		Id:        model.ID,
+		CreatedAt: model.CreatedAt.Format(time.DateTime),
+		UpdatedAt: model.UpdatedAt.Format(time.DateTime),
+		OrderId:   model.OrderId,
+		Sku:       model.Sku,
+	}
+	return proto
+}
+
+func ItemProtoToModel(proto *rel.ItemModel) *Item {
+	model := Item{
+		OrderId: proto.OrderId,
+		Sku:     proto.Sku,
+	}
+	model.ID = proto.Id
+	if t, err := time.Parse(time.DateTime, proto.CreatedAt); err == nil {
+		model.CreatedAt = t
+	}
+	if t, err := time.Parse(time.DateTime, proto.UpdatedAt); err == nil {
+		model.UpdatedAt = t
+	}
+	return &model
+}
+
+// ItemFilter 分页查询条件，零值的条件不生效
+type ItemFilter struct {
+	// Sort 排序字段，"-" 前缀表示降序，可选 id, created_at, updated_at
+	Sort []string
+}
+
+var itemSortColumns = map[string]string{
+	"id":         "id",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// Scope adds the conditions and the order of f to db. An unknown sort
+// field is reported as an error of db.
+func (f *ItemFilter) Scope(db *gorm.DB) *gorm.DB {
+	if f == nil {
+		return db
+	}
+	for _, s := range f.Sort {
+		column, ok := itemSortColumns[strings.TrimPrefix(s, "-")]
+		if !ok {
+			db.AddError(fmt.Errorf("ItemFilter: cannot sort by %q", s))
+			return db
+		}
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: strings.HasPrefix(s, "-")})
+	}
+	return db
+}
+
+// ItemRepository 数据访问接口
+type ItemRepository interface {
+	// Create 创建
+	Create(ctx context.Context, a *Item) error
+	// Delete 删除，记录不存在时返回 gorm.ErrRecordNotFound
+	Delete(ctx context.Context, a Item) error
+	// Update 修改
+	Update(ctx context.Context, a *Item) error
+	// UpdateFields 只修改 paths（FieldMask 的 proto 字段名）对应的列，
+	// 未知或只读的字段返回错误
+	UpdateFields(ctx context.Context, a *Item, paths []string) error
+	// Get 查询
+	Get(ctx context.Context, id int64) (Item, error)
+	// GetList 分页查询，filter 为 nil 时不过滤
+	GetList(ctx context.Context, info rel.PageInfo, filter *ItemFilter) ([]Item, int64, error)
+	// Transaction 在事务中运行 fn，fn 返回 nil 时提交；已在事务中时使用保存点
+	Transaction(ctx context.Context, fn func(repo ItemRepository) error) error
+	// BatchCreate 批量创建，每条 INSERT 语句最多 batchSize 行
+	BatchCreate(ctx context.Context, list []*Item, batchSize int) error
+	// BatchUpdate 在一个事务中逐条 Update
+	BatchUpdate(ctx context.Context, list []*Item) error
+	// BatchDelete 按主键批量删除
+	BatchDelete(ctx context.Context, ids []int64) error
+	// GetByIds 按主键批量查询
+	GetByIds(ctx context.Context, ids []int64) (list []Item, err error)
+	// Restore 恢复软删除的记录
+	Restore(ctx context.Context, a Item) error
+	// HardDelete 永久删除，包括已软删除的记录
+	HardDelete(ctx context.Context, a Item) error
+	// GetListWithDeleted 分页查询，包括已软删除的记录
+	GetListWithDeleted(ctx context.Context, info rel.PageInfo, filter *ItemFilter) (list []Item, total int64, err error)
+}
+
+// NewItemRepository returns the ItemRepository running on db, which
+// may be a transaction.
+func NewItemRepository(db *gorm.DB) ItemRepository {
+	return &itemRepository{db: db}
+}
+
+type itemRepository struct {
+	db *gorm.DB
+}
+
+func (r *itemRepository) Create(ctx context.Context, a *Item) error {
+	return r.db.WithContext(ctx).Create(a).Error
+}
+
+func (r *itemRepository) Delete(ctx context.Context, a Item) error {
+	result := r.db.WithContext(ctx).Delete(&a)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return result.Error
+}
+
+func (r *itemRepository) Update(ctx context.Context, a *Item) error {
+	return r.db.WithContext(ctx).Save(a).Error
+}
+
+func (r *itemRepository) Get(ctx context.Context, id int64) (result Item, err error) {
+	err = r.db.WithContext(ctx).Where("id = ?", id).First(&result).Error
+	return
+}
+
+func (r *itemRepository) GetList(ctx context.Context, info rel.PageInfo, filter *ItemFilter) (list []Item, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := filter.Scope(r.db.WithContext(ctx).Model(&Item{}))
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Limit(int(limit)).Offset(int(offset)).Find(&list).Error
+	return
+}
+
+func (r *itemRepository) Transaction(ctx context.Context, fn func(repo ItemRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewItemRepository(tx))
+	})
+}
+
+// DB returns the database the repository runs on, which may be a
+// transaction.
+func (r *itemRepository) DB() *gorm.DB {
+	return r.db
+}
+
+// itemUpdateColumns maps the field mask paths of UpdateFields to the columns they
+// update.
+var itemUpdateColumns = map[string][]string{
+	"order_id": {"order_id"},
+	"sku":      {"sku"},
+}
+
+func (r *itemRepository) UpdateFields(ctx context.Context, a *Item, paths []string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("UpdateItemFields: empty field mask")
+	}
+	var columns []string
+	for _, path := range paths {
+		c, ok := itemUpdateColumns[path]
+		if !ok {
+			return fmt.Errorf("UpdateItemFields: unknown or read-only field %q", path)
+		}
+		columns = append(columns, c...)
+	}
+	return r.db.WithContext(ctx).Model(a).Select(columns).Updates(a).Error
+}
+
+func (r *itemRepository) BatchCreate(ctx context.Context, list []*Item, batchSize int) error {
+	return r.db.WithContext(ctx).CreateInBatches(list, batchSize).Error
+}
+
+func (r *itemRepository) BatchUpdate(ctx context.Context, list []*Item) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, a := range list {
+			if err := NewItemRepository(tx).Update(ctx, a); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *itemRepository) BatchDelete(ctx context.Context, ids []int64) error {
+	return r.db.WithContext(ctx).Where("id IN ?", ids).Delete(&Item{}).Error
+}
+
+func (r *itemRepository) GetByIds(ctx context.Context, ids []int64) (list []Item, err error) {
+	err = r.db.WithContext(ctx).Where("id IN ?", ids).Find(&list).Error
+	return
+}
+
+func (r *itemRepository) Restore(ctx context.Context, a Item) error {
+	return r.db.WithContext(ctx).Unscoped().Model(&a).Update("deleted_at", nil).Error
+}
+
+func (r *itemRepository) HardDelete(ctx context.Context, a Item) error {
+	return r.db.WithContext(ctx).Unscoped().Delete(&a).Error
+}
+
+func (r *itemRepository) GetListWithDeleted(ctx context.Context, info rel.PageInfo, filter *ItemFilter) (list []Item, total int64, err error) {
+	return (&itemRepository{db: r.db.Unscoped()}).GetList(ctx, info, filter)
+}
+
+// CreateItem Func 创建
+func CreateItem(ctx context.Context, a Item) (err error) {
+	return NewItemRepository(global.DB_).Create(ctx, &a)
+}
+
+// DeleteItem  删除
+func DeleteItem(ctx context.Context, a Item) (err error) {
+	return NewItemRepository(global.DB_).Delete(ctx, a)
+}
+
+// UpdateItem 修改
+func UpdateItem(ctx context.Context, a *Item) (err error) {
+	return NewItemRepository(global.DB_).Update(ctx, a)
+}
+
+// UpdateItemFields 按 FieldMask 部分修改
+func UpdateItemFields(ctx context.Context, a *Item, paths []string) (err error) {
+	return NewItemRepository(global.DB_).UpdateFields(ctx, a, paths)
+}
+
+// GetItem 查询
+func GetItem(ctx context.Context, id int64) (result Item, err error) {
+	return NewItemRepository(global.DB_).Get(ctx, id)
+}
+
+// GetItemList 分页查询
+func GetItemList(ctx context.Context, info rel.PageInfo, filter *ItemFilter) (list []Item, total int64, err error) {
+	return NewItemRepository(global.DB_).GetList(ctx, info, filter)
+}
+
+// BatchCreateItem 批量创建，每条 INSERT 语句最多 batchSize 行
+func BatchCreateItem(ctx context.Context, list []*Item, batchSize int) error {
+	return NewItemRepository(global.DB_).BatchCreate(ctx, list, batchSize)
+}
+
+// BatchUpdateItem 在一个事务中逐条 Update
+func BatchUpdateItem(ctx context.Context, list []*Item) error {
+	return NewItemRepository(global.DB_).BatchUpdate(ctx, list)
+}
+
+// BatchDeleteItem 按主键批量删除
+func BatchDeleteItem(ctx context.Context, ids []int64) error {
+	return NewItemRepository(global.DB_).BatchDelete(ctx, ids)
+}
+
+// GetItemByIds 按主键批量查询
+func GetItemByIds(ctx context.Context, ids []int64) (list []Item, err error) {
+	return NewItemRepository(global.DB_).GetByIds(ctx, ids)
+}
+
+// RestoreItem 恢复软删除的记录
+func RestoreItem(ctx context.Context, a Item) error {
+	return NewItemRepository(global.DB_).Restore(ctx, a)
+}
+
+// HardDeleteItem 永久删除，包括已软删除的记录
+func HardDeleteItem(ctx context.Context, a Item) error {
+	return NewItemRepository(global.DB_).HardDelete(ctx, a)
+}
+
+// GetItemListWithDeleted 分页查询，包括已软删除的记录
+func GetItemListWithDeleted(ctx context.Context, info rel.PageInfo, filter *ItemFilter) (list []Item, total int64, err error) {
+	return NewItemRepository(global.DB_).GetListWithDeleted(ctx, info, filter)
+}
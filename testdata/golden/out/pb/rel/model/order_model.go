@@ -0,0 +1,336 @@
+// Code generated by protoc-gen-simple. DO NOT EDIT.
+// versions:
+// - protoc-gen-simple v0.0.7
+// - protoc          (unknown)
+// source: rel.proto
+
+package model
+
+import (
+	context "context"
+	rel "example.com/app/pb/rel"
+	fmt "fmt"
+	global "github.com/wwengg/simple/core/global"
+	store "github.com/wwengg/simple/core/store"
+	gorm "gorm.io/gorm"
+	clause "gorm.io/gorm/clause"
+	strings "strings"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = time.Now
+
+// Order Model
+type Order struct {
+	store.BASE_MODEL
+
+	Tags       []string             `json:"tags" gorm:"column:tags;serializer:json;type:json;"`
+	Counters   map[string]int64     `json:"counters" gorm:"column:counters;serializer:json;type:json;"`
+	Addr       *rel.Addr            `json:"addr" gorm:"column:addr;serializer:protojson;type:json;"`
+	History    []*rel.Addr          `json:"history" gorm:"column:history;serializer:protojson;type:json;"`
+	Colors     []rel.Color          `json:"colors" gorm:"column:colors;serializer:json;type:json;"`
+	Addrs      map[string]*rel.Addr `json:"addrs" gorm:"column:addrs;serializer:protojson;type:json;"`
+	Items      []Item               `json:"items" gorm:"foreignKey:OrderId"`
+	CustomerId int64                `json:"customerId" gorm:"column:customer_id;type:bigint;index;"`
+	Customer   *Customer            `json:"customer" gorm:"foreignKey:CustomerId"`
+}
+
+// PreloadOrder preloads the relations of Order.
+func PreloadOrder(db *gorm.DB) *gorm.DB {
+	return db.Preload("Items").Preload("Customer")
+}
+
+func (model *Order) Proto() *rel.OrderModel {
+	proto := &rel.OrderModel{
+		Id:        model.ID,
+		CreatedAt: model.CreatedAt.Format(time.DateTime),
+		UpdatedAt: model.UpdatedAt.Format(time.DateTime),
+		Tags:      model.Tags,
+		Counters:  model.Counters,
+		Addr:      model.Addr,
+		History:   model.History,
+		Colors:    model.Colors,
+		Addrs:     model.Addrs,
+	}
+	for _, v := range model.Items {
+		proto.Items = append(proto.Items, v.Proto())
+	}
+	if model.Customer != nil {
+		proto.Customer = model.Customer.Proto()
+	}
+	return proto
+}
+
+func OrderProtoToModel(proto *rel.OrderModel) *Order {
+	model := Order{
+		Tags:     proto.Tags,
+		Counters: proto.Counters,
+		Addr:     proto.Addr,
+		History:  proto.History,
+		Colors:   proto.Colors,
+		Addrs:    proto.Addrs,
+	}
+	model.ID = proto.Id
+	if t, err := time.Parse(time.DateTime, proto.CreatedAt); err == nil {
+		model.CreatedAt = t
+	}
+	if t, err := time.Parse(time.DateTime, proto.UpdatedAt); err == nil {
+		model.UpdatedAt = t
+	}
+	for _, v := range proto.Items {
+		model.Items = append(model.Items, *ItemProtoToModel(v))
+	}
+	if proto.Customer != nil {
+		model.Customer = CustomerProtoToModel(proto.Customer)
+		model.CustomerId = model.Customer.ID
+	}
+	return &model
+}
+
+// OrderFilter 分页查询条件，零值的条件不生效
+type OrderFilter struct {
+	// Sort 排序字段，"-" 前缀表示降序，可选 id, created_at, updated_at
+	Sort []string
+}
+
+var orderSortColumns = map[string]string{
+	"id":         "id",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// Scope adds the conditions and the order of f to db. An unknown sort
+// field is reported as an error of db.
+func (f *OrderFilter) Scope(db *gorm.DB) *gorm.DB {
+	if f == nil {
+		return db
+	}
+	for _, s := range f.Sort {
+		column, ok := orderSortColumns[strings.TrimPrefix(s, "-")]
+		if !ok {
+			db.AddError(fmt.Errorf("OrderFilter: cannot sort by %q", s))
+			return db
+		}
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: strings.HasPrefix(s, "-")})
+	}
+	return db
+}
+
+// OrderRepository 数据访问接口
+type OrderRepository interface {
+	// Create 创建
+	Create(ctx context.Context, a *Order) error
+	// Delete 删除，记录不存在时返回 gorm.ErrRecordNotFound
+	Delete(ctx context.Context, a Order) error
+	// Update 修改
+	Update(ctx context.Context, a *Order) error
+	// UpdateFields 只修改 paths（FieldMask 的 proto 字段名）对应的列，
+	// 未知或只读的字段返回错误
+	UpdateFields(ctx context.Context, a *Order, paths []string) error
+	// Get 查询
+	Get(ctx context.Context, id int64) (Order, error)
+	// GetList 分页查询，filter 为 nil 时不过滤
+	GetList(ctx context.Context, info rel.PageInfo, filter *OrderFilter) ([]Order, int64, error)
+	// Transaction 在事务中运行 fn，fn 返回 nil 时提交；已在事务中时使用保存点
+	Transaction(ctx context.Context, fn func(repo OrderRepository) error) error
+	// BatchCreate 批量创建，每条 INSERT 语句最多 batchSize 行
+	BatchCreate(ctx context.Context, list []*Order, batchSize int) error
+	// BatchUpdate 在一个事务中逐条 Update
+	BatchUpdate(ctx context.Context, list []*Order) error
+	// BatchDelete 按主键批量删除
+	BatchDelete(ctx context.Context, ids []int64) error
+	// GetByIds 按主键批量查询
+	GetByIds(ctx context.Context, ids []int64) (list []Order, err error)
+	// Restore 恢复软删除的记录
+	Restore(ctx context.Context, a Order) error
+	// HardDelete 永久删除，包括已软删除的记录
+	HardDelete(ctx context.Context, a Order) error
+	// GetListWithDeleted 分页查询，包括已软删除的记录
+	GetListWithDeleted(ctx context.Context, info rel.PageInfo, filter *OrderFilter) (list []Order, total int64, err error)
+}
+
+// NewOrderRepository returns the OrderRepository running on db, which
+// may be a transaction.
+func NewOrderRepository(db *gorm.DB) OrderRepository {
+	return &orderRepository{db: db}
+}
+
+type orderRepository struct {
+	db *gorm.DB
+}
+
+func (r *orderRepository) Create(ctx context.Context, a *Order) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Create(a).Error
+}
+
+func (r *orderRepository) Delete(ctx context.Context, a Order) error {
+	result := r.db.WithContext(ctx).Delete(&a)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return result.Error
+}
+
+func (r *orderRepository) Update(ctx context.Context, a *Order) error {
+	omit := []string{clause.Associations}
+	if a.Customer == nil {
+		omit = append(omit, "customer_id")
+	}
+	return r.db.WithContext(ctx).Omit(omit...).Save(a).Error
+}
+
+func (r *orderRepository) Get(ctx context.Context, id int64) (result Order, err error) {
+	err = PreloadOrder(r.db.WithContext(ctx)).Where("id = ?", id).First(&result).Error
+	return
+}
+
+func (r *orderRepository) GetList(ctx context.Context, info rel.PageInfo, filter *OrderFilter) (list []Order, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := filter.Scope(r.db.WithContext(ctx).Model(&Order{}))
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = PreloadOrder(db.Limit(int(limit)).Offset(int(offset))).Find(&list).Error
+	return
+}
+
+func (r *orderRepository) Transaction(ctx context.Context, fn func(repo OrderRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewOrderRepository(tx))
+	})
+}
+
+// DB returns the database the repository runs on, which may be a
+// transaction.
+func (r *orderRepository) DB() *gorm.DB {
+	return r.db
+}
+
+// orderUpdateColumns maps the field mask paths of UpdateFields to the columns they
+// update.
+var orderUpdateColumns = map[string][]string{
+	"tags":     {"tags"},
+	"counters": {"counters"},
+	"addr":     {"addr"},
+	"history":  {"history"},
+	"colors":   {"colors"},
+	"addrs":    {"addrs"},
+}
+
+func (r *orderRepository) UpdateFields(ctx context.Context, a *Order, paths []string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("UpdateOrderFields: empty field mask")
+	}
+	var columns []string
+	for _, path := range paths {
+		c, ok := orderUpdateColumns[path]
+		if !ok {
+			return fmt.Errorf("UpdateOrderFields: unknown or read-only field %q", path)
+		}
+		columns = append(columns, c...)
+	}
+	return r.db.WithContext(ctx).Model(a).Select(columns).Updates(a).Error
+}
+
+func (r *orderRepository) BatchCreate(ctx context.Context, list []*Order, batchSize int) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).CreateInBatches(list, batchSize).Error
+}
+
+func (r *orderRepository) BatchUpdate(ctx context.Context, list []*Order) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, a := range list {
+			if err := NewOrderRepository(tx).Update(ctx, a); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *orderRepository) BatchDelete(ctx context.Context, ids []int64) error {
+	return r.db.WithContext(ctx).Where("id IN ?", ids).Delete(&Order{}).Error
+}
+
+func (r *orderRepository) GetByIds(ctx context.Context, ids []int64) (list []Order, err error) {
+	err = PreloadOrder(r.db.WithContext(ctx)).Where("id IN ?", ids).Find(&list).Error
+	return
+}
+
+func (r *orderRepository) Restore(ctx context.Context, a Order) error {
+	return r.db.WithContext(ctx).Unscoped().Model(&a).Update("deleted_at", nil).Error
+}
+
+func (r *orderRepository) HardDelete(ctx context.Context, a Order) error {
+	return r.db.WithContext(ctx).Unscoped().Delete(&a).Error
+}
+
+func (r *orderRepository) GetListWithDeleted(ctx context.Context, info rel.PageInfo, filter *OrderFilter) (list []Order, total int64, err error) {
+	return (&orderRepository{db: r.db.Unscoped()}).GetList(ctx, info, filter)
+}
+
+// CreateOrder Func 创建
+func CreateOrder(ctx context.Context, a Order) (err error) {
+	return NewOrderRepository(global.DB_).Create(ctx, &a)
+}
+
+// DeleteOrder  删除
+func DeleteOrder(ctx context.Context, a Order) (err error) {
+	return NewOrderRepository(global.DB_).Delete(ctx, a)
+}
+
+// UpdateOrder 修改
+func UpdateOrder(ctx context.Context, a *Order) (err error) {
+	return NewOrderRepository(global.DB_).Update(ctx, a)
+}
+
+// UpdateOrderFields 按 FieldMask 部分修改
+func UpdateOrderFields(ctx context.Context, a *Order, paths []string) (err error) {
+	return NewOrderRepository(global.DB_).UpdateFields(ctx, a, paths)
+}
+
+// GetOrder 查询
+func GetOrder(ctx context.Context, id int64) (result Order, err error) {
+	return NewOrderRepository(global.DB_).Get(ctx, id)
+}
+
+// GetOrderList 分页查询
+func GetOrderList(ctx context.Context, info rel.PageInfo, filter *OrderFilter) (list []Order, total int64, err error) {
+	return NewOrderRepository(global.DB_).GetList(ctx, info, filter)
+}
+
+// BatchCreateOrder 批量创建，每条 INSERT 语句最多 batchSize 行
+func BatchCreateOrder(ctx context.Context, list []*Order, batchSize int) error {
+	return NewOrderRepository(global.DB_).BatchCreate(ctx, list, batchSize)
+}
+
+// BatchUpdateOrder 在一个事务中逐条 Update
+func BatchUpdateOrder(ctx context.Context, list []*Order) error {
+	return NewOrderRepository(global.DB_).BatchUpdate(ctx, list)
+}
+
+// BatchDeleteOrder 按主键批量删除
+func BatchDeleteOrder(ctx context.Context, ids []int64) error {
+	return NewOrderRepository(global.DB_).BatchDelete(ctx, ids)
+}
+
+// GetOrderByIds 按主键批量查询
+func GetOrderByIds(ctx context.Context, ids []int64) (list []Order, err error) {
+	return NewOrderRepository(global.DB_).GetByIds(ctx, ids)
+}
+
+// RestoreOrder 恢复软删除的记录
+func RestoreOrder(ctx context.Context, a Order) error {
+	return NewOrderRepository(global.DB_).Restore(ctx, a)
+}
+
+// HardDeleteOrder 永久删除，包括已软删除的记录
+func HardDeleteOrder(ctx context.Context, a Order) error {
+	return NewOrderRepository(global.DB_).HardDelete(ctx, a)
+}
+
+// GetOrderListWithDeleted 分页查询，包括已软删除的记录
+func GetOrderListWithDeleted(ctx context.Context, info rel.PageInfo, filter *OrderFilter) (list []Order, total int64, err error) {
+	return NewOrderRepository(global.DB_).GetListWithDeleted(ctx, info, filter)
+}
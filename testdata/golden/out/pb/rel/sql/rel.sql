@@ -0,0 +1,45 @@
+-- Code generated by protoc-gen-simple. DO NOT EDIT.
+-- versions:
+-- - protoc-gen-simple v0.0.7
+-- - protoc          (unknown)
+-- source: rel.proto
+-- dialect: mysql
+
+CREATE TABLE `orders` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `created_at` datetime(3),
+  `updated_at` datetime(3),
+  `deleted_at` datetime(3),
+  `tags` json,
+  `counters` json,
+  `addr` json,
+  `history` json,
+  `colors` json,
+  `addrs` json,
+  `customer_id` bigint,
+  PRIMARY KEY (`id`)
+);
+CREATE INDEX `idx_orders_deleted_at` ON `orders` (`deleted_at`);
+CREATE INDEX `idx_orders_customer_id` ON `orders` (`customer_id`);
+
+CREATE TABLE `items` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `created_at` datetime(3),
+  `updated_at` datetime(3),
+  `deleted_at` datetime(3),
+  `order_id` bigint,
+  `sku` varchar(255),
+  PRIMARY KEY (`id`)
+);
+CREATE INDEX `idx_items_deleted_at` ON `items` (`deleted_at`);
+
+CREATE TABLE `customers` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `created_at` datetime(3),
+  `updated_at` datetime(3),
+  `deleted_at` datetime(3),
+  `name` varchar(255),
+  PRIMARY KEY (`id`)
+);
+CREATE INDEX `idx_customers_deleted_at` ON `customers` (`deleted_at`);
+
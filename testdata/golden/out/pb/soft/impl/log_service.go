@@ -0,0 +1,53 @@
+// Code generated by protoc-gen-simple. DO NOT EDIT.
+// versions:
+// - protoc-gen-simple v0.0.7
+// - protoc          (unknown)
+// source: soft.proto
+
+package impl
+
+import (
+	context "context"
+	soft "example.com/app/pb/soft"
+	model "example.com/app/pb/soft/model"
+	global "github.com/wwengg/simple/core/global"
+	store "github.com/wwengg/simple/core/store"
+	gorm "gorm.io/gorm"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = context.TODO
+
+type Log struct {
+	// Repository is the data access of the service, by default
+	// model.NewLogRepository(s.db()).
+	Repository model.LogRepository
+	// DB is the database of the service, by default global.DB_.
+	DB *gorm.DB
+}
+
+func (s *Log) db() *gorm.DB {
+	if s.DB != nil {
+		return s.DB
+	}
+	return global.DB_
+}
+
+func (s *Log) repository() model.LogRepository {
+	if s.Repository != nil {
+		return s.Repository
+	}
+	return model.NewLogRepository(s.db())
+}
+
+// PurgeLog is server rpc method as defined
+func (s *Log) PurgeLog(ctx context.Context, args *soft.LogModel, reply *soft.CommonReply) (err error) {
+	*reply = soft.CommonReply{}
+	if err = s.repository().Delete(ctx, model.Log{BASE_MODEL: store.BASE_MODEL{ID: args.Id}}); err == nil {
+		reply.Code = soft.EnumCode_Success
+	} else {
+		reply.Code = soft.EnumCode_DeleteError
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+// Code generated by protoc-gen-simple. DO NOT EDIT.
+// versions:
+// - protoc-gen-simple v0.0.7
+// - protoc          (unknown)
+// source: soft.proto
+
+package impl
+
+import (
+	context "context"
+	soft "example.com/app/pb/soft"
+	model "example.com/app/pb/soft/model"
+	global "github.com/wwengg/simple/core/global"
+	store "github.com/wwengg/simple/core/store"
+	gorm "gorm.io/gorm"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = context.TODO
+
+type Post struct {
+	// Repository is the data access of the service, by default
+	// model.NewPostRepository(s.db()).
+	Repository model.PostRepository
+	// DB is the database of the service, by default global.DB_.
+	DB *gorm.DB
+}
+
+func (s *Post) db() *gorm.DB {
+	if s.DB != nil {
+		return s.DB
+	}
+	return global.DB_
+}
+
+func (s *Post) repository() model.PostRepository {
+	if s.Repository != nil {
+		return s.Repository
+	}
+	return model.NewPostRepository(s.db())
+}
+
+// RestorePost is server rpc method as defined
+func (s *Post) RestorePost(ctx context.Context, args *soft.PostModel, reply *soft.CommonReply) (err error) {
+	*reply = soft.CommonReply{}
+	if err = s.repository().Restore(ctx, model.Post{BASE_MODEL: store.BASE_MODEL{ID: args.Id}}); err == nil {
+		reply.Code = soft.EnumCode_Success
+	} else {
+		reply.Code = soft.EnumCode_UpdateError
+	}
+
+	return nil
+}
+
+// PurgePost is server rpc method as defined
+func (s *Post) PurgePost(ctx context.Context, args *soft.PostModel, reply *soft.CommonReply) (err error) {
+	*reply = soft.CommonReply{}
+	if err = s.repository().HardDelete(ctx, model.Post{BASE_MODEL: store.BASE_MODEL{ID: args.Id}}); err == nil {
+		reply.Code = soft.EnumCode_Success
+	} else {
+		reply.Code = soft.EnumCode_DeleteError
+	}
+
+	return nil
+}
@@ -0,0 +1,250 @@
+// Code generated by protoc-gen-simple. DO NOT EDIT.
+// versions:
+// - protoc-gen-simple v0.0.7
+// - protoc          (unknown)
+// source: soft.proto
+
+package model
+
+import (
+	context "context"
+	soft "example.com/app/pb/soft"
+	fmt "fmt"
+	global "github.com/wwengg/simple/core/global"
+	store "github.com/wwengg/simple/core/store"
+	gorm "gorm.io/gorm"
+	clause "gorm.io/gorm/clause"
+	strings "strings"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = time.Now
+
+// Log Model
+type Log struct {
+	store.BASE_MODEL
+
+	Line string `json:"line" gorm:"column:line;type:varchar(255);"`
+}
+
+func (model *Log) Proto() *soft.LogModel {
+	proto := &soft.LogModel{
+		Id:   model.ID,
+		Line: model.Line,
+	}
+	return proto
+}
+
+func LogProtoToModel(proto *soft.LogModel) *Log {
+	model := Log{
+		Line: proto.Line,
+	}
+	model.ID = proto.Id
+	return &model
+}
+
+// LogFilter 分页查询条件，零值的条件不生效
+type LogFilter struct {
+	// Sort 排序字段，"-" 前缀表示降序，可选 id, created_at, updated_at
+	Sort []string
+}
+
+var logSortColumns = map[string]string{
+	"id":         "id",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// Scope adds the conditions and the order of f to db. An unknown sort
+// field is reported as an error of db.
+func (f *LogFilter) Scope(db *gorm.DB) *gorm.DB {
+	if f == nil {
+		return db
+	}
+	for _, s := range f.Sort {
+		column, ok := logSortColumns[strings.TrimPrefix(s, "-")]
+		if !ok {
+			db.AddError(fmt.Errorf("LogFilter: cannot sort by %q", s))
+			return db
+		}
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: strings.HasPrefix(s, "-")})
+	}
+	return db
+}
+
+// LogRepository 数据访问接口
+type LogRepository interface {
+	// Create 创建
+	Create(ctx context.Context, a *Log) error
+	// Delete 删除，记录不存在时返回 gorm.ErrRecordNotFound
+	Delete(ctx context.Context, a Log) error
+	// Update 修改
+	Update(ctx context.Context, a *Log) error
+	// UpdateFields 只修改 paths（FieldMask 的 proto 字段名）对应的列，
+	// 未知或只读的字段返回错误
+	UpdateFields(ctx context.Context, a *Log, paths []string) error
+	// Get 查询
+	Get(ctx context.Context, id int64) (Log, error)
+	// GetList 分页查询，filter 为 nil 时不过滤
+	GetList(ctx context.Context, info soft.PageInfo, filter *LogFilter) ([]Log, int64, error)
+	// Transaction 在事务中运行 fn，fn 返回 nil 时提交；已在事务中时使用保存点
+	Transaction(ctx context.Context, fn func(repo LogRepository) error) error
+	// BatchCreate 批量创建，每条 INSERT 语句最多 batchSize 行
+	BatchCreate(ctx context.Context, list []*Log, batchSize int) error
+	// BatchUpdate 在一个事务中逐条 Update
+	BatchUpdate(ctx context.Context, list []*Log) error
+	// BatchDelete 按主键批量删除
+	BatchDelete(ctx context.Context, ids []int64) error
+	// GetByIds 按主键批量查询
+	GetByIds(ctx context.Context, ids []int64) (list []Log, err error)
+}
+
+// NewLogRepository returns the LogRepository running on db, which
+// may be a transaction.
+func NewLogRepository(db *gorm.DB) LogRepository {
+	return &logRepository{db: db.Unscoped()}
+}
+
+type logRepository struct {
+	db *gorm.DB
+}
+
+func (r *logRepository) Create(ctx context.Context, a *Log) error {
+	return r.db.WithContext(ctx).Create(a).Error
+}
+
+func (r *logRepository) Delete(ctx context.Context, a Log) error {
+	result := r.db.WithContext(ctx).Delete(&a)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return result.Error
+}
+
+func (r *logRepository) Update(ctx context.Context, a *Log) error {
+	return r.db.WithContext(ctx).Save(a).Error
+}
+
+func (r *logRepository) Get(ctx context.Context, id int64) (result Log, err error) {
+	err = r.db.WithContext(ctx).Where("id = ?", id).First(&result).Error
+	return
+}
+
+func (r *logRepository) GetList(ctx context.Context, info soft.PageInfo, filter *LogFilter) (list []Log, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := filter.Scope(r.db.WithContext(ctx).Model(&Log{}))
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Limit(int(limit)).Offset(int(offset)).Find(&list).Error
+	return
+}
+
+func (r *logRepository) Transaction(ctx context.Context, fn func(repo LogRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewLogRepository(tx))
+	})
+}
+
+// DB returns the database the repository runs on, which may be a
+// transaction.
+func (r *logRepository) DB() *gorm.DB {
+	return r.db
+}
+
+// logUpdateColumns maps the field mask paths of UpdateFields to the columns they
+// update.
+var logUpdateColumns = map[string][]string{
+	"line": {"line"},
+}
+
+func (r *logRepository) UpdateFields(ctx context.Context, a *Log, paths []string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("UpdateLogFields: empty field mask")
+	}
+	var columns []string
+	for _, path := range paths {
+		c, ok := logUpdateColumns[path]
+		if !ok {
+			return fmt.Errorf("UpdateLogFields: unknown or read-only field %q", path)
+		}
+		columns = append(columns, c...)
+	}
+	return r.db.WithContext(ctx).Model(a).Select(columns).Updates(a).Error
+}
+
+func (r *logRepository) BatchCreate(ctx context.Context, list []*Log, batchSize int) error {
+	return r.db.WithContext(ctx).CreateInBatches(list, batchSize).Error
+}
+
+func (r *logRepository) BatchUpdate(ctx context.Context, list []*Log) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, a := range list {
+			if err := NewLogRepository(tx).Update(ctx, a); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *logRepository) BatchDelete(ctx context.Context, ids []int64) error {
+	return r.db.WithContext(ctx).Where("id IN ?", ids).Delete(&Log{}).Error
+}
+
+func (r *logRepository) GetByIds(ctx context.Context, ids []int64) (list []Log, err error) {
+	err = r.db.WithContext(ctx).Where("id IN ?", ids).Find(&list).Error
+	return
+}
+
+// CreateLog Func 创建
+func CreateLog(ctx context.Context, a Log) (err error) {
+	return NewLogRepository(global.DB_).Create(ctx, &a)
+}
+
+// DeleteLog  删除
+func DeleteLog(ctx context.Context, a Log) (err error) {
+	return NewLogRepository(global.DB_).Delete(ctx, a)
+}
+
+// UpdateLog 修改
+func UpdateLog(ctx context.Context, a *Log) (err error) {
+	return NewLogRepository(global.DB_).Update(ctx, a)
+}
+
+// UpdateLogFields 按 FieldMask 部分修改
+func UpdateLogFields(ctx context.Context, a *Log, paths []string) (err error) {
+	return NewLogRepository(global.DB_).UpdateFields(ctx, a, paths)
+}
+
+// GetLog 查询
+func GetLog(ctx context.Context, id int64) (result Log, err error) {
+	return NewLogRepository(global.DB_).Get(ctx, id)
+}
+
+// GetLogList 分页查询
+func GetLogList(ctx context.Context, info soft.PageInfo, filter *LogFilter) (list []Log, total int64, err error) {
+	return NewLogRepository(global.DB_).GetList(ctx, info, filter)
+}
+
+// BatchCreateLog 批量创建，每条 INSERT 语句最多 batchSize 行
+func BatchCreateLog(ctx context.Context, list []*Log, batchSize int) error {
+	return NewLogRepository(global.DB_).BatchCreate(ctx, list, batchSize)
+}
+
+// BatchUpdateLog 在一个事务中逐条 Update
+func BatchUpdateLog(ctx context.Context, list []*Log) error {
+	return NewLogRepository(global.DB_).BatchUpdate(ctx, list)
+}
+
+// BatchDeleteLog 按主键批量删除
+func BatchDeleteLog(ctx context.Context, ids []int64) error {
+	return NewLogRepository(global.DB_).BatchDelete(ctx, ids)
+}
+
+// GetLogByIds 按主键批量查询
+func GetLogByIds(ctx context.Context, ids []int64) (list []Log, err error) {
+	return NewLogRepository(global.DB_).GetByIds(ctx, ids)
+}
@@ -0,0 +1,283 @@
+// Code generated by protoc-gen-simple. DO NOT EDIT.
+// versions:
+// - protoc-gen-simple v0.0.7
+// - protoc          (unknown)
+// source: soft.proto
+
+package model
+
+import (
+	context "context"
+	soft "example.com/app/pb/soft"
+	fmt "fmt"
+	global "github.com/wwengg/simple/core/global"
+	store "github.com/wwengg/simple/core/store"
+	gorm "gorm.io/gorm"
+	clause "gorm.io/gorm/clause"
+	strings "strings"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = time.Now
+
+// Post Model
+type Post struct {
+	store.BASE_MODEL
+
+	Title string `json:"title" gorm:"column:title;type:varchar(255);"`
+}
+
+func (model *Post) Proto() *soft.PostModel {
+	proto := &soft.PostModel{
+		Id:    model.ID,
+		Title: model.Title,
+	}
+	return proto
+}
+
+func PostProtoToModel(proto *soft.PostModel) *Post {
+	model := Post{
+		Title: proto.Title,
+	}
+	model.ID = proto.Id
+	return &model
+}
+
+// PostFilter 分页查询条件，零值的条件不生效
+type PostFilter struct {
+	// Sort 排序字段，"-" 前缀表示降序，可选 id, created_at, updated_at
+	Sort []string
+}
+
+var postSortColumns = map[string]string{
+	"id":         "id",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// Scope adds the conditions and the order of f to db. An unknown sort
+// field is reported as an error of db.
+func (f *PostFilter) Scope(db *gorm.DB) *gorm.DB {
+	if f == nil {
+		return db
+	}
+	for _, s := range f.Sort {
+		column, ok := postSortColumns[strings.TrimPrefix(s, "-")]
+		if !ok {
+			db.AddError(fmt.Errorf("PostFilter: cannot sort by %q", s))
+			return db
+		}
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: strings.HasPrefix(s, "-")})
+	}
+	return db
+}
+
+// PostRepository 数据访问接口
+type PostRepository interface {
+	// Create 创建
+	Create(ctx context.Context, a *Post) error
+	// Delete 删除，记录不存在时返回 gorm.ErrRecordNotFound
+	Delete(ctx context.Context, a Post) error
+	// Update 修改
+	Update(ctx context.Context, a *Post) error
+	// UpdateFields 只修改 paths（FieldMask 的 proto 字段名）对应的列，
+	// 未知或只读的字段返回错误
+	UpdateFields(ctx context.Context, a *Post, paths []string) error
+	// Get 查询
+	Get(ctx context.Context, id int64) (Post, error)
+	// GetList 分页查询，filter 为 nil 时不过滤
+	GetList(ctx context.Context, info soft.PageInfo, filter *PostFilter) ([]Post, int64, error)
+	// Transaction 在事务中运行 fn，fn 返回 nil 时提交；已在事务中时使用保存点
+	Transaction(ctx context.Context, fn func(repo PostRepository) error) error
+	// BatchCreate 批量创建，每条 INSERT 语句最多 batchSize 行
+	BatchCreate(ctx context.Context, list []*Post, batchSize int) error
+	// BatchUpdate 在一个事务中逐条 Update
+	BatchUpdate(ctx context.Context, list []*Post) error
+	// BatchDelete 按主键批量删除
+	BatchDelete(ctx context.Context, ids []int64) error
+	// GetByIds 按主键批量查询
+	GetByIds(ctx context.Context, ids []int64) (list []Post, err error)
+	// Restore 恢复软删除的记录
+	Restore(ctx context.Context, a Post) error
+	// HardDelete 永久删除，包括已软删除的记录
+	HardDelete(ctx context.Context, a Post) error
+	// GetListWithDeleted 分页查询，包括已软删除的记录
+	GetListWithDeleted(ctx context.Context, info soft.PageInfo, filter *PostFilter) (list []Post, total int64, err error)
+}
+
+// NewPostRepository returns the PostRepository running on db, which
+// may be a transaction.
+func NewPostRepository(db *gorm.DB) PostRepository {
+	return &postRepository{db: db}
+}
+
+type postRepository struct {
+	db *gorm.DB
+}
+
+func (r *postRepository) Create(ctx context.Context, a *Post) error {
+	return r.db.WithContext(ctx).Create(a).Error
+}
+
+func (r *postRepository) Delete(ctx context.Context, a Post) error {
+	result := r.db.WithContext(ctx).Delete(&a)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return result.Error
+}
+
+func (r *postRepository) Update(ctx context.Context, a *Post) error {
+	return r.db.WithContext(ctx).Save(a).Error
+}
+
+func (r *postRepository) Get(ctx context.Context, id int64) (result Post, err error) {
+	err = r.db.WithContext(ctx).Where("id = ?", id).First(&result).Error
+	return
+}
+
+func (r *postRepository) GetList(ctx context.Context, info soft.PageInfo, filter *PostFilter) (list []Post, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := filter.Scope(r.db.WithContext(ctx).Model(&Post{}))
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Limit(int(limit)).Offset(int(offset)).Find(&list).Error
+	return
+}
+
+func (r *postRepository) Transaction(ctx context.Context, fn func(repo PostRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewPostRepository(tx))
+	})
+}
+
+// DB returns the database the repository runs on, which may be a
+// transaction.
+func (r *postRepository) DB() *gorm.DB {
+	return r.db
+}
+
+// postUpdateColumns maps the field mask paths of UpdateFields to the columns they
+// update.
+var postUpdateColumns = map[string][]string{
+	"title": {"title"},
+}
+
+func (r *postRepository) UpdateFields(ctx context.Context, a *Post, paths []string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("UpdatePostFields: empty field mask")
+	}
+	var columns []string
+	for _, path := range paths {
+		c, ok := postUpdateColumns[path]
+		if !ok {
+			return fmt.Errorf("UpdatePostFields: unknown or read-only field %q", path)
+		}
+		columns = append(columns, c...)
+	}
+	return r.db.WithContext(ctx).Model(a).Select(columns).Updates(a).Error
+}
+
+func (r *postRepository) BatchCreate(ctx context.Context, list []*Post, batchSize int) error {
+	return r.db.WithContext(ctx).CreateInBatches(list, batchSize).Error
+}
+
+func (r *postRepository) BatchUpdate(ctx context.Context, list []*Post) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, a := range list {
+			if err := NewPostRepository(tx).Update(ctx, a); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *postRepository) BatchDelete(ctx context.Context, ids []int64) error {
+	return r.db.WithContext(ctx).Where("id IN ?", ids).Delete(&Post{}).Error
+}
+
+func (r *postRepository) GetByIds(ctx context.Context, ids []int64) (list []Post, err error) {
+	err = r.db.WithContext(ctx).Where("id IN ?", ids).Find(&list).Error
+	return
+}
+
+func (r *postRepository) Restore(ctx context.Context, a Post) error {
+	return r.db.WithContext(ctx).Unscoped().Model(&a).Update("deleted_at", nil).Error
+}
+
+func (r *postRepository) HardDelete(ctx context.Context, a Post) error {
+	return r.db.WithContext(ctx).Unscoped().Delete(&a).Error
+}
+
+func (r *postRepository) GetListWithDeleted(ctx context.Context, info soft.PageInfo, filter *PostFilter) (list []Post, total int64, err error) {
+	return (&postRepository{db: r.db.Unscoped()}).GetList(ctx, info, filter)
+}
+
+// CreatePost Func 创建
+func CreatePost(ctx context.Context, a Post) (err error) {
+	return NewPostRepository(global.DB_).Create(ctx, &a)
+}
+
+// DeletePost  删除
+func DeletePost(ctx context.Context, a Post) (err error) {
+	return NewPostRepository(global.DB_).Delete(ctx, a)
+}
+
+// UpdatePost 修改
+func UpdatePost(ctx context.Context, a *Post) (err error) {
+	return NewPostRepository(global.DB_).Update(ctx, a)
+}
+
+// UpdatePostFields 按 FieldMask 部分修改
+func UpdatePostFields(ctx context.Context, a *Post, paths []string) (err error) {
+	return NewPostRepository(global.DB_).UpdateFields(ctx, a, paths)
+}
+
+// GetPost 查询
+func GetPost(ctx context.Context, id int64) (result Post, err error) {
+	return NewPostRepository(global.DB_).Get(ctx, id)
+}
+
+// GetPostList 分页查询
+func GetPostList(ctx context.Context, info soft.PageInfo, filter *PostFilter) (list []Post, total int64, err error) {
+	return NewPostRepository(global.DB_).GetList(ctx, info, filter)
+}
+
+// BatchCreatePost 批量创建，每条 INSERT 语句最多 batchSize 行
+func BatchCreatePost(ctx context.Context, list []*Post, batchSize int) error {
+	return NewPostRepository(global.DB_).BatchCreate(ctx, list, batchSize)
+}
+
+// BatchUpdatePost 在一个事务中逐条 Update
+func BatchUpdatePost(ctx context.Context, list []*Post) error {
+	return NewPostRepository(global.DB_).BatchUpdate(ctx, list)
+}
+
+// BatchDeletePost 按主键批量删除
+func BatchDeletePost(ctx context.Context, ids []int64) error {
+	return NewPostRepository(global.DB_).BatchDelete(ctx, ids)
+}
+
+// GetPostByIds 按主键批量查询
+func GetPostByIds(ctx context.Context, ids []int64) (list []Post, err error) {
+	return NewPostRepository(global.DB_).GetByIds(ctx, ids)
+}
+
+// RestorePost 恢复软删除的记录
+func RestorePost(ctx context.Context, a Post) error {
+	return NewPostRepository(global.DB_).Restore(ctx, a)
+}
+
+// HardDeletePost 永久删除，包括已软删除的记录
+func HardDeletePost(ctx context.Context, a Post) error {
+	return NewPostRepository(global.DB_).HardDelete(ctx, a)
+}
+
+// GetPostListWithDeleted 分页查询，包括已软删除的记录
+func GetPostListWithDeleted(ctx context.Context, info soft.PageInfo, filter *PostFilter) (list []Post, total int64, err error) {
+	return NewPostRepository(global.DB_).GetListWithDeleted(ctx, info, filter)
+}
@@ -0,0 +1,27 @@
+-- Code generated by protoc-gen-simple. DO NOT EDIT.
+-- versions:
+-- - protoc-gen-simple v0.0.7
+-- - protoc          (unknown)
+-- source: soft.proto
+-- dialect: mysql
+
+CREATE TABLE `posts` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `created_at` datetime(3),
+  `updated_at` datetime(3),
+  `deleted_at` datetime(3),
+  `title` varchar(255),
+  PRIMARY KEY (`id`)
+);
+CREATE INDEX `idx_posts_deleted_at` ON `posts` (`deleted_at`);
+
+CREATE TABLE `logs` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `created_at` datetime(3),
+  `updated_at` datetime(3),
+  `deleted_at` datetime(3),
+  `line` varchar(255),
+  PRIMARY KEY (`id`)
+);
+CREATE INDEX `idx_logs_deleted_at` ON `logs` (`deleted_at`);
+
@@ -0,0 +1,128 @@
+// Code generated by protoc-gen-simple. DO NOT EDIT.
+// versions:
+// - protoc-gen-simple v0.0.7
+// - protoc          (unknown)
+// source: tx.proto
+
+package impl
+
+import (
+	context "context"
+	errors "errors"
+	tx "example.com/app/pb/tx"
+	model "example.com/app/pb/tx/model"
+	global "github.com/wwengg/simple/core/global"
+	store "github.com/wwengg/simple/core/store"
+	gorm "gorm.io/gorm"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = context.TODO
+
+type Account struct {
+	// Repository is the data access of the service, by default
+	// model.NewAccountRepository(s.db()).
+	Repository model.AccountRepository
+	// DB is the database of the service, by default global.DB_.
+	DB *gorm.DB
+}
+
+func (s *Account) db() *gorm.DB {
+	if s.DB != nil {
+		return s.DB
+	}
+	return global.DB_
+}
+
+func (s *Account) repository() model.AccountRepository {
+	if s.Repository != nil {
+		return s.Repository
+	}
+	return model.NewAccountRepository(s.db())
+}
+
+// AccountTx holds the repositories of a transaction of Account.
+type AccountTx struct {
+	DB      *gorm.DB
+	Account model.AccountRepository
+	Ledger  model.LedgerRepository
+}
+
+// transaction runs fn in a transaction of the repository of the service,
+// committed when fn returns nil.
+func (s *Account) transaction(ctx context.Context, fn func(tx *AccountTx) error) error {
+	return s.repository().Transaction(ctx, func(repo model.AccountRepository) error {
+		tx := &AccountTx{Account: repo}
+		if r, ok := repo.(interface{ DB() *gorm.DB }); ok {
+			tx.DB = r.DB()
+			tx.Ledger = model.NewLedgerRepository(tx.DB)
+		}
+		return fn(tx)
+	})
+}
+
+// CreateAccount is server rpc method as defined
+func (s *Account) CreateAccount(ctx context.Context, args *tx.AccountModel, reply *tx.CommonReply) (err error) {
+	*reply = tx.CommonReply{}
+	rollback := errors.New("rollback")
+	err = s.transaction(ctx, func(repos *AccountTx) error {
+		if err = repos.Account.Create(ctx, model.AccountProtoToModel(args)); err == nil {
+			reply.Code = tx.EnumCode_Success
+		} else {
+			reply.Code = tx.EnumCode_CreateError
+		}
+
+		if reply.Code != tx.EnumCode_Success {
+			return rollback
+		}
+		return nil
+	})
+	if err == rollback {
+		return nil
+	}
+	return err
+}
+
+// Transfer is server rpc method as defined
+func (s *Account) Transfer(ctx context.Context, args *tx.TransferArgs, reply *tx.CommonReply) (err error) {
+	*reply = tx.CommonReply{}
+	rollback := errors.New("rollback")
+	err = s.transaction(ctx, func(repos *AccountTx) error {
+		// TODO: add business logics using repos
+
+		// TODO: setting return values
+
+		if reply.Code != tx.EnumCode_Success {
+			return rollback
+		}
+		return nil
+	})
+	if err == rollback {
+		return nil
+	}
+	return err
+}
+
+// Ping is server rpc method as defined
+func (s *Account) Ping(ctx context.Context, args *tx.Empty, reply *tx.Empty) (err error) {
+	*reply = tx.Empty{}
+	return s.transaction(ctx, func(repos *AccountTx) error {
+		// TODO: add business logics using repos
+
+		// TODO: setting return values
+
+		return err
+	})
+}
+
+// DeleteAccount is server rpc method as defined
+func (s *Account) DeleteAccount(ctx context.Context, args *tx.AccountModel, reply *tx.CommonReply) (err error) {
+	*reply = tx.CommonReply{}
+	if err = s.repository().Delete(ctx, model.Account{BASE_MODEL: store.BASE_MODEL{ID: args.Id}}); err == nil {
+		reply.Code = tx.EnumCode_Success
+	} else {
+		reply.Code = tx.EnumCode_DeleteError
+	}
+
+	return nil
+}
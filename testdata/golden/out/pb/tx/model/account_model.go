@@ -0,0 +1,283 @@
+// Code generated by protoc-gen-simple. DO NOT EDIT.
+// versions:
+// - protoc-gen-simple v0.0.7
+// - protoc          (unknown)
+// source: tx.proto
+
+package model
+
+import (
+	context "context"
+	tx "example.com/app/pb/tx"
+	fmt "fmt"
+	global "github.com/wwengg/simple/core/global"
+	store "github.com/wwengg/simple/core/store"
+	gorm "gorm.io/gorm"
+	clause "gorm.io/gorm/clause"
+	strings "strings"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = time.Now
+
+// Account Model
+type Account struct {
+	store.BASE_MODEL
+
+	Balance int64 `json:"balance" gorm:"column:balance;type:bigint;"`
+}
+
+func (model *Account) Proto() *tx.AccountModel {
+	proto := &tx.AccountModel{
+		Id:      model.ID,
+		Balance: model.Balance,
+	}
+	return proto
+}
+
+func AccountProtoToModel(proto *tx.AccountModel) *Account {
+	model := Account{
+		Balance: proto.Balance,
+	}
+	model.ID = proto.Id
+	return &model
+}
+
+// AccountFilter 分页查询条件，零值的条件不生效
+type AccountFilter struct {
+	// Sort 排序字段，"-" 前缀表示降序，可选 id, created_at, updated_at
+	Sort []string
+}
+
+var accountSortColumns = map[string]string{
+	"id":         "id",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// Scope adds the conditions and the order of f to db. An unknown sort
+// field is reported as an error of db.
+func (f *AccountFilter) Scope(db *gorm.DB) *gorm.DB {
+	if f == nil {
+		return db
+	}
+	for _, s := range f.Sort {
+		column, ok := accountSortColumns[strings.TrimPrefix(s, "-")]
+		if !ok {
+			db.AddError(fmt.Errorf("AccountFilter: cannot sort by %q", s))
+			return db
+		}
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: strings.HasPrefix(s, "-")})
+	}
+	return db
+}
+
+// AccountRepository 数据访问接口
+type AccountRepository interface {
+	// Create 创建
+	Create(ctx context.Context, a *Account) error
+	// Delete 删除，记录不存在时返回 gorm.ErrRecordNotFound
+	Delete(ctx context.Context, a Account) error
+	// Update 修改
+	Update(ctx context.Context, a *Account) error
+	// UpdateFields 只修改 paths（FieldMask 的 proto 字段名）对应的列，
+	// 未知或只读的字段返回错误
+	UpdateFields(ctx context.Context, a *Account, paths []string) error
+	// Get 查询
+	Get(ctx context.Context, id int64) (Account, error)
+	// GetList 分页查询，filter 为 nil 时不过滤
+	GetList(ctx context.Context, info tx.PageInfo, filter *AccountFilter) ([]Account, int64, error)
+	// Transaction 在事务中运行 fn，fn 返回 nil 时提交；已在事务中时使用保存点
+	Transaction(ctx context.Context, fn func(repo AccountRepository) error) error
+	// BatchCreate 批量创建，每条 INSERT 语句最多 batchSize 行
+	BatchCreate(ctx context.Context, list []*Account, batchSize int) error
+	// BatchUpdate 在一个事务中逐条 Update
+	BatchUpdate(ctx context.Context, list []*Account) error
+	// BatchDelete 按主键批量删除
+	BatchDelete(ctx context.Context, ids []int64) error
+	// GetByIds 按主键批量查询
+	GetByIds(ctx context.Context, ids []int64) (list []Account, err error)
+	// Restore 恢复软删除的记录
+	Restore(ctx context.Context, a Account) error
+	// HardDelete 永久删除，包括已软删除的记录
+	HardDelete(ctx context.Context, a Account) error
+	// GetListWithDeleted 分页查询，包括已软删除的记录
+	GetListWithDeleted(ctx context.Context, info tx.PageInfo, filter *AccountFilter) (list []Account, total int64, err error)
+}
+
+// NewAccountRepository returns the AccountRepository running on db, which
+// may be a transaction.
+func NewAccountRepository(db *gorm.DB) AccountRepository {
+	return &accountRepository{db: db}
+}
+
+type accountRepository struct {
+	db *gorm.DB
+}
+
+func (r *accountRepository) Create(ctx context.Context, a *Account) error {
+	return r.db.WithContext(ctx).Create(a).Error
+}
+
+func (r *accountRepository) Delete(ctx context.Context, a Account) error {
+	result := r.db.WithContext(ctx).Delete(&a)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return result.Error
+}
+
+func (r *accountRepository) Update(ctx context.Context, a *Account) error {
+	return r.db.WithContext(ctx).Save(a).Error
+}
+
+func (r *accountRepository) Get(ctx context.Context, id int64) (result Account, err error) {
+	err = r.db.WithContext(ctx).Where("id = ?", id).First(&result).Error
+	return
+}
+
+func (r *accountRepository) GetList(ctx context.Context, info tx.PageInfo, filter *AccountFilter) (list []Account, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := filter.Scope(r.db.WithContext(ctx).Model(&Account{}))
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Limit(int(limit)).Offset(int(offset)).Find(&list).Error
+	return
+}
+
+func (r *accountRepository) Transaction(ctx context.Context, fn func(repo AccountRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewAccountRepository(tx))
+	})
+}
+
+// DB returns the database the repository runs on, which may be a
+// transaction.
+func (r *accountRepository) DB() *gorm.DB {
+	return r.db
+}
+
+// accountUpdateColumns maps the field mask paths of UpdateFields to the columns they
+// update.
+var accountUpdateColumns = map[string][]string{
+	"balance": {"balance"},
+}
+
+func (r *accountRepository) UpdateFields(ctx context.Context, a *Account, paths []string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("UpdateAccountFields: empty field mask")
+	}
+	var columns []string
+	for _, path := range paths {
+		c, ok := accountUpdateColumns[path]
+		if !ok {
+			return fmt.Errorf("UpdateAccountFields: unknown or read-only field %q", path)
+		}
+		columns = append(columns, c...)
+	}
+	return r.db.WithContext(ctx).Model(a).Select(columns).Updates(a).Error
+}
+
+func (r *accountRepository) BatchCreate(ctx context.Context, list []*Account, batchSize int) error {
+	return r.db.WithContext(ctx).CreateInBatches(list, batchSize).Error
+}
+
+func (r *accountRepository) BatchUpdate(ctx context.Context, list []*Account) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, a := range list {
+			if err := NewAccountRepository(tx).Update(ctx, a); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *accountRepository) BatchDelete(ctx context.Context, ids []int64) error {
+	return r.db.WithContext(ctx).Where("id IN ?", ids).Delete(&Account{}).Error
+}
+
+func (r *accountRepository) GetByIds(ctx context.Context, ids []int64) (list []Account, err error) {
+	err = r.db.WithContext(ctx).Where("id IN ?", ids).Find(&list).Error
+	return
+}
+
+func (r *accountRepository) Restore(ctx context.Context, a Account) error {
+	return r.db.WithContext(ctx).Unscoped().Model(&a).Update("deleted_at", nil).Error
+}
+
+func (r *accountRepository) HardDelete(ctx context.Context, a Account) error {
+	return r.db.WithContext(ctx).Unscoped().Delete(&a).Error
+}
+
+func (r *accountRepository) GetListWithDeleted(ctx context.Context, info tx.PageInfo, filter *AccountFilter) (list []Account, total int64, err error) {
+	return (&accountRepository{db: r.db.Unscoped()}).GetList(ctx, info, filter)
+}
+
+// CreateAccount Func 创建
+func CreateAccount(ctx context.Context, a Account) (err error) {
+	return NewAccountRepository(global.DB_).Create(ctx, &a)
+}
+
+// DeleteAccount  删除
+func DeleteAccount(ctx context.Context, a Account) (err error) {
+	return NewAccountRepository(global.DB_).Delete(ctx, a)
+}
+
+// UpdateAccount 修改
+func UpdateAccount(ctx context.Context, a *Account) (err error) {
+	return NewAccountRepository(global.DB_).Update(ctx, a)
+}
+
+// UpdateAccountFields 按 FieldMask 部分修改
+func UpdateAccountFields(ctx context.Context, a *Account, paths []string) (err error) {
+	return NewAccountRepository(global.DB_).UpdateFields(ctx, a, paths)
+}
+
+// GetAccount 查询
+func GetAccount(ctx context.Context, id int64) (result Account, err error) {
+	return NewAccountRepository(global.DB_).Get(ctx, id)
+}
+
+// GetAccountList 分页查询
+func GetAccountList(ctx context.Context, info tx.PageInfo, filter *AccountFilter) (list []Account, total int64, err error) {
+	return NewAccountRepository(global.DB_).GetList(ctx, info, filter)
+}
+
+// BatchCreateAccount 批量创建，每条 INSERT 语句最多 batchSize 行
+func BatchCreateAccount(ctx context.Context, list []*Account, batchSize int) error {
+	return NewAccountRepository(global.DB_).BatchCreate(ctx, list, batchSize)
+}
+
+// BatchUpdateAccount 在一个事务中逐条 Update
+func BatchUpdateAccount(ctx context.Context, list []*Account) error {
+	return NewAccountRepository(global.DB_).BatchUpdate(ctx, list)
+}
+
+// BatchDeleteAccount 按主键批量删除
+func BatchDeleteAccount(ctx context.Context, ids []int64) error {
+	return NewAccountRepository(global.DB_).BatchDelete(ctx, ids)
+}
+
+// GetAccountByIds 按主键批量查询
+func GetAccountByIds(ctx context.Context, ids []int64) (list []Account, err error) {
+	return NewAccountRepository(global.DB_).GetByIds(ctx, ids)
+}
+
+// RestoreAccount 恢复软删除的记录
+func RestoreAccount(ctx context.Context, a Account) error {
+	return NewAccountRepository(global.DB_).Restore(ctx, a)
+}
+
+// HardDeleteAccount 永久删除，包括已软删除的记录
+func HardDeleteAccount(ctx context.Context, a Account) error {
+	return NewAccountRepository(global.DB_).HardDelete(ctx, a)
+}
+
+// GetAccountListWithDeleted 分页查询，包括已软删除的记录
+func GetAccountListWithDeleted(ctx context.Context, info tx.PageInfo, filter *AccountFilter) (list []Account, total int64, err error) {
+	return NewAccountRepository(global.DB_).GetListWithDeleted(ctx, info, filter)
+}
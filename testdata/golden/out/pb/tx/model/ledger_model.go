@@ -0,0 +1,283 @@
+// Code generated by protoc-gen-simple. DO NOT EDIT.
+// versions:
+// - protoc-gen-simple v0.0.7
+// - protoc          (unknown)
+// source: tx.proto
+
+package model
+
+import (
+	context "context"
+	tx "example.com/app/pb/tx"
+	fmt "fmt"
+	global "github.com/wwengg/simple/core/global"
+	store "github.com/wwengg/simple/core/store"
+	gorm "gorm.io/gorm"
+	clause "gorm.io/gorm/clause"
+	strings "strings"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = time.Now
+
+// Ledger Model
+type Ledger struct {
+	store.BASE_MODEL
+
+	Amount int64 `json:"amount" gorm:"column:amount;type:bigint;"`
+}
+
+func (model *Ledger) Proto() *tx.LedgerModel {
+	proto := &tx.LedgerModel{
+		Id:     model.ID,
+		Amount: model.Amount,
+	}
+	return proto
+}
+
+func LedgerProtoToModel(proto *tx.LedgerModel) *Ledger {
+	model := Ledger{
+		Amount: proto.Amount,
+	}
+	model.ID = proto.Id
+	return &model
+}
+
+// LedgerFilter 分页查询条件，零值的条件不生效
+type LedgerFilter struct {
+	// Sort 排序字段，"-" 前缀表示降序，可选 id, created_at, updated_at
+	Sort []string
+}
+
+var ledgerSortColumns = map[string]string{
+	"id":         "id",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// Scope adds the conditions and the order of f to db. An unknown sort
+// field is reported as an error of db.
+func (f *LedgerFilter) Scope(db *gorm.DB) *gorm.DB {
+	if f == nil {
+		return db
+	}
+	for _, s := range f.Sort {
+		column, ok := ledgerSortColumns[strings.TrimPrefix(s, "-")]
+		if !ok {
+			db.AddError(fmt.Errorf("LedgerFilter: cannot sort by %q", s))
+			return db
+		}
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: strings.HasPrefix(s, "-")})
+	}
+	return db
+}
+
+// LedgerRepository 数据访问接口
+type LedgerRepository interface {
+	// Create 创建
+	Create(ctx context.Context, a *Ledger) error
+	// Delete 删除，记录不存在时返回 gorm.ErrRecordNotFound
+	Delete(ctx context.Context, a Ledger) error
+	// Update 修改
+	Update(ctx context.Context, a *Ledger) error
+	// UpdateFields 只修改 paths（FieldMask 的 proto 字段名）对应的列，
+	// 未知或只读的字段返回错误
+	UpdateFields(ctx context.Context, a *Ledger, paths []string) error
+	// Get 查询
+	Get(ctx context.Context, id int64) (Ledger, error)
+	// GetList 分页查询，filter 为 nil 时不过滤
+	GetList(ctx context.Context, info tx.PageInfo, filter *LedgerFilter) ([]Ledger, int64, error)
+	// Transaction 在事务中运行 fn，fn 返回 nil 时提交；已在事务中时使用保存点
+	Transaction(ctx context.Context, fn func(repo LedgerRepository) error) error
+	// BatchCreate 批量创建，每条 INSERT 语句最多 batchSize 行
+	BatchCreate(ctx context.Context, list []*Ledger, batchSize int) error
+	// BatchUpdate 在一个事务中逐条 Update
+	BatchUpdate(ctx context.Context, list []*Ledger) error
+	// BatchDelete 按主键批量删除
+	BatchDelete(ctx context.Context, ids []int64) error
+	// GetByIds 按主键批量查询
+	GetByIds(ctx context.Context, ids []int64) (list []Ledger, err error)
+	// Restore 恢复软删除的记录
+	Restore(ctx context.Context, a Ledger) error
+	// HardDelete 永久删除，包括已软删除的记录
+	HardDelete(ctx context.Context, a Ledger) error
+	// GetListWithDeleted 分页查询，包括已软删除的记录
+	GetListWithDeleted(ctx context.Context, info tx.PageInfo, filter *LedgerFilter) (list []Ledger, total int64, err error)
+}
+
+// NewLedgerRepository returns the LedgerRepository running on db, which
+// may be a transaction.
+func NewLedgerRepository(db *gorm.DB) LedgerRepository {
+	return &ledgerRepository{db: db}
+}
+
+type ledgerRepository struct {
+	db *gorm.DB
+}
+
+func (r *ledgerRepository) Create(ctx context.Context, a *Ledger) error {
+	return r.db.WithContext(ctx).Create(a).Error
+}
+
+func (r *ledgerRepository) Delete(ctx context.Context, a Ledger) error {
+	result := r.db.WithContext(ctx).Delete(&a)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return result.Error
+}
+
+func (r *ledgerRepository) Update(ctx context.Context, a *Ledger) error {
+	return r.db.WithContext(ctx).Save(a).Error
+}
+
+func (r *ledgerRepository) Get(ctx context.Context, id int64) (result Ledger, err error) {
+	err = r.db.WithContext(ctx).Where("id = ?", id).First(&result).Error
+	return
+}
+
+func (r *ledgerRepository) GetList(ctx context.Context, info tx.PageInfo, filter *LedgerFilter) (list []Ledger, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := filter.Scope(r.db.WithContext(ctx).Model(&Ledger{}))
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Limit(int(limit)).Offset(int(offset)).Find(&list).Error
+	return
+}
+
+func (r *ledgerRepository) Transaction(ctx context.Context, fn func(repo LedgerRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewLedgerRepository(tx))
+	})
+}
+
+// DB returns the database the repository runs on, which may be a
+// transaction.
+func (r *ledgerRepository) DB() *gorm.DB {
+	return r.db
+}
+
+// ledgerUpdateColumns maps the field mask paths of UpdateFields to the columns they
+// update.
+var ledgerUpdateColumns = map[string][]string{
+	"amount": {"amount"},
+}
+
+func (r *ledgerRepository) UpdateFields(ctx context.Context, a *Ledger, paths []string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("UpdateLedgerFields: empty field mask")
+	}
+	var columns []string
+	for _, path := range paths {
+		c, ok := ledgerUpdateColumns[path]
+		if !ok {
+			return fmt.Errorf("UpdateLedgerFields: unknown or read-only field %q", path)
+		}
+		columns = append(columns, c...)
+	}
+	return r.db.WithContext(ctx).Model(a).Select(columns).Updates(a).Error
+}
+
+func (r *ledgerRepository) BatchCreate(ctx context.Context, list []*Ledger, batchSize int) error {
+	return r.db.WithContext(ctx).CreateInBatches(list, batchSize).Error
+}
+
+func (r *ledgerRepository) BatchUpdate(ctx context.Context, list []*Ledger) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, a := range list {
+			if err := NewLedgerRepository(tx).Update(ctx, a); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *ledgerRepository) BatchDelete(ctx context.Context, ids []int64) error {
+	return r.db.WithContext(ctx).Where("id IN ?", ids).Delete(&Ledger{}).Error
+}
+
+func (r *ledgerRepository) GetByIds(ctx context.Context, ids []int64) (list []Ledger, err error) {
+	err = r.db.WithContext(ctx).Where("id IN ?", ids).Find(&list).Error
+	return
+}
+
+func (r *ledgerRepository) Restore(ctx context.Context, a Ledger) error {
+	return r.db.WithContext(ctx).Unscoped().Model(&a).Update("deleted_at", nil).Error
+}
+
+func (r *ledgerRepository) HardDelete(ctx context.Context, a Ledger) error {
+	return r.db.WithContext(ctx).Unscoped().Delete(&a).Error
+}
+
+func (r *ledgerRepository) GetListWithDeleted(ctx context.Context, info tx.PageInfo, filter *LedgerFilter) (list []Ledger, total int64, err error) {
+	return (&ledgerRepository{db: r.db.Unscoped()}).GetList(ctx, info, filter)
+}
+
+// CreateLedger Func 创建
+func CreateLedger(ctx context.Context, a Ledger) (err error) {
+	return NewLedgerRepository(global.DB_).Create(ctx, &a)
+}
+
+// DeleteLedger  删除
+func DeleteLedger(ctx context.Context, a Ledger) (err error) {
+	return NewLedgerRepository(global.DB_).Delete(ctx, a)
+}
+
+// UpdateLedger 修改
+func UpdateLedger(ctx context.Context, a *Ledger) (err error) {
+	return NewLedgerRepository(global.DB_).Update(ctx, a)
+}
+
+// UpdateLedgerFields 按 FieldMask 部分修改
+func UpdateLedgerFields(ctx context.Context, a *Ledger, paths []string) (err error) {
+	return NewLedgerRepository(global.DB_).UpdateFields(ctx, a, paths)
+}
+
+// GetLedger 查询
+func GetLedger(ctx context.Context, id int64) (result Ledger, err error) {
+	return NewLedgerRepository(global.DB_).Get(ctx, id)
+}
+
+// GetLedgerList 分页查询
+func GetLedgerList(ctx context.Context, info tx.PageInfo, filter *LedgerFilter) (list []Ledger, total int64, err error) {
+	return NewLedgerRepository(global.DB_).GetList(ctx, info, filter)
+}
+
+// BatchCreateLedger 批量创建，每条 INSERT 语句最多 batchSize 行
+func BatchCreateLedger(ctx context.Context, list []*Ledger, batchSize int) error {
+	return NewLedgerRepository(global.DB_).BatchCreate(ctx, list, batchSize)
+}
+
+// BatchUpdateLedger 在一个事务中逐条 Update
+func BatchUpdateLedger(ctx context.Context, list []*Ledger) error {
+	return NewLedgerRepository(global.DB_).BatchUpdate(ctx, list)
+}
+
+// BatchDeleteLedger 按主键批量删除
+func BatchDeleteLedger(ctx context.Context, ids []int64) error {
+	return NewLedgerRepository(global.DB_).BatchDelete(ctx, ids)
+}
+
+// GetLedgerByIds 按主键批量查询
+func GetLedgerByIds(ctx context.Context, ids []int64) (list []Ledger, err error) {
+	return NewLedgerRepository(global.DB_).GetByIds(ctx, ids)
+}
+
+// RestoreLedger 恢复软删除的记录
+func RestoreLedger(ctx context.Context, a Ledger) error {
+	return NewLedgerRepository(global.DB_).Restore(ctx, a)
+}
+
+// HardDeleteLedger 永久删除，包括已软删除的记录
+func HardDeleteLedger(ctx context.Context, a Ledger) error {
+	return NewLedgerRepository(global.DB_).HardDelete(ctx, a)
+}
+
+// GetLedgerListWithDeleted 分页查询，包括已软删除的记录
+func GetLedgerListWithDeleted(ctx context.Context, info tx.PageInfo, filter *LedgerFilter) (list []Ledger, total int64, err error) {
+	return NewLedgerRepository(global.DB_).GetListWithDeleted(ctx, info, filter)
+}
@@ -0,0 +1,27 @@
+-- Code generated by protoc-gen-simple. DO NOT EDIT.
+-- versions:
+-- - protoc-gen-simple v0.0.7
+-- - protoc          (unknown)
+-- source: tx.proto
+-- dialect: mysql
+
+CREATE TABLE `accounts` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `created_at` datetime(3),
+  `updated_at` datetime(3),
+  `deleted_at` datetime(3),
+  `balance` bigint,
+  PRIMARY KEY (`id`)
+);
+CREATE INDEX `idx_accounts_deleted_at` ON `accounts` (`deleted_at`);
+
+CREATE TABLE `ledgers` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `created_at` datetime(3),
+  `updated_at` datetime(3),
+  `deleted_at` datetime(3),
+  `amount` bigint,
+  PRIMARY KEY (`id`)
+);
+CREATE INDEX `idx_ledgers_deleted_at` ON `ledgers` (`deleted_at`);
+
@@ -0,0 +1,67 @@
+// Code generated by protoc-gen-simple. DO NOT EDIT.
+// versions:
+// - protoc-gen-simple v0.0.7
+// - protoc          (unknown)
+// source: upsert.proto
+
+package impl
+
+import (
+	context "context"
+	upsert "example.com/app/pb/upsert"
+	model "example.com/app/pb/upsert/model"
+	global "github.com/wwengg/simple/core/global"
+	gorm "gorm.io/gorm"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = context.TODO
+
+type Article struct {
+	// Repository is the data access of the service, by default
+	// model.NewArticleRepository(s.db()).
+	Repository model.ArticleRepository
+	// DB is the database of the service, by default global.DB_.
+	DB *gorm.DB
+}
+
+func (s *Article) db() *gorm.DB {
+	if s.DB != nil {
+		return s.DB
+	}
+	return global.DB_
+}
+
+func (s *Article) repository() model.ArticleRepository {
+	if s.Repository != nil {
+		return s.Repository
+	}
+	return model.NewArticleRepository(s.db())
+}
+
+// UpsertArticle is server rpc method as defined
+func (s *Article) UpsertArticle(ctx context.Context, args *upsert.ArticleModel, reply *upsert.CommonReply) (err error) {
+	*reply = upsert.CommonReply{}
+	if err = s.repository().Upsert(ctx, model.ArticleProtoToModel(args)); err == nil {
+		reply.Code = upsert.EnumCode_Success
+	} else {
+		reply.Code = upsert.EnumCode_CreateError
+	}
+
+	return nil
+}
+
+// FindOrCreateArticle is server rpc method as defined
+func (s *Article) FindOrCreateArticle(ctx context.Context, args *upsert.ArticleModel, reply *upsert.ArticleReply) (err error) {
+	*reply = upsert.ArticleReply{}
+	data := model.ArticleProtoToModel(args)
+	if created, err := s.repository().FindOrCreate(ctx, data); err == nil {
+		reply.Data = data.Proto()
+		reply.Created = created
+		reply.Code = upsert.EnumCode_Success
+	} else {
+		reply.Code = upsert.EnumCode_CreateError
+	}
+
+	return nil
+}
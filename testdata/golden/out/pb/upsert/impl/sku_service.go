@@ -0,0 +1,65 @@
+// Code generated by protoc-gen-simple. DO NOT EDIT.
+// versions:
+// - protoc-gen-simple v0.0.7
+// - protoc          (unknown)
+// source: upsert.proto
+
+package impl
+
+import (
+	context "context"
+	upsert "example.com/app/pb/upsert"
+	model "example.com/app/pb/upsert/model"
+	global "github.com/wwengg/simple/core/global"
+	gorm "gorm.io/gorm"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = context.TODO
+
+type Sku struct {
+	// Repository is the data access of the service, by default
+	// model.NewSkuRepository(s.db()).
+	Repository model.SkuRepository
+	// DB is the database of the service, by default global.DB_.
+	DB *gorm.DB
+}
+
+func (s *Sku) db() *gorm.DB {
+	if s.DB != nil {
+		return s.DB
+	}
+	return global.DB_
+}
+
+func (s *Sku) repository() model.SkuRepository {
+	if s.Repository != nil {
+		return s.Repository
+	}
+	return model.NewSkuRepository(s.db())
+}
+
+// UpsertSku is server rpc method as defined
+func (s *Sku) UpsertSku(ctx context.Context, args *upsert.SkuModel, reply *upsert.CommonReply) (err error) {
+	*reply = upsert.CommonReply{}
+	if err = s.repository().Upsert(ctx, model.SkuProtoToModel(args)); err == nil {
+		reply.Code = upsert.EnumCode_Success
+	} else {
+		reply.Code = upsert.EnumCode_CreateError
+	}
+
+	return nil
+}
+
+// FindOrCreateSku is server rpc method as defined
+func (s *Sku) FindOrCreateSku(ctx context.Context, args *upsert.SkuModel, reply *upsert.CommonReply) (err error) {
+	*reply = upsert.CommonReply{}
+	data := model.SkuProtoToModel(args)
+	if _, err := s.repository().FindOrCreate(ctx, data); err == nil {
+		reply.Code = upsert.EnumCode_Success
+	} else {
+		reply.Code = upsert.EnumCode_CreateError
+	}
+
+	return nil
+}
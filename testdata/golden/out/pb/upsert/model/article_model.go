@@ -0,0 +1,362 @@
+// Code generated by protoc-gen-simple. DO NOT EDIT.
+// versions:
+// - protoc-gen-simple v0.0.7
+// - protoc          (unknown)
+// source: upsert.proto
+
+package model
+
+import (
+	context "context"
+	upsert "example.com/app/pb/upsert"
+	fmt "fmt"
+	global "github.com/wwengg/simple/core/global"
+	store "github.com/wwengg/simple/core/store"
+	gorm "gorm.io/gorm"
+	clause "gorm.io/gorm/clause"
+	strings "strings"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = time.Now
+
+// Article Model
+type Article struct {
+	store.BASE_MODEL
+
+	TenantId   int64  `json:"tenantId" gorm:"column:tenant_id;type:bigint;uniqueIndex:idx_article_tenant_id_slug,priority:1;"`
+	Slug       string `json:"slug" gorm:"column:slug;type:varchar(255);uniqueIndex:idx_article_tenant_id_slug,priority:2;"`
+	ExternalId string `json:"externalId" gorm:"column:external_id;type:varchar(255);uniqueIndex:idx_article_external_id;"`
+	Title      string `json:"title" gorm:"column:title;type:varchar(255);"`
+}
+
+func (model *Article) Proto() *upsert.ArticleModel {
+	proto := &upsert.ArticleModel{
+		Id:         model.ID,
+		TenantId:   model.TenantId,
+		Slug:       model.Slug,
+		ExternalId: model.ExternalId,
+		Title:      model.Title,
+	}
+	return proto
+}
+
+func ArticleProtoToModel(proto *upsert.ArticleModel) *Article {
+	model := Article{
+		TenantId:   proto.TenantId,
+		Slug:       proto.Slug,
+		ExternalId: proto.ExternalId,
+		Title:      proto.Title,
+	}
+	model.ID = proto.Id
+	return &model
+}
+
+// ArticleFilter 分页查询条件，零值的条件不生效
+type ArticleFilter struct {
+	// Sort 排序字段，"-" 前缀表示降序，可选 id, created_at, updated_at
+	Sort []string
+}
+
+var articleSortColumns = map[string]string{
+	"id":         "id",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// Scope adds the conditions and the order of f to db. An unknown sort
+// field is reported as an error of db.
+func (f *ArticleFilter) Scope(db *gorm.DB) *gorm.DB {
+	if f == nil {
+		return db
+	}
+	for _, s := range f.Sort {
+		column, ok := articleSortColumns[strings.TrimPrefix(s, "-")]
+		if !ok {
+			db.AddError(fmt.Errorf("ArticleFilter: cannot sort by %q", s))
+			return db
+		}
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: strings.HasPrefix(s, "-")})
+	}
+	return db
+}
+
+// ArticleRepository 数据访问接口
+type ArticleRepository interface {
+	// Create 创建
+	Create(ctx context.Context, a *Article) error
+	// Delete 删除，记录不存在时返回 gorm.ErrRecordNotFound
+	Delete(ctx context.Context, a Article) error
+	// Update 修改
+	Update(ctx context.Context, a *Article) error
+	// UpdateFields 只修改 paths（FieldMask 的 proto 字段名）对应的列，
+	// 未知或只读的字段返回错误
+	UpdateFields(ctx context.Context, a *Article, paths []string) error
+	// Get 查询
+	Get(ctx context.Context, id int64) (Article, error)
+	// GetList 分页查询，filter 为 nil 时不过滤
+	GetList(ctx context.Context, info upsert.PageInfo, filter *ArticleFilter) ([]Article, int64, error)
+	// Transaction 在事务中运行 fn，fn 返回 nil 时提交；已在事务中时使用保存点
+	Transaction(ctx context.Context, fn func(repo ArticleRepository) error) error
+	// GetByTenantIdAndSlug 按唯一索引查询
+	GetByTenantIdAndSlug(ctx context.Context, tenantId int64, slug string) (Article, error)
+	// GetByExternalId 按唯一索引查询
+	GetByExternalId(ctx context.Context, externalId string) (Article, error)
+	// BatchCreate 批量创建，每条 INSERT 语句最多 batchSize 行
+	BatchCreate(ctx context.Context, list []*Article, batchSize int) error
+	// BatchUpdate 在一个事务中逐条 Update
+	BatchUpdate(ctx context.Context, list []*Article) error
+	// BatchDelete 按主键批量删除
+	BatchDelete(ctx context.Context, ids []int64) error
+	// GetByIds 按主键批量查询
+	GetByIds(ctx context.Context, ids []int64) (list []Article, err error)
+	// Upsert 按唯一索引创建或修改
+	Upsert(ctx context.Context, a *Article) error
+	// FindOrCreate 按唯一索引查询，不存在时创建；a 为查询或创建的记录，已软删除的记录会被恢复
+	FindOrCreate(ctx context.Context, a *Article) (created bool, err error)
+	// Restore 恢复软删除的记录
+	Restore(ctx context.Context, a Article) error
+	// HardDelete 永久删除，包括已软删除的记录
+	HardDelete(ctx context.Context, a Article) error
+	// GetListWithDeleted 分页查询，包括已软删除的记录
+	GetListWithDeleted(ctx context.Context, info upsert.PageInfo, filter *ArticleFilter) (list []Article, total int64, err error)
+}
+
+// NewArticleRepository returns the ArticleRepository running on db, which
+// may be a transaction.
+func NewArticleRepository(db *gorm.DB) ArticleRepository {
+	return &articleRepository{db: db}
+}
+
+type articleRepository struct {
+	db *gorm.DB
+}
+
+func (r *articleRepository) Create(ctx context.Context, a *Article) error {
+	return r.db.WithContext(ctx).Create(a).Error
+}
+
+func (r *articleRepository) Delete(ctx context.Context, a Article) error {
+	result := r.db.WithContext(ctx).Delete(&a)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return result.Error
+}
+
+func (r *articleRepository) Update(ctx context.Context, a *Article) error {
+	return r.db.WithContext(ctx).Save(a).Error
+}
+
+func (r *articleRepository) Get(ctx context.Context, id int64) (result Article, err error) {
+	err = r.db.WithContext(ctx).Where("id = ?", id).First(&result).Error
+	return
+}
+
+func (r *articleRepository) GetList(ctx context.Context, info upsert.PageInfo, filter *ArticleFilter) (list []Article, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := filter.Scope(r.db.WithContext(ctx).Model(&Article{}))
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Limit(int(limit)).Offset(int(offset)).Find(&list).Error
+	return
+}
+
+func (r *articleRepository) Transaction(ctx context.Context, fn func(repo ArticleRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewArticleRepository(tx))
+	})
+}
+
+// DB returns the database the repository runs on, which may be a
+// transaction.
+func (r *articleRepository) DB() *gorm.DB {
+	return r.db
+}
+
+// articleUpdateColumns maps the field mask paths of UpdateFields to the columns they
+// update.
+var articleUpdateColumns = map[string][]string{
+	"tenant_id":   {"tenant_id"},
+	"slug":        {"slug"},
+	"external_id": {"external_id"},
+	"title":       {"title"},
+}
+
+func (r *articleRepository) UpdateFields(ctx context.Context, a *Article, paths []string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("UpdateArticleFields: empty field mask")
+	}
+	var columns []string
+	for _, path := range paths {
+		c, ok := articleUpdateColumns[path]
+		if !ok {
+			return fmt.Errorf("UpdateArticleFields: unknown or read-only field %q", path)
+		}
+		columns = append(columns, c...)
+	}
+	return r.db.WithContext(ctx).Model(a).Select(columns).Updates(a).Error
+}
+
+func (r *articleRepository) GetByTenantIdAndSlug(ctx context.Context, tenantId int64, slug string) (result Article, err error) {
+	err = r.db.WithContext(ctx).Where("tenant_id = ? AND slug = ?", tenantId, slug).First(&result).Error
+	return
+}
+
+func (r *articleRepository) GetByExternalId(ctx context.Context, externalId string) (result Article, err error) {
+	err = r.db.WithContext(ctx).Where("external_id = ?", externalId).First(&result).Error
+	return
+}
+
+func (r *articleRepository) BatchCreate(ctx context.Context, list []*Article, batchSize int) error {
+	return r.db.WithContext(ctx).CreateInBatches(list, batchSize).Error
+}
+
+func (r *articleRepository) BatchUpdate(ctx context.Context, list []*Article) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, a := range list {
+			if err := NewArticleRepository(tx).Update(ctx, a); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *articleRepository) BatchDelete(ctx context.Context, ids []int64) error {
+	return r.db.WithContext(ctx).Where("id IN ?", ids).Delete(&Article{}).Error
+}
+
+func (r *articleRepository) GetByIds(ctx context.Context, ids []int64) (list []Article, err error) {
+	err = r.db.WithContext(ctx).Where("id IN ?", ids).Find(&list).Error
+	return
+}
+
+func (r *articleRepository) Upsert(ctx context.Context, a *Article) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tenant_id"}, {Name: "slug"}},
+		DoUpdates: clause.AssignmentColumns([]string{"external_id", "title", "updated_at", "deleted_at"}),
+	}).Create(a).Error
+}
+
+func (r *articleRepository) FindOrCreate(ctx context.Context, a *Article) (created bool, err error) {
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tenant_id"}, {Name: "slug"}},
+		DoNothing: true,
+	}).Create(a)
+	if result.Error != nil || result.RowsAffected == 1 {
+		return result.Error == nil, result.Error
+	}
+	var found Article
+	if err = r.db.WithContext(ctx).Unscoped().Where("tenant_id = ? AND slug = ?", a.TenantId, a.Slug).First(&found).Error; err != nil {
+		return false, err
+	}
+	if found.DeletedAt.Valid {
+		if err = r.db.WithContext(ctx).Unscoped().Model(&found).Update("deleted_at", nil).Error; err != nil {
+			return false, err
+		}
+		found.DeletedAt = gorm.DeletedAt{}
+	}
+	*a = found
+	return false, nil
+}
+
+func (r *articleRepository) Restore(ctx context.Context, a Article) error {
+	return r.db.WithContext(ctx).Unscoped().Model(&a).Update("deleted_at", nil).Error
+}
+
+func (r *articleRepository) HardDelete(ctx context.Context, a Article) error {
+	return r.db.WithContext(ctx).Unscoped().Delete(&a).Error
+}
+
+func (r *articleRepository) GetListWithDeleted(ctx context.Context, info upsert.PageInfo, filter *ArticleFilter) (list []Article, total int64, err error) {
+	return (&articleRepository{db: r.db.Unscoped()}).GetList(ctx, info, filter)
+}
+
+// CreateArticle Func 创建
+func CreateArticle(ctx context.Context, a Article) (err error) {
+	return NewArticleRepository(global.DB_).Create(ctx, &a)
+}
+
+// DeleteArticle  删除
+func DeleteArticle(ctx context.Context, a Article) (err error) {
+	return NewArticleRepository(global.DB_).Delete(ctx, a)
+}
+
+// UpdateArticle 修改
+func UpdateArticle(ctx context.Context, a *Article) (err error) {
+	return NewArticleRepository(global.DB_).Update(ctx, a)
+}
+
+// UpdateArticleFields 按 FieldMask 部分修改
+func UpdateArticleFields(ctx context.Context, a *Article, paths []string) (err error) {
+	return NewArticleRepository(global.DB_).UpdateFields(ctx, a, paths)
+}
+
+// GetArticle 查询
+func GetArticle(ctx context.Context, id int64) (result Article, err error) {
+	return NewArticleRepository(global.DB_).Get(ctx, id)
+}
+
+// GetArticleList 分页查询
+func GetArticleList(ctx context.Context, info upsert.PageInfo, filter *ArticleFilter) (list []Article, total int64, err error) {
+	return NewArticleRepository(global.DB_).GetList(ctx, info, filter)
+}
+
+// GetArticleByTenantIdAndSlug 按唯一索引查询
+func GetArticleByTenantIdAndSlug(ctx context.Context, tenantId int64, slug string) (result Article, err error) {
+	return NewArticleRepository(global.DB_).GetByTenantIdAndSlug(ctx, tenantId, slug)
+}
+
+// GetArticleByExternalId 按唯一索引查询
+func GetArticleByExternalId(ctx context.Context, externalId string) (result Article, err error) {
+	return NewArticleRepository(global.DB_).GetByExternalId(ctx, externalId)
+}
+
+// BatchCreateArticle 批量创建，每条 INSERT 语句最多 batchSize 行
+func BatchCreateArticle(ctx context.Context, list []*Article, batchSize int) error {
+	return NewArticleRepository(global.DB_).BatchCreate(ctx, list, batchSize)
+}
+
+// BatchUpdateArticle 在一个事务中逐条 Update
+func BatchUpdateArticle(ctx context.Context, list []*Article) error {
+	return NewArticleRepository(global.DB_).BatchUpdate(ctx, list)
+}
+
+// BatchDeleteArticle 按主键批量删除
+func BatchDeleteArticle(ctx context.Context, ids []int64) error {
+	return NewArticleRepository(global.DB_).BatchDelete(ctx, ids)
+}
+
+// GetArticleByIds 按主键批量查询
+func GetArticleByIds(ctx context.Context, ids []int64) (list []Article, err error) {
+	return NewArticleRepository(global.DB_).GetByIds(ctx, ids)
+}
+
+// UpsertArticle 按唯一索引创建或修改
+func UpsertArticle(ctx context.Context, a *Article) error {
+	return NewArticleRepository(global.DB_).Upsert(ctx, a)
+}
+
+// FindOrCreateArticle 按唯一索引查询，不存在时创建；a 为查询或创建的记录，已软删除的记录会被恢复
+func FindOrCreateArticle(ctx context.Context, a *Article) (created bool, err error) {
+	return NewArticleRepository(global.DB_).FindOrCreate(ctx, a)
+}
+
+// RestoreArticle 恢复软删除的记录
+func RestoreArticle(ctx context.Context, a Article) error {
+	return NewArticleRepository(global.DB_).Restore(ctx, a)
+}
+
+// HardDeleteArticle 永久删除，包括已软删除的记录
+func HardDeleteArticle(ctx context.Context, a Article) error {
+	return NewArticleRepository(global.DB_).HardDelete(ctx, a)
+}
+
+// GetArticleListWithDeleted 分页查询，包括已软删除的记录
+func GetArticleListWithDeleted(ctx context.Context, info upsert.PageInfo, filter *ArticleFilter) (list []Article, total int64, err error) {
+	return NewArticleRepository(global.DB_).GetListWithDeleted(ctx, info, filter)
+}
@@ -0,0 +1,322 @@
+// Code generated by protoc-gen-simple. DO NOT EDIT.
+// versions:
+// - protoc-gen-simple v0.0.7
+// - protoc          (unknown)
+// source: upsert.proto
+
+package model
+
+import (
+	context "context"
+	errors "errors"
+	upsert "example.com/app/pb/upsert"
+	fmt "fmt"
+	global "github.com/wwengg/simple/core/global"
+	gorm "gorm.io/gorm"
+	clause "gorm.io/gorm/clause"
+	strings "strings"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = time.Now
+
+// Sku Model
+type Sku struct {
+	Id      int64  `json:"id" gorm:"column:id;type:bigint;primaryKey;autoIncrement;"`
+	Code    string `json:"code" gorm:"column:code;type:varchar(255);unique;"`
+	Version int64  `json:"version" gorm:"column:version;type:bigint;"`
+}
+
+// ErrSkuVersionConflict is returned by the updates of Sku when the row was changed
+// or deleted since its version was read.
+var ErrSkuVersionConflict = errors.New("Sku: version conflict, the record was changed")
+
+func (model *Sku) Proto() *upsert.SkuModel {
+	proto := &upsert.SkuModel{
+		Id:      model.Id,
+		Code:    model.Code,
+		Version: model.Version,
+	}
+	return proto
+}
+
+func SkuProtoToModel(proto *upsert.SkuModel) *Sku {
+	model := Sku{
+		Id:      proto.Id,
+		Code:    proto.Code,
+		Version: proto.Version,
+	}
+	return &model
+}
+
+// SkuFilter 分页查询条件，零值的条件不生效
+type SkuFilter struct {
+	// Sort 排序字段，"-" 前缀表示降序，可选 id
+	Sort []string
+}
+
+var skuSortColumns = map[string]string{
+	"id": "id",
+}
+
+// Scope adds the conditions and the order of f to db. An unknown sort
+// field is reported as an error of db.
+func (f *SkuFilter) Scope(db *gorm.DB) *gorm.DB {
+	if f == nil {
+		return db
+	}
+	for _, s := range f.Sort {
+		column, ok := skuSortColumns[strings.TrimPrefix(s, "-")]
+		if !ok {
+			db.AddError(fmt.Errorf("SkuFilter: cannot sort by %q", s))
+			return db
+		}
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: strings.HasPrefix(s, "-")})
+	}
+	return db
+}
+
+// SkuRepository 数据访问接口
+type SkuRepository interface {
+	// Create 创建
+	Create(ctx context.Context, a *Sku) error
+	// Delete 删除，记录不存在时返回 gorm.ErrRecordNotFound
+	Delete(ctx context.Context, a Sku) error
+	// Update 修改
+	Update(ctx context.Context, a *Sku) error
+	// UpdateFields 只修改 paths（FieldMask 的 proto 字段名）对应的列，
+	// 未知或只读的字段返回错误
+	UpdateFields(ctx context.Context, a *Sku, paths []string) error
+	// Get 查询
+	Get(ctx context.Context, id int64) (Sku, error)
+	// GetList 分页查询，filter 为 nil 时不过滤
+	GetList(ctx context.Context, info upsert.PageInfo, filter *SkuFilter) ([]Sku, int64, error)
+	// Transaction 在事务中运行 fn，fn 返回 nil 时提交；已在事务中时使用保存点
+	Transaction(ctx context.Context, fn func(repo SkuRepository) error) error
+	// GetByCode 按唯一索引查询
+	GetByCode(ctx context.Context, code string) (Sku, error)
+	// BatchCreate 批量创建，每条 INSERT 语句最多 batchSize 行
+	BatchCreate(ctx context.Context, list []*Sku, batchSize int) error
+	// BatchUpdate 在一个事务中逐条 Update
+	BatchUpdate(ctx context.Context, list []*Sku) error
+	// BatchDelete 按主键批量删除
+	BatchDelete(ctx context.Context, ids []int64) error
+	// GetByIds 按主键批量查询
+	GetByIds(ctx context.Context, ids []int64) (list []Sku, err error)
+	// Upsert 按唯一索引创建或修改
+	Upsert(ctx context.Context, a *Sku) error
+	// FindOrCreate 按唯一索引查询，不存在时创建；a 为查询或创建的记录
+	FindOrCreate(ctx context.Context, a *Sku) (created bool, err error)
+}
+
+// NewSkuRepository returns the SkuRepository running on db, which
+// may be a transaction.
+func NewSkuRepository(db *gorm.DB) SkuRepository {
+	return &skuRepository{db: db}
+}
+
+type skuRepository struct {
+	db *gorm.DB
+}
+
+func (r *skuRepository) Create(ctx context.Context, a *Sku) error {
+	return r.db.WithContext(ctx).Create(a).Error
+}
+
+func (r *skuRepository) Delete(ctx context.Context, a Sku) error {
+	result := r.db.WithContext(ctx).Delete(&a)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return result.Error
+}
+
+func (r *skuRepository) Update(ctx context.Context, a *Sku) error {
+	version := a.Version
+	a.Version++
+	result := r.db.WithContext(ctx).Model(a).Where("id = ? AND version = ?", a.Id, version).Select("*").Omit("created_at").Updates(a)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = ErrSkuVersionConflict
+	}
+	if result.Error != nil {
+		a.Version = version
+	}
+	return result.Error
+}
+
+func (r *skuRepository) Get(ctx context.Context, id int64) (result Sku, err error) {
+	err = r.db.WithContext(ctx).Where("id = ?", id).First(&result).Error
+	return
+}
+
+func (r *skuRepository) GetList(ctx context.Context, info upsert.PageInfo, filter *SkuFilter) (list []Sku, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := filter.Scope(r.db.WithContext(ctx).Model(&Sku{}))
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Limit(int(limit)).Offset(int(offset)).Find(&list).Error
+	return
+}
+
+func (r *skuRepository) Transaction(ctx context.Context, fn func(repo SkuRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewSkuRepository(tx))
+	})
+}
+
+// DB returns the database the repository runs on, which may be a
+// transaction.
+func (r *skuRepository) DB() *gorm.DB {
+	return r.db
+}
+
+// skuUpdateColumns maps the field mask paths of UpdateFields to the columns they
+// update.
+var skuUpdateColumns = map[string][]string{
+	"code": {"code"},
+}
+
+func (r *skuRepository) UpdateFields(ctx context.Context, a *Sku, paths []string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("UpdateSkuFields: empty field mask")
+	}
+	var columns []string
+	for _, path := range paths {
+		c, ok := skuUpdateColumns[path]
+		if !ok {
+			return fmt.Errorf("UpdateSkuFields: unknown or read-only field %q", path)
+		}
+		columns = append(columns, c...)
+	}
+	columns = append(columns, "version")
+	version := a.Version
+	a.Version++
+	result := r.db.WithContext(ctx).Model(a).Where("id = ? AND version = ?", a.Id, version).Select(columns).Updates(a)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = ErrSkuVersionConflict
+	}
+	if result.Error != nil {
+		a.Version = version
+	}
+	return result.Error
+}
+
+func (r *skuRepository) GetByCode(ctx context.Context, code string) (result Sku, err error) {
+	err = r.db.WithContext(ctx).Where("code = ?", code).First(&result).Error
+	return
+}
+
+func (r *skuRepository) BatchCreate(ctx context.Context, list []*Sku, batchSize int) error {
+	return r.db.WithContext(ctx).CreateInBatches(list, batchSize).Error
+}
+
+func (r *skuRepository) BatchUpdate(ctx context.Context, list []*Sku) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, a := range list {
+			if err := NewSkuRepository(tx).Update(ctx, a); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *skuRepository) BatchDelete(ctx context.Context, ids []int64) error {
+	return r.db.WithContext(ctx).Where("id IN ?", ids).Delete(&Sku{}).Error
+}
+
+func (r *skuRepository) GetByIds(ctx context.Context, ids []int64) (list []Sku, err error) {
+	err = r.db.WithContext(ctx).Where("id IN ?", ids).Find(&list).Error
+	return
+}
+
+func (r *skuRepository) Upsert(ctx context.Context, a *Sku) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "code"}},
+		DoUpdates: clause.Set{clause.Assignment{Column: clause.Column{Name: "version"}, Value: gorm.Expr("version + 1")}},
+	}).Create(a).Error
+}
+
+func (r *skuRepository) FindOrCreate(ctx context.Context, a *Sku) (created bool, err error) {
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "code"}},
+		DoNothing: true,
+	}).Create(a)
+	if result.Error != nil || result.RowsAffected == 1 {
+		return result.Error == nil, result.Error
+	}
+	var found Sku
+	if err = r.db.WithContext(ctx).Where("code = ?", a.Code).First(&found).Error; err != nil {
+		return false, err
+	}
+	*a = found
+	return false, nil
+}
+
+// CreateSku Func 创建
+func CreateSku(ctx context.Context, a Sku) (err error) {
+	return NewSkuRepository(global.DB_).Create(ctx, &a)
+}
+
+// DeleteSku  删除
+func DeleteSku(ctx context.Context, a Sku) (err error) {
+	return NewSkuRepository(global.DB_).Delete(ctx, a)
+}
+
+// UpdateSku 修改
+func UpdateSku(ctx context.Context, a *Sku) (err error) {
+	return NewSkuRepository(global.DB_).Update(ctx, a)
+}
+
+// UpdateSkuFields 按 FieldMask 部分修改
+func UpdateSkuFields(ctx context.Context, a *Sku, paths []string) (err error) {
+	return NewSkuRepository(global.DB_).UpdateFields(ctx, a, paths)
+}
+
+// GetSku 查询
+func GetSku(ctx context.Context, id int64) (result Sku, err error) {
+	return NewSkuRepository(global.DB_).Get(ctx, id)
+}
+
+// GetSkuList 分页查询
+func GetSkuList(ctx context.Context, info upsert.PageInfo, filter *SkuFilter) (list []Sku, total int64, err error) {
+	return NewSkuRepository(global.DB_).GetList(ctx, info, filter)
+}
+
+// GetSkuByCode 按唯一索引查询
+func GetSkuByCode(ctx context.Context, code string) (result Sku, err error) {
+	return NewSkuRepository(global.DB_).GetByCode(ctx, code)
+}
+
+// BatchCreateSku 批量创建，每条 INSERT 语句最多 batchSize 行
+func BatchCreateSku(ctx context.Context, list []*Sku, batchSize int) error {
+	return NewSkuRepository(global.DB_).BatchCreate(ctx, list, batchSize)
+}
+
+// BatchUpdateSku 在一个事务中逐条 Update
+func BatchUpdateSku(ctx context.Context, list []*Sku) error {
+	return NewSkuRepository(global.DB_).BatchUpdate(ctx, list)
+}
+
+// BatchDeleteSku 按主键批量删除
+func BatchDeleteSku(ctx context.Context, ids []int64) error {
+	return NewSkuRepository(global.DB_).BatchDelete(ctx, ids)
+}
+
+// GetSkuByIds 按主键批量查询
+func GetSkuByIds(ctx context.Context, ids []int64) (list []Sku, err error) {
+	return NewSkuRepository(global.DB_).GetByIds(ctx, ids)
+}
+
+// UpsertSku 按唯一索引创建或修改
+func UpsertSku(ctx context.Context, a *Sku) error {
+	return NewSkuRepository(global.DB_).Upsert(ctx, a)
+}
+
+// FindOrCreateSku 按唯一索引查询，不存在时创建；a 为查询或创建的记录
+func FindOrCreateSku(ctx context.Context, a *Sku) (created bool, err error) {
+	return NewSkuRepository(global.DB_).FindOrCreate(ctx, a)
+}
@@ -0,0 +1,30 @@
+-- Code generated by protoc-gen-simple. DO NOT EDIT.
+-- versions:
+-- - protoc-gen-simple v0.0.7
+-- - protoc          (unknown)
+-- source: upsert.proto
+-- dialect: mysql
+
+CREATE TABLE `articles` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `created_at` datetime(3),
+  `updated_at` datetime(3),
+  `deleted_at` datetime(3),
+  `tenant_id` bigint,
+  `slug` varchar(255),
+  `external_id` varchar(255),
+  `title` varchar(255),
+  PRIMARY KEY (`id`)
+);
+CREATE INDEX `idx_articles_deleted_at` ON `articles` (`deleted_at`);
+CREATE UNIQUE INDEX `idx_article_tenant_id_slug` ON `articles` (`tenant_id`, `slug`);
+CREATE UNIQUE INDEX `idx_article_external_id` ON `articles` (`external_id`);
+
+CREATE TABLE `skus` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `code` varchar(255),
+  `version` bigint,
+  PRIMARY KEY (`id`)
+);
+CREATE UNIQUE INDEX `uni_skus_code` ON `skus` (`code`);
+
@@ -0,0 +1,109 @@
+// Code generated by protoc-gen-simple. DO NOT EDIT.
+// versions:
+// - protoc-gen-simple v0.0.7
+// - protoc          (unknown)
+// source: user.proto
+
+package impl
+
+import (
+	context "context"
+	user "example.com/app/pb/user"
+	model "example.com/app/pb/user/model"
+	global "github.com/wwengg/simple/core/global"
+	store "github.com/wwengg/simple/core/store"
+	gorm "gorm.io/gorm"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = context.TODO
+
+type User struct {
+	// Repository is the data access of the service, by default
+	// model.NewUserRepository(s.db()).
+	Repository model.UserRepository
+	// DB is the database of the service, by default global.DB_.
+	DB *gorm.DB
+}
+
+func (s *User) db() *gorm.DB {
+	if s.DB != nil {
+		return s.DB
+	}
+	return global.DB_
+}
+
+func (s *User) repository() model.UserRepository {
+	if s.Repository != nil {
+		return s.Repository
+	}
+	return model.NewUserRepository(s.db())
+}
+
+// CreateUser is server rpc method as defined
+func (s *User) CreateUser(ctx context.Context, args *user.UserModel, reply *user.CommonReply) (err error) {
+	*reply = user.CommonReply{}
+	if err = s.repository().Create(ctx, model.UserProtoToModel(args)); err == nil {
+		reply.Code = user.EnumCode_Success
+	} else {
+		reply.Code = user.EnumCode_CreateError
+	}
+
+	return nil
+}
+
+// UpdateUser is server rpc method as defined
+func (s *User) UpdateUser(ctx context.Context, args *user.UserModel, reply *user.CommonReply) (err error) {
+	*reply = user.CommonReply{}
+	if err = s.repository().Update(ctx, model.UserProtoToModel(args)); err == nil {
+		reply.Code = user.EnumCode_Success
+	} else {
+		reply.Code = user.EnumCode_UpdateError
+	}
+
+	return nil
+}
+
+// DeleteUser is server rpc method as defined
+func (s *User) DeleteUser(ctx context.Context, args *user.IdArgs, reply *user.CommonReply) (err error) {
+	*reply = user.CommonReply{}
+	if err = s.repository().Delete(ctx, model.User{BASE_MODEL: store.BASE_MODEL{ID: args.Id}}); err == nil {
+		reply.Code = user.EnumCode_Success
+	} else {
+		reply.Code = user.EnumCode_DeleteError
+	}
+
+	return nil
+}
+
+// FindUserById is server rpc method as defined
+func (s *User) FindUserById(ctx context.Context, args *user.IdArgs, reply *user.UserReply) (err error) {
+	*reply = user.UserReply{}
+	if result, err := s.repository().Get(ctx, args.Id); err == nil {
+		reply.Data = result.Proto()
+		reply.Code = user.EnumCode_Success
+	} else {
+		reply.Code = user.EnumCode_FindError
+	}
+	return nil
+}
+
+// FindUserList is server rpc method as defined
+func (s *User) FindUserList(ctx context.Context, args *user.ListArgs, reply *user.UserListReply) (err error) {
+	*reply = user.UserListReply{}
+	pageInfo := args.GetPageInfo()
+	if pageInfo == nil {
+		// 未传 page_info 时查询第一页
+		pageInfo = &user.PageInfo{Page: 1, PageSize: 10}
+	}
+	if list, total, err := s.repository().GetList(ctx, *pageInfo, nil); err == nil {
+		for _, v := range list {
+			reply.List = append(reply.List, v.Proto())
+		}
+		reply.Total = total
+		reply.Code = user.EnumCode_Success
+	} else {
+		reply.Code = user.EnumCode_FindError
+	}
+	return nil
+}
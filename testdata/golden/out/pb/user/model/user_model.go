@@ -0,0 +1,291 @@
+// Code generated by protoc-gen-simple. DO NOT EDIT.
+// versions:
+// - protoc-gen-simple v0.0.7
+// - protoc          (unknown)
+// source: user.proto
+
+package model
+
+import (
+	context "context"
+	user "example.com/app/pb/user"
+	fmt "fmt"
+	global "github.com/wwengg/simple/core/global"
+	store "github.com/wwengg/simple/core/store"
+	gorm "gorm.io/gorm"
+	clause "gorm.io/gorm/clause"
+	strings "strings"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = time.Now
+
+// User Model
+type User struct {
+	store.BASE_MODEL
+
+	Name string `json:"name" gorm:"column:name;type:varchar(255);"`
+}
+
+func (model *User) Proto() *user.UserModel {
+	proto := &user.UserModel{
+		Id:        model.ID,
+		CreatedAt: model.CreatedAt.Format(time.DateTime),
+		UpdatedAt: model.UpdatedAt.Format(time.DateTime),
+		Name:      model.Name,
+	}
+	return proto
+}
+
+func UserProtoToModel(proto *user.UserModel) *User {
+	model := User{
+		Name: proto.Name,
+	}
+	model.ID = proto.Id
+	if t, err := time.Parse(time.DateTime, proto.CreatedAt); err == nil {
+		model.CreatedAt = t
+	}
+	if t, err := time.Parse(time.DateTime, proto.UpdatedAt); err == nil {
+		model.UpdatedAt = t
+	}
+	return &model
+}
+
+// UserFilter 分页查询条件，零值的条件不生效
+type UserFilter struct {
+	// Sort 排序字段，"-" 前缀表示降序，可选 id, created_at, updated_at
+	Sort []string
+}
+
+var userSortColumns = map[string]string{
+	"id":         "id",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// Scope adds the conditions and the order of f to db. An unknown sort
+// field is reported as an error of db.
+func (f *UserFilter) Scope(db *gorm.DB) *gorm.DB {
+	if f == nil {
+		return db
+	}
+	for _, s := range f.Sort {
+		column, ok := userSortColumns[strings.TrimPrefix(s, "-")]
+		if !ok {
+			db.AddError(fmt.Errorf("UserFilter: cannot sort by %q", s))
+			return db
+		}
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: strings.HasPrefix(s, "-")})
+	}
+	return db
+}
+
+// UserRepository 数据访问接口
+type UserRepository interface {
+	// Create 创建
+	Create(ctx context.Context, a *User) error
+	// Delete 删除，记录不存在时返回 gorm.ErrRecordNotFound
+	Delete(ctx context.Context, a User) error
+	// Update 修改
+	Update(ctx context.Context, a *User) error
+	// UpdateFields 只修改 paths（FieldMask 的 proto 字段名）对应的列，
+	// 未知或只读的字段返回错误
+	UpdateFields(ctx context.Context, a *User, paths []string) error
+	// Get 查询
+	Get(ctx context.Context, id int64) (User, error)
+	// GetList 分页查询，filter 为 nil 时不过滤
+	GetList(ctx context.Context, info user.PageInfo, filter *UserFilter) ([]User, int64, error)
+	// Transaction 在事务中运行 fn，fn 返回 nil 时提交；已在事务中时使用保存点
+	Transaction(ctx context.Context, fn func(repo UserRepository) error) error
+	// BatchCreate 批量创建，每条 INSERT 语句最多 batchSize 行
+	BatchCreate(ctx context.Context, list []*User, batchSize int) error
+	// BatchUpdate 在一个事务中逐条 Update
+	BatchUpdate(ctx context.Context, list []*User) error
+	// BatchDelete 按主键批量删除
+	BatchDelete(ctx context.Context, ids []int64) error
+	// GetByIds 按主键批量查询
+	GetByIds(ctx context.Context, ids []int64) (list []User, err error)
+	// Restore 恢复软删除的记录
+	Restore(ctx context.Context, a User) error
+	// HardDelete 永久删除，包括已软删除的记录
+	HardDelete(ctx context.Context, a User) error
+	// GetListWithDeleted 分页查询，包括已软删除的记录
+	GetListWithDeleted(ctx context.Context, info user.PageInfo, filter *UserFilter) (list []User, total int64, err error)
+}
+
+// NewUserRepository returns the UserRepository running on db, which
+// may be a transaction.
+func NewUserRepository(db *gorm.DB) UserRepository {
+	return &userRepository{db: db}
+}
+
+type userRepository struct {
+	db *gorm.DB
+}
+
+func (r *userRepository) Create(ctx context.Context, a *User) error {
+	return r.db.WithContext(ctx).Create(a).Error
+}
+
+func (r *userRepository) Delete(ctx context.Context, a User) error {
+	result := r.db.WithContext(ctx).Delete(&a)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return result.Error
+}
+
+func (r *userRepository) Update(ctx context.Context, a *User) error {
+	return r.db.WithContext(ctx).Save(a).Error
+}
+
+func (r *userRepository) Get(ctx context.Context, id int64) (result User, err error) {
+	err = r.db.WithContext(ctx).Where("id = ?", id).First(&result).Error
+	return
+}
+
+func (r *userRepository) GetList(ctx context.Context, info user.PageInfo, filter *UserFilter) (list []User, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := filter.Scope(r.db.WithContext(ctx).Model(&User{}))
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Limit(int(limit)).Offset(int(offset)).Find(&list).Error
+	return
+}
+
+func (r *userRepository) Transaction(ctx context.Context, fn func(repo UserRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewUserRepository(tx))
+	})
+}
+
+// DB returns the database the repository runs on, which may be a
+// transaction.
+func (r *userRepository) DB() *gorm.DB {
+	return r.db
+}
+
+// userUpdateColumns maps the field mask paths of UpdateFields to the columns they
+// update.
+var userUpdateColumns = map[string][]string{
+	"name": {"name"},
+}
+
+func (r *userRepository) UpdateFields(ctx context.Context, a *User, paths []string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("UpdateUserFields: empty field mask")
+	}
+	var columns []string
+	for _, path := range paths {
+		c, ok := userUpdateColumns[path]
+		if !ok {
+			return fmt.Errorf("UpdateUserFields: unknown or read-only field %q", path)
+		}
+		columns = append(columns, c...)
+	}
+	return r.db.WithContext(ctx).Model(a).Select(columns).Updates(a).Error
+}
+
+func (r *userRepository) BatchCreate(ctx context.Context, list []*User, batchSize int) error {
+	return r.db.WithContext(ctx).CreateInBatches(list, batchSize).Error
+}
+
+func (r *userRepository) BatchUpdate(ctx context.Context, list []*User) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, a := range list {
+			if err := NewUserRepository(tx).Update(ctx, a); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *userRepository) BatchDelete(ctx context.Context, ids []int64) error {
+	return r.db.WithContext(ctx).Where("id IN ?", ids).Delete(&User{}).Error
+}
+
+func (r *userRepository) GetByIds(ctx context.Context, ids []int64) (list []User, err error) {
+	err = r.db.WithContext(ctx).Where("id IN ?", ids).Find(&list).Error
+	return
+}
+
+func (r *userRepository) Restore(ctx context.Context, a User) error {
+	return r.db.WithContext(ctx).Unscoped().Model(&a).Update("deleted_at", nil).Error
+}
+
+func (r *userRepository) HardDelete(ctx context.Context, a User) error {
+	return r.db.WithContext(ctx).Unscoped().Delete(&a).Error
+}
+
+func (r *userRepository) GetListWithDeleted(ctx context.Context, info user.PageInfo, filter *UserFilter) (list []User, total int64, err error) {
+	return (&userRepository{db: r.db.Unscoped()}).GetList(ctx, info, filter)
+}
+
+// CreateUser Func 创建
+func CreateUser(ctx context.Context, a User) (err error) {
+	return NewUserRepository(global.DB_).Create(ctx, &a)
+}
+
+// DeleteUser  删除
+func DeleteUser(ctx context.Context, a User) (err error) {
+	return NewUserRepository(global.DB_).Delete(ctx, a)
+}
+
+// UpdateUser 修改
+func UpdateUser(ctx context.Context, a *User) (err error) {
+	return NewUserRepository(global.DB_).Update(ctx, a)
+}
+
+// UpdateUserFields 按 FieldMask 部分修改
+func UpdateUserFields(ctx context.Context, a *User, paths []string) (err error) {
+	return NewUserRepository(global.DB_).UpdateFields(ctx, a, paths)
+}
+
+// GetUser 查询
+func GetUser(ctx context.Context, id int64) (result User, err error) {
+	return NewUserRepository(global.DB_).Get(ctx, id)
+}
+
+// GetUserList 分页查询
+func GetUserList(ctx context.Context, info user.PageInfo, filter *UserFilter) (list []User, total int64, err error) {
+	return NewUserRepository(global.DB_).GetList(ctx, info, filter)
+}
+
+// BatchCreateUser 批量创建，每条 INSERT 语句最多 batchSize 行
+func BatchCreateUser(ctx context.Context, list []*User, batchSize int) error {
+	return NewUserRepository(global.DB_).BatchCreate(ctx, list, batchSize)
+}
+
+// BatchUpdateUser 在一个事务中逐条 Update
+func BatchUpdateUser(ctx context.Context, list []*User) error {
+	return NewUserRepository(global.DB_).BatchUpdate(ctx, list)
+}
+
+// BatchDeleteUser 按主键批量删除
+func BatchDeleteUser(ctx context.Context, ids []int64) error {
+	return NewUserRepository(global.DB_).BatchDelete(ctx, ids)
+}
+
+// GetUserByIds 按主键批量查询
+func GetUserByIds(ctx context.Context, ids []int64) (list []User, err error) {
+	return NewUserRepository(global.DB_).GetByIds(ctx, ids)
+}
+
+// RestoreUser 恢复软删除的记录
+func RestoreUser(ctx context.Context, a User) error {
+	return NewUserRepository(global.DB_).Restore(ctx, a)
+}
+
+// HardDeleteUser 永久删除，包括已软删除的记录
+func HardDeleteUser(ctx context.Context, a User) error {
+	return NewUserRepository(global.DB_).HardDelete(ctx, a)
+}
+
+// GetUserListWithDeleted 分页查询，包括已软删除的记录
+func GetUserListWithDeleted(ctx context.Context, info user.PageInfo, filter *UserFilter) (list []User, total int64, err error) {
+	return NewUserRepository(global.DB_).GetListWithDeleted(ctx, info, filter)
+}
@@ -0,0 +1,17 @@
+-- Code generated by protoc-gen-simple. DO NOT EDIT.
+-- versions:
+-- - protoc-gen-simple v0.0.7
+-- - protoc          (unknown)
+-- source: user.proto
+-- dialect: mysql
+
+CREATE TABLE `users` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `created_at` datetime(3),
+  `updated_at` datetime(3),
+  `deleted_at` datetime(3),
+  `name` varchar(255),
+  PRIMARY KEY (`id`)
+);
+CREATE INDEX `idx_users_deleted_at` ON `users` (`deleted_at`);
+
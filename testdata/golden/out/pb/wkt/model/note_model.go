@@ -0,0 +1,287 @@
+// Code generated by protoc-gen-simple. DO NOT EDIT.
+// versions:
+// - protoc-gen-simple v0.0.7
+// - protoc          (unknown)
+// source: wkt.proto
+
+package model
+
+import (
+	context "context"
+	wkt "example.com/app/pb/wkt"
+	fmt "fmt"
+	global "github.com/wwengg/simple/core/global"
+	store "github.com/wwengg/simple/core/store"
+	gorm "gorm.io/gorm"
+	clause "gorm.io/gorm/clause"
+	strings "strings"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = time.Now
+
+// Note Model
+type Note struct {
+	store.BASE_MODEL
+
+	Body string `json:"body" gorm:"column:body;type:varchar(255);"`
+}
+
+func (model *Note) Proto() *wkt.NoteModel {
+	proto := &wkt.NoteModel{
+		Id:        model.ID,
+		CreatedAt: model.CreatedAt.Format(time.DateTime),
+		Body:      model.Body,
+	}
+	return proto
+}
+
+func NoteProtoToModel(proto *wkt.NoteModel) *Note {
+	model := Note{
+		Body: proto.Body,
+	}
+	model.ID = proto.Id
+	if t, err := time.Parse(time.DateTime, proto.CreatedAt); err == nil {
+		model.CreatedAt = t
+	}
+	return &model
+}
+
+// NoteFilter 分页查询条件，零值的条件不生效
+type NoteFilter struct {
+	// Sort 排序字段，"-" 前缀表示降序，可选 id, created_at, updated_at
+	Sort []string
+}
+
+var noteSortColumns = map[string]string{
+	"id":         "id",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// Scope adds the conditions and the order of f to db. An unknown sort
+// field is reported as an error of db.
+func (f *NoteFilter) Scope(db *gorm.DB) *gorm.DB {
+	if f == nil {
+		return db
+	}
+	for _, s := range f.Sort {
+		column, ok := noteSortColumns[strings.TrimPrefix(s, "-")]
+		if !ok {
+			db.AddError(fmt.Errorf("NoteFilter: cannot sort by %q", s))
+			return db
+		}
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: strings.HasPrefix(s, "-")})
+	}
+	return db
+}
+
+// NoteRepository 数据访问接口
+type NoteRepository interface {
+	// Create 创建
+	Create(ctx context.Context, a *Note) error
+	// Delete 删除，记录不存在时返回 gorm.ErrRecordNotFound
+	Delete(ctx context.Context, a Note) error
+	// Update 修改
+	Update(ctx context.Context, a *Note) error
+	// UpdateFields 只修改 paths（FieldMask 的 proto 字段名）对应的列，
+	// 未知或只读的字段返回错误
+	UpdateFields(ctx context.Context, a *Note, paths []string) error
+	// Get 查询
+	Get(ctx context.Context, id int64) (Note, error)
+	// GetList 分页查询，filter 为 nil 时不过滤
+	GetList(ctx context.Context, info wkt.PageInfo, filter *NoteFilter) ([]Note, int64, error)
+	// Transaction 在事务中运行 fn，fn 返回 nil 时提交；已在事务中时使用保存点
+	Transaction(ctx context.Context, fn func(repo NoteRepository) error) error
+	// BatchCreate 批量创建，每条 INSERT 语句最多 batchSize 行
+	BatchCreate(ctx context.Context, list []*Note, batchSize int) error
+	// BatchUpdate 在一个事务中逐条 Update
+	BatchUpdate(ctx context.Context, list []*Note) error
+	// BatchDelete 按主键批量删除
+	BatchDelete(ctx context.Context, ids []int64) error
+	// GetByIds 按主键批量查询
+	GetByIds(ctx context.Context, ids []int64) (list []Note, err error)
+	// Restore 恢复软删除的记录
+	Restore(ctx context.Context, a Note) error
+	// HardDelete 永久删除，包括已软删除的记录
+	HardDelete(ctx context.Context, a Note) error
+	// GetListWithDeleted 分页查询，包括已软删除的记录
+	GetListWithDeleted(ctx context.Context, info wkt.PageInfo, filter *NoteFilter) (list []Note, total int64, err error)
+}
+
+// NewNoteRepository returns the NoteRepository running on db, which
+// may be a transaction.
+func NewNoteRepository(db *gorm.DB) NoteRepository {
+	return &noteRepository{db: db}
+}
+
+type noteRepository struct {
+	db *gorm.DB
+}
+
+func (r *noteRepository) Create(ctx context.Context, a *Note) error {
+	return r.db.WithContext(ctx).Create(a).Error
+}
+
+func (r *noteRepository) Delete(ctx context.Context, a Note) error {
+	result := r.db.WithContext(ctx).Delete(&a)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return result.Error
+}
+
+func (r *noteRepository) Update(ctx context.Context, a *Note) error {
+	return r.db.WithContext(ctx).Save(a).Error
+}
+
+func (r *noteRepository) Get(ctx context.Context, id int64) (result Note, err error) {
+	err = r.db.WithContext(ctx).Where("id = ?", id).First(&result).Error
+	return
+}
+
+func (r *noteRepository) GetList(ctx context.Context, info wkt.PageInfo, filter *NoteFilter) (list []Note, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := filter.Scope(r.db.WithContext(ctx).Model(&Note{}))
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Limit(int(limit)).Offset(int(offset)).Find(&list).Error
+	return
+}
+
+func (r *noteRepository) Transaction(ctx context.Context, fn func(repo NoteRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewNoteRepository(tx))
+	})
+}
+
+// DB returns the database the repository runs on, which may be a
+// transaction.
+func (r *noteRepository) DB() *gorm.DB {
+	return r.db
+}
+
+// noteUpdateColumns maps the field mask paths of UpdateFields to the columns they
+// update.
+var noteUpdateColumns = map[string][]string{
+	"body": {"body"},
+}
+
+func (r *noteRepository) UpdateFields(ctx context.Context, a *Note, paths []string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("UpdateNoteFields: empty field mask")
+	}
+	var columns []string
+	for _, path := range paths {
+		c, ok := noteUpdateColumns[path]
+		if !ok {
+			return fmt.Errorf("UpdateNoteFields: unknown or read-only field %q", path)
+		}
+		columns = append(columns, c...)
+	}
+	return r.db.WithContext(ctx).Model(a).Select(columns).Updates(a).Error
+}
+
+func (r *noteRepository) BatchCreate(ctx context.Context, list []*Note, batchSize int) error {
+	return r.db.WithContext(ctx).CreateInBatches(list, batchSize).Error
+}
+
+func (r *noteRepository) BatchUpdate(ctx context.Context, list []*Note) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, a := range list {
+			if err := NewNoteRepository(tx).Update(ctx, a); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *noteRepository) BatchDelete(ctx context.Context, ids []int64) error {
+	return r.db.WithContext(ctx).Where("id IN ?", ids).Delete(&Note{}).Error
+}
+
+func (r *noteRepository) GetByIds(ctx context.Context, ids []int64) (list []Note, err error) {
+	err = r.db.WithContext(ctx).Where("id IN ?", ids).Find(&list).Error
+	return
+}
+
+func (r *noteRepository) Restore(ctx context.Context, a Note) error {
+	return r.db.WithContext(ctx).Unscoped().Model(&a).Update("deleted_at", nil).Error
+}
+
+func (r *noteRepository) HardDelete(ctx context.Context, a Note) error {
+	return r.db.WithContext(ctx).Unscoped().Delete(&a).Error
+}
+
+func (r *noteRepository) GetListWithDeleted(ctx context.Context, info wkt.PageInfo, filter *NoteFilter) (list []Note, total int64, err error) {
+	return (&noteRepository{db: r.db.Unscoped()}).GetList(ctx, info, filter)
+}
+
+// CreateNote Func 创建
+func CreateNote(ctx context.Context, a Note) (err error) {
+	return NewNoteRepository(global.DB_).Create(ctx, &a)
+}
+
+// DeleteNote  删除
+func DeleteNote(ctx context.Context, a Note) (err error) {
+	return NewNoteRepository(global.DB_).Delete(ctx, a)
+}
+
+// UpdateNote 修改
+func UpdateNote(ctx context.Context, a *Note) (err error) {
+	return NewNoteRepository(global.DB_).Update(ctx, a)
+}
+
+// UpdateNoteFields 按 FieldMask 部分修改
+func UpdateNoteFields(ctx context.Context, a *Note, paths []string) (err error) {
+	return NewNoteRepository(global.DB_).UpdateFields(ctx, a, paths)
+}
+
+// GetNote 查询
+func GetNote(ctx context.Context, id int64) (result Note, err error) {
+	return NewNoteRepository(global.DB_).Get(ctx, id)
+}
+
+// GetNoteList 分页查询
+func GetNoteList(ctx context.Context, info wkt.PageInfo, filter *NoteFilter) (list []Note, total int64, err error) {
+	return NewNoteRepository(global.DB_).GetList(ctx, info, filter)
+}
+
+// BatchCreateNote 批量创建，每条 INSERT 语句最多 batchSize 行
+func BatchCreateNote(ctx context.Context, list []*Note, batchSize int) error {
+	return NewNoteRepository(global.DB_).BatchCreate(ctx, list, batchSize)
+}
+
+// BatchUpdateNote 在一个事务中逐条 Update
+func BatchUpdateNote(ctx context.Context, list []*Note) error {
+	return NewNoteRepository(global.DB_).BatchUpdate(ctx, list)
+}
+
+// BatchDeleteNote 按主键批量删除
+func BatchDeleteNote(ctx context.Context, ids []int64) error {
+	return NewNoteRepository(global.DB_).BatchDelete(ctx, ids)
+}
+
+// GetNoteByIds 按主键批量查询
+func GetNoteByIds(ctx context.Context, ids []int64) (list []Note, err error) {
+	return NewNoteRepository(global.DB_).GetByIds(ctx, ids)
+}
+
+// RestoreNote 恢复软删除的记录
+func RestoreNote(ctx context.Context, a Note) error {
+	return NewNoteRepository(global.DB_).Restore(ctx, a)
+}
+
+// HardDeleteNote 永久删除，包括已软删除的记录
+func HardDeleteNote(ctx context.Context, a Note) error {
+	return NewNoteRepository(global.DB_).HardDelete(ctx, a)
+}
+
+// GetNoteListWithDeleted 分页查询，包括已软删除的记录
+func GetNoteListWithDeleted(ctx context.Context, info wkt.PageInfo, filter *NoteFilter) (list []Note, total int64, err error) {
+	return NewNoteRepository(global.DB_).GetListWithDeleted(ctx, info, filter)
+}
@@ -0,0 +1,34 @@
+-- Code generated by protoc-gen-simple. DO NOT EDIT.
+-- versions:
+-- - protoc-gen-simple v0.0.7
+-- - protoc          (unknown)
+-- source: wkt.proto
+-- dialect: mysql
+
+CREATE TABLE `events` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `created_at` datetime(3),
+  `updated_at` datetime(3),
+  `deleted_at` datetime(3),
+  `starts_at` datetime(3),
+  `length` bigint,
+  `nick` varchar(255),
+  `quota` bigint,
+  `blob` blob,
+  `flag` tinyint(1),
+  `meta` json,
+  `times` json,
+  PRIMARY KEY (`id`)
+);
+CREATE INDEX `idx_events_deleted_at` ON `events` (`deleted_at`);
+
+CREATE TABLE `notes` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `created_at` datetime(3),
+  `updated_at` datetime(3),
+  `deleted_at` datetime(3),
+  `body` varchar(255),
+  PRIMARY KEY (`id`)
+);
+CREATE INDEX `idx_notes_deleted_at` ON `notes` (`deleted_at`);
+
@@ -2,4 +2,4 @@
 
 protoc -I. -I${GOPATH}/src \
   --gogofast_out=. --gogofast_opt=paths=source_relative \
-  --simple_out=. --simple_opt=paths=source_relative helloworld.proto
+  --simple_out=. --simple_opt=paths=source_relative,model_suffix=Model helloworld.proto
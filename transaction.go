@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// generateServiceTx generates <Service>Tx, the repositories of every model of
+// file running in one transaction, and the transaction method of the service
+// used by its transactional methods. The transaction of a service with the
+// model model runs through its Repository, so that a fake repository needs no
+// database; the other repositories and the DB are then only set when the
+// repository is the gorm one. Services without a model run it on their DB.
+func generateServiceTx(g *protogen.GeneratedFile, file *protogen.File, serviceName string, model *protogen.Message) {
+	modelPath := modelPackage(file).importPath
+	gormDB := g.QualifiedGoIdent(GormPackage.Ident("DB"))
+	ctx := g.QualifiedGoIdent(contextPackage.Ident("Context"))
+	g.P(fmt.Sprintf(`// %[1]sTx holds the repositories of a transaction of %[1]s.
+		type %[1]sTx struct {
+			DB *%[2]s`, serviceName, gormDB))
+	var repos, others []string
+	for _, message := range file.Messages {
+		if isModel(message) {
+			name := modelName(message)
+			newRepo := g.QualifiedGoIdent(modelPath.Ident("New" + name + "Repository"))
+			g.P(fmt.Sprintf(`	%s %s`, name, g.QualifiedGoIdent(modelPath.Ident(name+"Repository"))))
+			repos = append(repos, fmt.Sprintf(`%s: %s(db),`, name, newRepo))
+			if message != model {
+				others = append(others, fmt.Sprintf(`tx.%s = %s(tx.DB)`, name, newRepo))
+			}
+		}
+	}
+	g.P("}")
+	g.P()
+	if model == nil {
+		g.P(fmt.Sprintf(`// transaction runs fn in a transaction of the database of the service,
+			// committed when fn returns nil.
+			func (s *%[1]s) transaction(ctx %[3]s, fn func(tx *%[1]sTx) error) error {
+				return s.db().WithContext(ctx).Transaction(func(db *%[2]s) error {
+					return fn(&%[1]sTx{
+						DB: db,
+						%[4]s
+					})
+				})
+			}
+		`, serviceName, gormDB, ctx, strings.Join(repos, "\n")))
+		return
+	}
+	name := modelName(model)
+	g.P(fmt.Sprintf(`// transaction runs fn in a transaction of the repository of the service,
+		// committed when fn returns nil.
+		func (s *%[1]s) transaction(ctx %[3]s, fn func(tx *%[1]sTx) error) error {
+			return s.repository().Transaction(ctx, func(repo %[5]s) error {
+				tx := &%[1]sTx{%[4]s: repo}
+				if r, ok := repo.(interface{ DB() *%[2]s }); ok {
+					tx.DB = r.DB()
+					%[6]s
+				}
+				return fn(tx)
+			})
+		}
+	`, serviceName, gormDB, ctx, name, g.QualifiedGoIdent(modelPath.Ident(name+"Repository")), strings.Join(others, "\n")))
+}
+
+// generateTransactionalMethod generates a method running body, or a TODO
+// when body is empty, in a transaction. The transaction is rolled back when
+// the method returns an error or, if the reply has a code, when the code is
+// not Success.
+func generateTransactionalMethod(g *protogen.GeneratedFile, file *protogen.File, method *protogen.Method, serviceName, body string) {
+	methodName := upperFirstLatter(method.GoName)
+	inType := g.QualifiedGoIdent(method.Input.GoIdent)
+	outType := g.QualifiedGoIdent(method.Output.GoIdent)
+	if body == "" {
+		body = `// TODO: add business logics using repos
+
+				// TODO: setting return values
+`
+	}
+	if messageField(method.Output, "Code") == nil {
+		g.P(fmt.Sprintf(`// %[1]s is server rpc method as defined
+			func (s *%[2]s) %[1]s(ctx context.Context, args *%[3]s, reply *%[4]s) (err error){
+				*reply = %[4]s{}
+				return s.transaction(ctx, func(repos *%[2]sTx) error {
+					%[5]s
+					return err
+				})
+			}
+		`, methodName, serviceName, inType, outType, body))
+		return
+	}
+	g.P(fmt.Sprintf(`// %[1]s is server rpc method as defined
+		func (s *%[2]s) %[1]s(ctx context.Context, args *%[3]s, reply *%[4]s) (err error){
+			*reply = %[4]s{}
+			rollback := %[6]s("rollback")
+			err = s.transaction(ctx, func(repos *%[2]sTx) error {
+				%[5]s
+				if reply.Code != %[7]s {
+					return rollback
+				}
+				return nil
+			})
+			if err == rollback {
+				return nil
+			}
+			return err
+		}
+	`, methodName, serviceName, inType, outType, body, g.QualifiedGoIdent(errorsPackage.Ident("New")),
+		g.QualifiedGoIdent(commonPackage(file).Ident("EnumCode_Success"))))
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateServiceTx(t *testing.T) {
+	tests := []struct {
+		name  string
+		model string
+		want  []string
+	}{{
+		// A fake Repository runs the transaction without a database.
+		name:  "model service",
+		model: "AccountModel",
+		want: []string{
+			`return s.repository().Transaction(ctx, func(repo model.AccountRepository) error {`,
+			`tx := &AccountTx{Account: repo}`,
+			`if r, ok := repo.(interface{ DB() *gorm.DB }); ok {`,
+			`tx.Ledger = model.NewLedgerRepository(tx.DB)`,
+		},
+	}, {
+		name: "service without a model",
+		want: []string{
+			`return s.db().WithContext(ctx).Transaction(func(db *gorm.DB) error {`,
+			`Account: model.NewAccountRepository(db),`,
+			`Ledger:  model.NewLedgerRepository(db),`,
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := testPlugin(t, testFile("tx", `message_type {
+				name: "AccountModel" options { [simple.model]: true }
+				field { name: "balance" number: 1 type: TYPE_INT64 }
+			}
+			message_type {
+				name: "LedgerModel" options { [simple.model]: true }
+				field { name: "amount" number: 1 type: TYPE_INT64 }
+			}`))
+			file := gen.Files[len(gen.Files)-1]
+			g := gen.NewGeneratedFile("account_service.go", "example.com/app/pb/tx/impl")
+			g.P("package impl")
+			if tt.model != "" {
+				generateServiceTx(g, file, "Account", testMessage(t, gen, tt.model))
+			} else {
+				generateServiceTx(g, file, "Account", nil)
+			}
+			b, err := g.Content()
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(b), want) {
+					t.Errorf("transaction has no %s:\n%s", want, b)
+				}
+			}
+		})
+	}
+}
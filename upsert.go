@@ -0,0 +1,216 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// upsertTarget returns the conflict target of Upsert and FindOrCreate: the
+// unique index with the upsert option, or else the single unique column or
+// unique index of message. It returns nil when there is none.
+func upsertTarget(message *protogen.Message) ([]*protogen.Field, error) {
+	indexes, err := modelIndexes(message)
+	if err != nil {
+		return nil, err
+	}
+	var target []*protogen.Field
+	for _, index := range indexes {
+		if !index.upsert {
+			continue
+		}
+		if target != nil {
+			return nil, fmt.Errorf("message %s: only one index can be the upsert target", message.Desc.FullName())
+		}
+		target = index.fields
+	}
+	if target != nil {
+		return target, nil
+	}
+	keys, err := uniqueKeys(message)
+	if err != nil || len(keys) != 1 {
+		return nil, err
+	}
+	return keys[0].fields, nil
+}
+
+// upsertColumns returns the columns Upsert updates when the row exists: the
+// columns a field mask can update except the conflict target, plus the
+// updated_at and deleted_at columns of the base model. An upsert revives a
+// soft deleted row.
+func upsertColumns(message *protogen.Message, target []*protogen.Field) ([]string, error) {
+	paths, err := maskPaths(message)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	for _, field := range target {
+		seen[columnName(field)] = true
+	}
+	var columns []string
+	for _, p := range paths {
+		for _, column := range p.columns {
+			if !seen[column] {
+				seen[column] = true
+				columns = append(columns, column)
+			}
+		}
+	}
+	if hasBaseModel(message) {
+		columns = append(columns, "updated_at")
+		if softDelete, err := hasSoftDelete(message); err != nil {
+			return nil, err
+		} else if softDelete {
+			columns = append(columns, "deleted_at")
+		}
+	}
+	return columns, nil
+}
+
+// upsertMethods returns the Upsert and FindOrCreate repository methods of the
+// model of message on the conflict target, or nil when it has no target.
+func upsertMethods(g *protogen.GeneratedFile, message *protogen.Message, modelName string, preload bool) ([]repositoryMethod, error) {
+	target, err := upsertTarget(message)
+	if err != nil || target == nil {
+		return nil, err
+	}
+	columns, err := upsertColumns(message, target)
+	if err != nil {
+		return nil, err
+	}
+	var conflict, conds, values []string
+	for _, field := range target {
+		conflict = append(conflict, fmt.Sprintf("{Name: %q}", columnName(field)))
+		conds = append(conds, columnName(field)+" = ?")
+		values = append(values, "a."+field.GoName)
+	}
+	onConflict := g.QualifiedGoIdent(clausePackage.Ident("OnConflict"))
+	conflictColumns := fmt.Sprintf("Columns: []%s{%s},", g.QualifiedGoIdent(clausePackage.Ident("Column")), strings.Join(conflict, ", "))
+	var quoted []string
+	for _, column := range columns {
+		quoted = append(quoted, strconv.Quote(column))
+	}
+	doUpdates := fmt.Sprintf("%s([]string{%s})", g.QualifiedGoIdent(clausePackage.Ident("AssignmentColumns")), strings.Join(quoted, ", "))
+	if isLockedModel(message) {
+		// The version of the existing row is incremented as by Update.
+		field, err := versionField(message)
+		if err != nil {
+			return nil, err
+		}
+		assignment := fmt.Sprintf(`%s{Column: %s{Name: %q}, Value: %s("%[3]s + 1")}`, g.QualifiedGoIdent(clausePackage.Ident("Assignment")),
+			g.QualifiedGoIdent(clausePackage.Ident("Column")), columnName(field), g.QualifiedGoIdent(GormPackage.Ident("Expr")))
+		if len(columns) == 0 {
+			doUpdates = fmt.Sprintf("%s{%s}", g.QualifiedGoIdent(clausePackage.Ident("Set")), assignment)
+		} else {
+			doUpdates = fmt.Sprintf("append(%s, %s)", doUpdates, assignment)
+		}
+		columns = append(columns, columnName(field))
+	}
+	doUpdates = "DoUpdates: " + doUpdates + ","
+	if len(columns) == 0 {
+		doUpdates = "DoNothing: true,"
+	}
+	softDelete, err := hasSoftDelete(message)
+	if err != nil {
+		return nil, err
+	}
+	query, revive := "r.db.WithContext(ctx)", ""
+	findOrCreateDoc := "按唯一索引查询，不存在时创建；a 为查询或创建的记录"
+	if softDelete {
+		findOrCreateDoc += "，已软删除的记录会被恢复"
+		// The conflicting row may be soft deleted, it is then restored.
+		query += ".Unscoped()"
+		revive = fmt.Sprintf(`if found.DeletedAt.Valid {
+				if err = r.db.WithContext(ctx).Unscoped().Model(&found).Update("deleted_at", nil).Error; err != nil {
+					return false, err
+				}
+				found.DeletedAt = %s{}
+			}
+			`, g.QualifiedGoIdent(GormPackage.Ident("DeletedAt")))
+	}
+	if preload {
+		query = "Preload" + modelName + "(" + query + ")"
+	}
+	ctx := g.QualifiedGoIdent(contextPackage.Ident("Context"))
+	omit := omitAssociations(g, preload)
+	return []repositoryMethod{{
+		name:    "Upsert",
+		doc:     "按唯一索引创建或修改",
+		params:  fmt.Sprintf("ctx %s, a *%s", ctx, modelName),
+		results: "error",
+		body: fmt.Sprintf(`return r.db.WithContext(ctx)%s.Clauses(%s{
+				%s
+				%s
+			}).Create(a).Error`, omit, onConflict, conflictColumns, doUpdates),
+		args:     "ctx, a",
+		funcName: "Upsert" + modelName,
+	}, {
+		name:    "FindOrCreate",
+		doc:     findOrCreateDoc,
+		params:  fmt.Sprintf("ctx %s, a *%s", ctx, modelName),
+		results: "(created bool, err error)",
+		body: fmt.Sprintf(`result := r.db.WithContext(ctx)%s.Clauses(%s{
+				%s
+				DoNothing: true,
+			}).Create(a)
+			if result.Error != nil || result.RowsAffected == 1 {
+				return result.Error == nil, result.Error
+			}
+			var found %s
+			if err = %s.Where(%q, %s).First(&found).Error; err != nil {
+				return false, err
+			}
+			%s*a = found
+			return false, nil`, omit, onConflict, conflictColumns, modelName, query, strings.Join(conds, " AND "), strings.Join(values, ", "), revive),
+		args:     "ctx, a",
+		funcName: "FindOrCreate" + modelName,
+	}}, nil
+}
+
+// upsertMethodBody returns the statements implementing method with the
+// Upsert and FindOrCreate conventions for the service model, using the model
+// repository repo. It returns "" when the method follows neither.
+// FindOrCreate sets the Data of the reply to the found or created row and its
+// Created to whether it was created, when the reply has these fields.
+func upsertMethodBody(g *protogen.GeneratedFile, file *protogen.File, model *protogen.Message, method *protogen.Method, repo string) (string, error) {
+	name := upperFirstLatter(method.GoName)
+	if !strings.HasPrefix(name, "Upsert") && !strings.HasPrefix(name, "FindOrCreate") {
+		return "", nil
+	}
+	target, err := upsertTarget(model)
+	if err != nil {
+		return "", err
+	}
+	if target == nil {
+		return "", fmt.Errorf("method %s: %s requires a unique index with upsert: true, or a single unique column or index, of %s", method.Desc.FullName(), name, model.Desc.FullName())
+	}
+	commonPath := commonPackage(file)
+	toModel := g.QualifiedGoIdent(modelPackage(file).importPath.Ident(modelName(model) + "ProtoToModel"))
+	success := g.QualifiedGoIdent(commonPath.Ident("EnumCode_Success"))
+	createError := g.QualifiedGoIdent(commonPath.Ident("EnumCode_CreateError"))
+	if strings.HasPrefix(name, "Upsert") {
+		return fmt.Sprintf(`if err = %s.Upsert(ctx, %s(args));err == nil{
+				reply.Code = %s
+			}else{
+				reply.Code = %s
+			}
+`, repo, toModel, success, createError), nil
+	}
+	created, set := "_", ""
+	if f := messageField(method.Output, "Data"); f != nil && f.Message == model && !f.Desc.IsList() {
+		set += "reply.Data = data.Proto()\n"
+	}
+	if f := messageField(method.Output, "Created"); f != nil && f.Desc.Kind() == protoreflect.BoolKind && !f.Desc.IsList() {
+		created, set = "created", set+"reply.Created = created\n"
+	}
+	return fmt.Sprintf(`data := %s(args)
+			if %s,err := %s.FindOrCreate(ctx, data);err == nil{
+				%sreply.Code = %s
+			}else{
+				reply.Code = %s
+			}
+`, toModel, created, repo, set, success, createError), nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFindOrCreateSoftDeleted(t *testing.T) {
+	tests := []struct {
+		name    string
+		options string
+		revive  bool
+	}{
+		{"soft delete", `[simple.model]: true`, true},
+		{"no soft delete", `[simple.table] { soft_delete: false }`, false},
+		{"no base model", `[simple.table] { base_model: false primary_key: "code" }`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := testPlugin(t, testFile("upsert", `message_type {
+				name: "TagModel" options { `+tt.options+` }
+				field { name: "code" number: 1 type: TYPE_STRING options { [simple.column] { unique: true } } }
+				field { name: "label" number: 2 type: TYPE_STRING }
+			}`))
+			file := gen.Files[len(gen.Files)-1]
+			g := gen.NewGeneratedFile("upsert_model.go", file.GoImportPath)
+			methods, err := upsertMethods(g, testMessage(t, gen, "TagModel"), "Tag", false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var body string
+			for _, m := range methods {
+				if m.name == "FindOrCreate" {
+					body = m.body
+				}
+			}
+			// The row conflicting with the insert is looked up with the soft
+			// deleted ones, and restored.
+			lookup := strings.Contains(body, `r.db.WithContext(ctx).Unscoped().Where("code = ?", a.Code).First(&found)`)
+			restore := strings.Contains(body, `if found.DeletedAt.Valid {`) &&
+				strings.Contains(body, `r.db.WithContext(ctx).Unscoped().Model(&found).Update("deleted_at", nil)`)
+			if lookup != tt.revive || restore != tt.revive {
+				t.Errorf("FindOrCreate restores soft deleted rows = %v, %v, want %v:\n%s", lookup, restore, tt.revive, body)
+			}
+		})
+	}
+}
@@ -2,20 +2,44 @@ package main
 
 import (
 	"fmt"
-	"strings"
+	"path"
 
 	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
-func generateTableFile(gen *protogen.Plugin, file *protogen.File, message *protogen.Message) *protogen.GeneratedFile {
-	name := string(message.Desc.Name())
-	// fullName := string(message.Desc.FullName())
+func init() {
+	registerGenerator(&generator{
+		name:        "vue",
+		description: "element-ui table page for every model message (*.vue)",
+		defaultOn:   true,
+		generate: func(gen *protogen.Plugin, file *protogen.File) error {
+			for _, message := range file.Messages {
+				if isModel(message) {
+					generateTableFile(gen, file, message)
+				}
+			}
+			return nil
+		},
+	})
+	registerGenerator(&generator{
+		name:        "js",
+		description: "api request functions for every service (*.js)",
+		defaultOn:   true,
+		generate: func(gen *protogen.Plugin, file *protogen.File) error {
+			for _, service := range file.Services {
+				generateApiCode(gen, file, service)
+			}
+			return nil
+		},
+	})
+}
 
-	afterName, _ := strings.CutSuffix(name, "Model")
+func generateTableFile(gen *protogen.Plugin, file *protogen.File, message *protogen.Message) *protogen.GeneratedFile {
+	afterName := modelName(message)
 
 	lowerName := lowerFirstLatter(afterName)
-	filename := lowerName + ".vue"
+	filename := path.Join(*vueDirFlag, lowerName+".vue")
 	g := gen.NewGeneratedFile(filename, file.GoImportPath)
 	g.P(`<template>
   <div class="app-container">
@@ -41,10 +65,7 @@ func generateTableFile(gen *protogen.Plugin, file *protogen.File, message *proto
       </el-table-column>
       <el-table-column label="UpdatedAt" width="150px" align="center" prop="updatedAt">
       </el-table-column>`)
-	for _, field := range message.Fields {
-		if field.GoName == "Id" || field.GoName == "CreatedAt" || field.GoName == "UpdatedAt" || field.GoName == "DeletedAt" {
-			continue
-		}
+	for _, field := range modelFields(message) {
 		generateTableColumnFiled(g, field)
 
 	}
@@ -68,8 +89,9 @@ func generateTableFile(gen *protogen.Plugin, file *protogen.File, message *proto
     <el-dialog :title="textMap[dialogStatus]" :visible.sync="dialogFormVisible">
       <el-form ref="dataForm" :rules="rules" :model="temp" label-position="left" label-width="120px"
         style="width: 450px; margin-left:50px;">`)
-	for _, field := range message.Fields {
-		if field.GoName == "Id" || field.GoName == "CreatedAt" || field.GoName == "UpdatedAt" || field.GoName == "DeletedAt" {
+	for _, field := range modelFields(message) {
+		if isLockedModel(message) && field.Desc.Name() == "version" {
+			// The version read by handleUpdate is sent back unchanged.
 			continue
 		}
 		generateFormFiled(g, field)
@@ -108,10 +130,7 @@ export default {
         createdAt: '',
         updatedAt: '',
 `, afterName, lowerName))
-	for _, field := range message.Fields {
-		if field.GoName == "Id" || field.GoName == "CreatedAt" || field.GoName == "UpdatedAt" || field.GoName == "DeletedAt" {
-			continue
-		}
+	for _, field := range modelFields(message) {
 		generateTempFiled(g, field)
 	}
 	g.P(`      },
@@ -148,12 +167,22 @@ export default {
         id: undefined,
         createdAt: '',
         updatedAt: '',`)
-	for _, field := range message.Fields {
-		if field.GoName == "Id" || field.GoName == "CreatedAt" || field.GoName == "UpdatedAt" || field.GoName == "DeletedAt" {
-			continue
-		}
+	for _, field := range modelFields(message) {
 		generateTempFiled(g, field)
 	}
+	conflict := ""
+	if isLockedModel(message) {
+		conflict = ` else if (res.code === 'VersionConflict') {
+            this.$notify({
+              title: 'Warning',
+              message: '记录已被修改，请刷新后重试',
+              type: 'warning',
+              duration: 2000
+            })
+            this.dialogFormVisible = false
+            this.getTableData()
+          }`
+	}
 	g.P(fmt.Sprintf(`      }
     },
     handleCreate() {
@@ -206,7 +235,7 @@ export default {
               duration: 2000
             })
             this.getTableData()
-          }
+          }%[2]s
 
         }
       })
@@ -218,7 +247,7 @@ export default {
   }
 }
 </script>
-`, afterName))
+`, afterName, conflict))
 	return g
 }
 
@@ -288,7 +317,7 @@ func generateTempFiled(g *protogen.GeneratedFile, field *protogen.Field) {
 func generateApiCode(gen *protogen.Plugin, file *protogen.File, service *protogen.Service) {
 	serviceName := upperFirstLatter(service.GoName)
 
-	filename := lowerFirstLatter(serviceName) + ".js"
+	filename := path.Join(*jsDirFlag, lowerFirstLatter(serviceName)+".js")
 	g := gen.NewGeneratedFile(filename, file.GoImportPath)
 	g.P(`import request from '@/utils/request'
 import protoRoot from '@/proto/proto.js'
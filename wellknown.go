@@ -0,0 +1,159 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var timeLayoutFlag = flag.String("time_layout", "", "layout of string timestamps such as created_at (default: time.DateTime)")
+
+const (
+	timestampName protoreflect.FullName = "google.protobuf.Timestamp"
+	durationName  protoreflect.FullName = "google.protobuf.Duration"
+	structName    protoreflect.FullName = "google.protobuf.Struct"
+)
+
+// wrapperTypes maps the wrapper messages to the kind of their value and the
+// name of their wrapperspb constructor.
+var wrapperTypes = map[protoreflect.FullName]struct {
+	kind        protoreflect.Kind
+	constructor string
+}{
+	"google.protobuf.DoubleValue": {protoreflect.DoubleKind, "Double"},
+	"google.protobuf.FloatValue":  {protoreflect.FloatKind, "Float"},
+	"google.protobuf.Int64Value":  {protoreflect.Int64Kind, "Int64"},
+	"google.protobuf.UInt64Value": {protoreflect.Uint64Kind, "UInt64"},
+	"google.protobuf.Int32Value":  {protoreflect.Int32Kind, "Int32"},
+	"google.protobuf.UInt32Value": {protoreflect.Uint32Kind, "UInt32"},
+	"google.protobuf.BoolValue":   {protoreflect.BoolKind, "Bool"},
+	"google.protobuf.StringValue": {protoreflect.StringKind, "String"},
+	"google.protobuf.BytesValue":  {protoreflect.BytesKind, "Bytes"},
+}
+
+// isWellKnownColumn reports whether field is a singular well-known type
+// stored as a plain column: Timestamp, Duration and the wrappers.
+func isWellKnownColumn(field *protogen.Field) bool {
+	if field.Message == nil || field.Desc.IsList() || field.Desc.IsMap() {
+		return false
+	}
+	switch name := field.Message.Desc.FullName(); name {
+	case timestampName, durationName:
+		return true
+	default:
+		_, ok := wrapperTypes[name]
+		return ok
+	}
+}
+
+// isStructField reports whether field is a singular google.protobuf.Struct.
+func isStructField(field *protogen.Field) bool {
+	return field.Message != nil && !field.Desc.IsList() && !field.Desc.IsMap() && field.Message.Desc.FullName() == structName
+}
+
+// wellKnownColumnType returns the column type of a field accepted by
+// isWellKnownColumn. Durations and wrappers become nullable pointer columns,
+// keeping an unset field apart from a zero value.
+func wellKnownColumnType(g *protogen.GeneratedFile, field *protogen.Field) columnType {
+	switch name := field.Message.Desc.FullName(); name {
+	case timestampName:
+		return columnType{g.QualifiedGoIdent(TimePackage.Ident("Time")), "datetime(3)"}
+	case durationName:
+		return columnType{"*" + g.QualifiedGoIdent(TimePackage.Ident("Duration")), "bigint"}
+	default:
+		ct := kindColumnTypes[wrapperTypes[name].kind]
+		ct.goType = "*" + ct.goType
+		return ct
+	}
+}
+
+// wellKnownConverter converts a field accepted by isWellKnownColumn.
+func wellKnownConverter(g *protogen.GeneratedFile, field *protogen.Field) fieldConverter {
+	name := field.GoName
+	switch field.Message.Desc.FullName() {
+	case timestampName:
+		return timestampConverter(g, name, "model."+name)
+	case durationName:
+		return pointerConverter(name, g.QualifiedGoIdent(durationpbPackage.Ident("New")), "proto."+name+".AsDuration()")
+	case "google.protobuf.BytesValue":
+		// A nil slice would be written as NULL, so an empty value is copied
+		// into a non-nil one.
+		return pointerConverter(name, g.QualifiedGoIdent(wrapperspbPackage.Ident("Bytes")), "append([]byte{}, proto."+name+".GetValue()...)")
+	}
+	return pointerConverter(name, g.QualifiedGoIdent(wrapperspbPackage.Ident(wrapperTypes[field.Message.Desc.FullName()].constructor)), "proto."+name+".GetValue()")
+}
+
+// pointerConverter converts a pointer model value to and from the message
+// field name, created by constructor and read by the expression value. A nil
+// value stays an unset field.
+func pointerConverter(name, constructor, value string) fieldConverter {
+	return fieldConverter{
+		goName: name,
+		toProtoStmt: fmt.Sprintf(`if model.%[1]s != nil {
+			proto.%[1]s = %[2]s(*model.%[1]s)
+		}`, name, constructor),
+		toModelStmt: fmt.Sprintf(`if proto.%[1]s != nil {
+			v := %[2]s
+			model.%[1]s = &v
+		}`, name, value),
+	}
+}
+
+// structConverter converts a google.protobuf.Struct field stored as a JSON
+// column of type map[string]interface{}.
+func structConverter(g *protogen.GeneratedFile, field *protogen.Field) fieldConverter {
+	return fieldConverter{
+		goName: field.GoName,
+		toProtoStmt: fmt.Sprintf(`if model.%[1]s != nil {
+			proto.%[1]s, _ = %[2]s(model.%[1]s)
+		}`, field.GoName, g.QualifiedGoIdent(structpbPackage.Ident("NewStruct"))),
+		toModelStmt: fmt.Sprintf(`if proto.%[1]s != nil {
+			model.%[1]s = proto.%[1]s.AsMap()
+		}`, field.GoName),
+	}
+}
+
+// timestampConverter converts the time.Time model value modelExpr to and from
+// the google.protobuf.Timestamp proto field name.
+func timestampConverter(g *protogen.GeneratedFile, name, modelExpr string) fieldConverter {
+	return fieldConverter{
+		goName: name,
+		toProtoStmt: fmt.Sprintf(`if !%[2]s.IsZero() {
+			proto.%[1]s = %[3]s(%[2]s)
+		}`, name, modelExpr, g.QualifiedGoIdent(timestamppbPackage.Ident("New"))),
+		toModelStmt: fmt.Sprintf(`if proto.%[1]s != nil {
+			%[2]s = proto.%[1]s.AsTime()
+		}`, name, modelExpr),
+	}
+}
+
+// timeStringConverter converts the time.Time model value modelExpr to and
+// from the string proto field name, formatted with the time_layout parameter.
+func timeStringConverter(g *protogen.GeneratedFile, name, modelExpr string) fieldConverter {
+	layout := g.QualifiedGoIdent(TimePackage.Ident("DateTime"))
+	if *timeLayoutFlag != "" {
+		layout = strconv.Quote(*timeLayoutFlag)
+	}
+	return fieldConverter{
+		goName:  name,
+		toProto: fmt.Sprintf("%s.Format(%s)", modelExpr, layout),
+		toModelStmt: fmt.Sprintf(`if t, err := %[3]s(%[4]s, proto.%[1]s); err == nil {
+			%[2]s = t
+		}`, name, modelExpr, g.QualifiedGoIdent(TimePackage.Ident("Parse")), layout),
+	}
+}
+
+// timeConverter converts a time.Time model value to and from field, which is
+// either a google.protobuf.Timestamp or a string.
+func timeConverter(g *protogen.GeneratedFile, field *protogen.Field, modelExpr string) (fieldConverter, error) {
+	switch {
+	case field.Message != nil && field.Message.Desc.FullName() == timestampName:
+		return timestampConverter(g, field.GoName, modelExpr), nil
+	case field.Desc.Kind() == protoreflect.StringKind && !field.Desc.IsList():
+		return timeStringConverter(g, field.GoName, modelExpr), nil
+	}
+	return fieldConverter{}, fmt.Errorf("field %s: time fields must be google.protobuf.Timestamp or string", field.Desc.FullName())
+}
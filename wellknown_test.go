@@ -0,0 +1,74 @@
+package main
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+func TestWellKnownColumn(t *testing.T) {
+	gen := testPlugin(t, testFile("wkt", `message_type {
+		name: "EventModel" options { [simple.model]: true }
+		field { name: "length" number: 1 type: TYPE_MESSAGE type_name: ".google.protobuf.Duration" }
+		field { name: "blob" number: 2 type: TYPE_MESSAGE type_name: ".google.protobuf.BytesValue" }
+		field { name: "quota" number: 3 type: TYPE_MESSAGE type_name: ".google.protobuf.Int64Value" }
+	}`))
+	file := gen.Files[len(gen.Files)-1]
+	g := gen.NewGeneratedFile("wkt_model.go", file.GoImportPath)
+	tests := []struct {
+		field   string
+		goType  string
+		toProto string
+		toModel string
+	}{{
+		field:  "length",
+		goType: "*time.Duration",
+		toProto: `if model.Length != nil {
+			proto.Length = durationpb.New(*model.Length)
+		}`,
+		toModel: `if proto.Length != nil {
+			v := proto.Length.AsDuration()
+			model.Length = &v
+		}`,
+	}, {
+		// An empty value is kept apart from NULL.
+		field:  "blob",
+		goType: "*[]byte",
+		toProto: `if model.Blob != nil {
+			proto.Blob = wrapperspb.Bytes(*model.Blob)
+		}`,
+		toModel: `if proto.Blob != nil {
+			v := append([]byte{}, proto.Blob.GetValue()...)
+			model.Blob = &v
+		}`,
+	}, {
+		field:  "quota",
+		goType: "*int64",
+		toProto: `if model.Quota != nil {
+			proto.Quota = wrapperspb.Int64(*model.Quota)
+		}`,
+		toModel: `if proto.Quota != nil {
+			v := proto.Quota.GetValue()
+			model.Quota = &v
+		}`,
+	}}
+	message := testMessage(t, gen, "EventModel")
+	for _, tt := range tests {
+		var field *protogen.Field
+		for _, f := range message.Fields {
+			if string(f.Desc.Name()) == tt.field {
+				field = f
+			}
+		}
+		if ct := wellKnownColumnType(g, field); ct.goType != tt.goType {
+			t.Errorf("%s column type = %s, want %s", tt.field, ct.goType, tt.goType)
+		}
+		c := wellKnownConverter(g, field)
+		if c.toProto != "" || c.toProtoStmt != tt.toProto {
+			t.Errorf("%s to proto = %q, %q, want %q", tt.field, c.toProto, c.toProtoStmt, tt.toProto)
+		}
+		if c.toModel != "" || c.toModelStmt != tt.toModel {
+			t.Errorf("%s to model = %q, %q, want %q", tt.field, c.toModel, c.toModelStmt, tt.toModel)
+		}
+	}
+}